@@ -7,6 +7,7 @@ require (
 	github.com/gorilla/mux v1.8.1
 )
 require (
+	github.com/DATA-DOG/go-sqlmock v1.5.2
 	github.com/golang-jwt/jwt/v5 v5.2.2
 	github.com/joho/godotenv v1.5.1
 	golang.org/x/crypto v0.37.0
@@ -0,0 +1,219 @@
+package handler
+
+import (
+	"math"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"myapp/internal/accommodation/model"
+	"myapp/internal/apperr"
+	facilityModel "myapp/internal/facility/model"
+	"myapp/internal/geo"
+	"myapp/internal/storage"
+)
+
+// AccommodationRequest คือ body ของ POST /accommodations
+// main_image ไม่รับจาก body; ตั้งผ่าน PUT /images/{id}/cover เท่านั้น
+type AccommodationRequest struct {
+	Name              string   `json:"name" validate:"required,max=255"`
+	VillageID         int64    `json:"village_id" validate:"required,gte=1"`
+	About             string   `json:"about" validate:"max=10000"`
+	PopularFacilities string   `json:"popular_facilities" validate:"max=2000"`
+	PricePerNight     float64  `json:"price_per_night" validate:"gte=0,lte=10000000"`
+	Latitude          *float64 `json:"latitude" validate:"required,gte=-90,lte=90"`
+	Longitude         *float64 `json:"longitude" validate:"required,gte=-180,lte=180"`
+}
+
+// UpdateAccommodationRequest คือ body ของ PUT /accommodations (ระบุ id ใน body)
+type UpdateAccommodationRequest struct {
+	ID int64 `json:"id" validate:"required,gte=1"`
+	AccommodationRequest
+}
+
+func (req AccommodationRequest) toModel() model.Accommodation {
+	return model.Accommodation{
+		Name:              req.Name,
+		VillageID:         req.VillageID,
+		About:             req.About,
+		PopularFacilities: req.PopularFacilities,
+		PricePerNight:     math.Round(req.PricePerNight*100) / 100,
+		Latitude:          *req.Latitude,
+		Longitude:         *req.Longitude,
+	}
+}
+
+type AccommodationResponse struct {
+	ID                int64              `json:"id"`
+	HostID            *int64             `json:"host_id"`
+	Name              string             `json:"name"`
+	MainImage         string             `json:"main_image"`
+	MainImageURL      string             `json:"main_image_url"`
+	VillageID         int64              `json:"village_id"`
+	About             string             `json:"about"`
+	PopularFacilities string             `json:"popular_facilities"`
+	PricePerNight     float64            `json:"price_per_night"`
+	Latitude          float64            `json:"latitude"`
+	Longitude         float64            `json:"longitude"`
+	RatingAvg         float64            `json:"rating_avg"`
+	RatingCount       int                `json:"rating_count"`
+	CreatedAt         time.Time          `json:"createdAt"`
+	UpdatedAt         time.Time          `json:"updatedAt"`
+	Facilities        []FacilityResponse `json:"facilities"`
+	Location          *LocationResponse  `json:"location,omitempty"`
+}
+
+type FacilityResponse struct {
+	Code     string `json:"code"`
+	Name     string `json:"name"`
+	Category string `json:"category"`
+	Icon     string `json:"icon"`
+}
+
+func newFacilityResponses(list []model.Facility) []FacilityResponse {
+	res := make([]FacilityResponse, 0, len(list))
+	for _, f := range list {
+		res = append(res, FacilityResponse{Code: f.Code, Name: f.Name, Category: f.Category, Icon: f.Icon})
+	}
+	return res
+}
+
+// SetFacilitiesRequest คือ body ของ PUT /accommodations/{id}/facilities; ส่ง [] เพื่อล้างทั้งหมด
+type SetFacilitiesRequest struct {
+	Facilities []string `json:"facilities" validate:"max=100"`
+}
+
+// codes คืน code ที่ normalize แล้วและไม่ซ้ำกัน
+func (req SetFacilitiesRequest) codes() ([]string, error) {
+	return normalizeCodes(req.Facilities, "facilities")
+}
+
+// LocationResponse แสดงเมื่อขอ ?include=location
+type LocationResponse struct {
+	Village  LocationName `json:"village"`
+	District LocationName `json:"district"`
+	Province LocationName `json:"province"`
+}
+
+type LocationName struct {
+	ID   int64  `json:"id"`
+	Name string `json:"name"`
+}
+
+func newAccommodationResponse(a model.Accommodation, files storage.Storage) AccommodationResponse {
+	var loc *LocationResponse
+	if l := a.Location; l != nil {
+		loc = &LocationResponse{
+			Village:  LocationName{ID: l.VillageID, Name: l.VillageName},
+			District: LocationName{ID: l.DistrictID, Name: l.DistrictName},
+			Province: LocationName{ID: l.ProvinceID, Name: l.ProvinceName},
+		}
+	}
+	return AccommodationResponse{
+		ID:                a.ID,
+		HostID:            a.HostID,
+		Name:              a.Name,
+		MainImage:         a.MainImage,
+		MainImageURL:      files.URL(a.MainImage),
+		VillageID:         a.VillageID,
+		About:             a.About,
+		PopularFacilities: a.PopularFacilities,
+		PricePerNight:     a.PricePerNight,
+		Latitude:          a.Latitude,
+		Longitude:         a.Longitude,
+		RatingAvg:         math.Round(a.RatingAvg()*100) / 100,
+		RatingCount:       a.RatingCount,
+		CreatedAt:         a.CreatedAt,
+		UpdatedAt:         a.UpdatedAt,
+		Facilities:        newFacilityResponses(a.Facilities),
+		Location:          loc,
+	}
+}
+
+// includeLocation คือค่าของ ?include= ที่ขอให้ฝังชื่อ village/district/province
+const includeLocation = "location"
+
+const (
+	defaultNearbyRadiusKm = 10.0
+	maxNearbyRadiusKm     = 100.0
+)
+
+type NearbyAccommodationResponse struct {
+	AccommodationResponse
+	DistanceKm float64 `json:"distance_km"`
+}
+
+func newNearbyAccommodationResponse(n model.NearbyAccommodation, files storage.Storage) NearbyAccommodationResponse {
+	return NearbyAccommodationResponse{
+		AccommodationResponse: newAccommodationResponse(n.Accommodation, files),
+		DistanceKm:            math.Round(n.DistanceKm*1000) / 1000,
+	}
+}
+
+// maxFacilityFilter คือจำนวน code สูงสุดใน ?facilities=
+const maxFacilityFilter = 20
+
+// parseFacilities อ่าน ?facilities=wifi,parking; คืน nil เมื่อไม่ได้ส่งมา
+func parseFacilities(raw string) ([]string, error) {
+	if strings.TrimSpace(raw) == "" {
+		return nil, nil
+	}
+	codes, err := normalizeCodes(strings.Split(raw, ","), "facilities")
+	if err != nil {
+		return nil, err
+	}
+	if len(codes) > maxFacilityFilter {
+		return nil, apperr.Validation("Invalid facilities", map[string]string{
+			"facilities": "must list at most " + strconv.Itoa(maxFacilityFilter) + " codes",
+		})
+	}
+	return codes, nil
+}
+
+// normalizeCodes ตัดช่องว่าง/แปลงเป็นตัวเล็ก/ตัดค่าซ้ำ และตรวจรูปแบบของ facility code
+func normalizeCodes(raw []string, field string) ([]string, error) {
+	codes := make([]string, 0, len(raw))
+	for _, c := range raw {
+		c = strings.ToLower(strings.TrimSpace(c))
+		if c == "" || slices.Contains(codes, c) {
+			continue
+		}
+		if !facilityModel.CodeRe.MatchString(c) {
+			return nil, apperr.Validation("Invalid "+field, map[string]string{
+				field: "must contain facility codes made of lowercase letters, digits and underscores",
+			})
+		}
+		codes = append(codes, c)
+	}
+	return codes, nil
+}
+
+// parseBBox อ่าน "min_lng,min_lat,max_lng,max_lat" (ลำดับเดียวกับ GeoJSON); คืน nil เมื่อไม่ได้ส่งมา
+func parseBBox(raw string) (*geo.Box, error) {
+	if raw == "" {
+		return nil, nil
+	}
+	invalid := apperr.Validation("Invalid bbox", map[string]string{
+		"bbox": "must be min_lng,min_lat,max_lng,max_lat with valid coordinates",
+	})
+
+	parts := strings.Split(raw, ",")
+	if len(parts) != 4 {
+		return nil, invalid
+	}
+	var v [4]float64
+	for i, p := range parts {
+		f, err := strconv.ParseFloat(strings.TrimSpace(p), 64)
+		if err != nil || math.IsNaN(f) {
+			return nil, invalid
+		}
+		v[i] = f
+	}
+	b := geo.Box{MinLng: v[0], MinLat: v[1], MaxLng: v[2], MaxLat: v[3]}
+	if b.MinLat < -90 || b.MaxLat > 90 || b.MinLat > b.MaxLat ||
+		b.MinLng < -180 || b.MinLng > 180 || b.MaxLng < -180 || b.MaxLng > 180 {
+		return nil, invalid
+	}
+	return &b, nil
+}
@@ -0,0 +1,69 @@
+package handler
+
+import (
+	"testing"
+
+	"myapp/internal/apperr"
+	"myapp/internal/geo"
+)
+
+func TestParseBBox(t *testing.T) {
+	tests := []struct {
+		name string
+		raw  string
+		want *geo.Box
+	}{
+		{"empty means no filter", "", nil},
+		{"ordinary box", "100.3,13.5,100.9,14.0", &geo.Box{MinLng: 100.3, MinLat: 13.5, MaxLng: 100.9, MaxLat: 14.0}},
+		{"spaces around values", " 100.3 , 13.5 ,100.9, 14.0 ", &geo.Box{MinLng: 100.3, MinLat: 13.5, MaxLng: 100.9, MaxLat: 14.0}},
+		{"crosses the antimeridian", "170,-20,-170,-10", &geo.Box{MinLng: 170, MinLat: -20, MaxLng: -170, MaxLat: -10}},
+		{"whole world", "-180,-90,180,90", &geo.Box{MinLng: -180, MinLat: -90, MaxLng: 180, MaxLat: 90}},
+		{"single point", "100.5,13.7,100.5,13.7", &geo.Box{MinLng: 100.5, MinLat: 13.7, MaxLng: 100.5, MaxLat: 13.7}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseBBox(tt.raw)
+			if err != nil {
+				t.Fatalf("parseBBox(%q) error = %v", tt.raw, err)
+			}
+			if (got == nil) != (tt.want == nil) || (got != nil && *got != *tt.want) {
+				t.Errorf("parseBBox(%q) = %+v, want %+v", tt.raw, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseBBoxRejects(t *testing.T) {
+	tests := []struct {
+		name string
+		raw  string
+	}{
+		{"too few values", "100.3,13.5,100.9"},
+		{"too many values", "100.3,13.5,100.9,14.0,1"},
+		{"only commas", ",,,"},
+		{"not a number", "100.3,abc,100.9,14.0"},
+		{"nan", "NaN,13.5,100.9,14.0"},
+		{"min_lat below -90", "100.3,-90.1,100.9,14.0"},
+		{"max_lat above 90", "100.3,13.5,100.9,90.1"},
+		{"min_lng below -180", "-180.1,13.5,100.9,14.0"},
+		{"min_lng above 180", "180.1,13.5,100.9,14.0"},
+		{"max_lng below -180", "100.3,13.5,-180.1,14.0"},
+		{"max_lng above 180", "100.3,13.5,180.1,14.0"},
+		{"infinite", "100.3,13.5,+Inf,14.0"},
+		{"min_lat greater than max_lat", "100.3,14.0,100.9,13.5"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseBBox(tt.raw)
+			if err == nil {
+				t.Fatalf("parseBBox(%q) = %+v, want error", tt.raw, got)
+			}
+			e, ok := apperr.As(err)
+			if !ok || e.Fields["bbox"] == "" {
+				t.Errorf("parseBBox(%q) error = %v, want validation error on bbox", tt.raw, err)
+			}
+		})
+	}
+}
@@ -1,64 +1,256 @@
 package handler
 
 import (
-	"encoding/json"
+	"context"
 	"myapp/internal/accommodation/model"
 	"myapp/internal/accommodation/usecase"
+	"myapp/internal/apperr"
+	"myapp/internal/auth"
+	"myapp/internal/database"
+	"myapp/internal/geo"
+	"myapp/internal/httpx"
+	"myapp/internal/storage"
 
 	"net/http"
-	"strconv"
-
-	"github.com/gorilla/mux"
 )
 
 type AccommodationHandler struct {
 	Usecase usecase.AccommodationUsecase
+	Files   storage.Storage
 }
 
-func NewAccommodationHandler(u usecase.AccommodationUsecase) *AccommodationHandler {
-	return &AccommodationHandler{Usecase: u}
+func NewAccommodationHandler(u usecase.AccommodationUsecase, files storage.Storage) *AccommodationHandler {
+	return &AccommodationHandler{Usecase: u, Files: files}
 }
 
+// GetAll รองรับ ?village_id=&bbox=&facilities=&include=location&limit=&offset=&cursor=&sort=
+// bbox คือกรอบของแผนที่ในรูป min_lng,min_lat,max_lng,max_lat (min_lng > max_lng = คร่อมเส้น 180 องศา)
+// facilities คือ code คั่นด้วย comma เช่น wifi,parking (ต้องมีครบทุกรายการ)
 func (h *AccommodationHandler) GetAll(w http.ResponseWriter, r *http.Request) {
-	list, err := h.Usecase.GetAll()
+	opts, err := httpx.ListOptions(r)
+	if err != nil {
+		httpx.WriteError(w, r, err)
+		return
+	}
+	villageID, err := httpx.QueryID(r, "village_id")
+	if err != nil {
+		httpx.WriteError(w, r, err)
+		return
+	}
+	bbox, err := parseBBox(r.URL.Query().Get("bbox"))
+	if err != nil {
+		httpx.WriteError(w, r, err)
+		return
+	}
+	include, err := httpx.QueryOneOf(r, "include", includeLocation)
+	if err != nil {
+		httpx.WriteError(w, r, err)
+		return
+	}
+
+	facilities, err := parseFacilities(r.URL.Query().Get("facilities"))
+	if err != nil {
+		httpx.WriteError(w, r, err)
+		return
+	}
+
+	f := model.AccommodationFilter{VillageID: villageID, BBox: bbox, Facilities: facilities}
+	page, err := h.Usecase.List(r.Context(), f, opts)
 	if err != nil {
-		http.Error(w, err.Error(), 500)
+		httpx.WriteError(w, r, err)
+		return
+	}
+	items := make([]*model.Accommodation, len(page.Items))
+	for i := range page.Items {
+		items[i] = &page.Items[i]
+	}
+	if err := h.attach(r.Context(), include, items...); err != nil {
+		httpx.WriteError(w, r, err)
+		return
+	}
+	httpx.WriteJSON(w, http.StatusOK, database.MapPage(page, h.response))
+}
+
+// Nearby คือ [GET] /accommodations/nearby?lat=&lng=&radius_km=&limit=&include=location เรียงจากใกล้ไปไกล
+func (h *AccommodationHandler) Nearby(w http.ResponseWriter, r *http.Request) {
+	lat, err := httpx.QueryFloat(r, "lat", -90, 90)
+	if err != nil {
+		httpx.WriteError(w, r, err)
+		return
+	}
+	lng, err := httpx.QueryFloat(r, "lng", -180, 180)
+	if err != nil {
+		httpx.WriteError(w, r, err)
+		return
+	}
+	if lat == nil || lng == nil {
+		httpx.WriteError(w, r, apperr.Validation("lat and lng are required", map[string]string{
+			"lat": "is required", "lng": "is required",
+		}))
+		return
+	}
+	radius, err := httpx.QueryFloat(r, "radius_km", 0.01, maxNearbyRadiusKm)
+	if err != nil {
+		httpx.WriteError(w, r, err)
+		return
+	}
+	if radius == nil {
+		d := defaultNearbyRadiusKm
+		radius = &d
+	}
+	limit, err := httpx.QueryInt(r, "limit", database.DefaultPageLimit, 1, database.MaxPageLimit)
+	if err != nil {
+		httpx.WriteError(w, r, err)
+		return
+	}
+	include, err := httpx.QueryOneOf(r, "include", includeLocation)
+	if err != nil {
+		httpx.WriteError(w, r, err)
+		return
+	}
+
+	list, err := h.Usecase.Nearby(r.Context(), geo.Point{Lat: *lat, Lng: *lng}, *radius, limit)
+	if err != nil {
+		httpx.WriteError(w, r, err)
+		return
+	}
+	items := make([]*model.Accommodation, len(list))
+	for i := range list {
+		items[i] = &list[i].Accommodation
+	}
+	if err := h.attach(r.Context(), include, items...); err != nil {
+		httpx.WriteError(w, r, err)
 		return
 	}
-	json.NewEncoder(w).Encode(list)
+
+	resp := make([]NearbyAccommodationResponse, 0, len(list))
+	for _, n := range list {
+		resp = append(resp, newNearbyAccommodationResponse(n, h.Files))
+	}
+	httpx.WriteJSON(w, http.StatusOK, resp)
 }
 
 func (h *AccommodationHandler) GetByID(w http.ResponseWriter, r *http.Request) {
-	id, _ := strconv.ParseInt(mux.Vars(r)["id"], 10, 64)
-	data, err := h.Usecase.GetByID(id)
+	id, err := httpx.PathID(r, "id")
+	if err != nil {
+		httpx.WriteError(w, r, err)
+		return
+	}
+	include, err := httpx.QueryOneOf(r, "include", includeLocation)
 	if err != nil {
-		http.Error(w, err.Error(), 404)
+		httpx.WriteError(w, r, err)
 		return
 	}
-	json.NewEncoder(w).Encode(data)
+	data, err := h.Usecase.GetByID(r.Context(), id)
+	if err != nil {
+		httpx.WriteError(w, r, err)
+		return
+	}
+	if err := h.attach(r.Context(), include, &data); err != nil {
+		httpx.WriteError(w, r, err)
+		return
+	}
+	httpx.WriteJSON(w, http.StatusOK, h.response(data))
 }
 
+// Create: ผู้สร้างเป็นเจ้าของที่พัก
 func (h *AccommodationHandler) Create(w http.ResponseWriter, r *http.Request) {
-	var a model.Accommodation
-	json.NewDecoder(r.Body).Decode(&a)
-	if err := h.Usecase.Create(a); err != nil {
-		http.Error(w, err.Error(), 500)
+	p, ok := auth.PrincipalFrom(r.Context())
+	if !ok {
+		httpx.WriteError(w, r, auth.ErrMissingToken)
+		return
+	}
+	var req AccommodationRequest
+	if err := httpx.Decode(w, r, &req); err != nil {
+		httpx.WriteError(w, r, err)
+		return
+	}
+	a := req.toModel()
+	a.HostID = &p.UserID
+	if err := h.Usecase.Create(r.Context(), a); err != nil {
+		httpx.WriteError(w, r, err)
 		return
 	}
 	w.WriteHeader(http.StatusCreated)
 }
 
+// Update: id อยู่ใน body จึงตรวจเจ้าของที่นี่แทน auth.AuthorizeOwner
 func (h *AccommodationHandler) Update(w http.ResponseWriter, r *http.Request) {
-	var a model.Accommodation
-	json.NewDecoder(r.Body).Decode(&a)
-	if err := h.Usecase.Update(a); err != nil {
-		http.Error(w, err.Error(), 500)
+	p, ok := auth.PrincipalFrom(r.Context())
+	if !ok {
+		httpx.WriteError(w, r, auth.ErrMissingToken)
+		return
+	}
+	var req UpdateAccommodationRequest
+	if err := httpx.Decode(w, r, &req); err != nil {
+		httpx.WriteError(w, r, err)
+		return
+	}
+	current, err := h.Usecase.GetByID(r.Context(), req.ID)
+	if err != nil {
+		httpx.WriteError(w, r, err)
+		return
+	}
+	if !auth.CanManage(p, current.OwnerID()) {
+		httpx.WriteError(w, r, auth.ErrForbidden)
+		return
+	}
+	a := req.toModel()
+	a.ID = req.ID
+	if err := h.Usecase.Update(r.Context(), a); err != nil {
+		httpx.WriteError(w, r, err)
 	}
 }
 
 func (h *AccommodationHandler) Delete(w http.ResponseWriter, r *http.Request) {
-	id, _ := strconv.ParseInt(mux.Vars(r)["id"], 10, 64)
-	if err := h.Usecase.Delete(id); err != nil {
-		http.Error(w, err.Error(), 500)
+	id, err := httpx.PathID(r, "id")
+	if err != nil {
+		httpx.WriteError(w, r, err)
+		return
+	}
+	if err := h.Usecase.Delete(r.Context(), id); err != nil {
+		httpx.WriteError(w, r, err)
+	}
+}
+
+// ✅ [PUT] /accommodations/{id}/facilities - แทนที่ facility ทั้งหมดของที่พักด้วย code จาก catalogue
+func (h *AccommodationHandler) SetFacilities(w http.ResponseWriter, r *http.Request) {
+	id, err := httpx.PathID(r, "id")
+	if err != nil {
+		httpx.WriteError(w, r, err)
+		return
+	}
+	var req SetFacilitiesRequest
+	if err := httpx.Decode(w, r, &req); err != nil {
+		httpx.WriteError(w, r, err)
+		return
+	}
+	codes, err := req.codes()
+	if err != nil {
+		httpx.WriteError(w, r, err)
+		return
+	}
+
+	facilities, err := h.Usecase.SetFacilities(r.Context(), id, codes)
+	if err != nil {
+		httpx.WriteError(w, r, err)
+		return
+	}
+	httpx.WriteJSON(w, http.StatusOK, newFacilityResponses(facilities))
+}
+
+// response แปลงที่พักเป็น response DTO พร้อม URL ของรูปหลัก
+func (h *AccommodationHandler) response(a model.Accommodation) AccommodationResponse {
+	return newAccommodationResponse(a, h.Files)
+}
+
+// attach เติม facility ให้ทุก item และเติมที่ตั้งเมื่อขอ ?include=location
+func (h *AccommodationHandler) attach(ctx context.Context, include string, items ...*model.Accommodation) error {
+	if err := h.Usecase.AttachFacilities(ctx, items...); err != nil {
+		return err
+	}
+	if include == includeLocation {
+		return h.Usecase.AttachLocations(ctx, items...)
 	}
+	return nil
 }
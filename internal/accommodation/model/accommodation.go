@@ -1,16 +1,74 @@
 package model
 
-import "time"
+import (
+	"time"
+
+	"myapp/internal/geo"
+)
 
 type Accommodation struct {
-	ID                int64     `json:"id"`
-	Name              string    `json:"name"`
-	MainImage         string    `json:"main_image"`
-	VillageID         int64     `json:"village_id"`
-	About             string    `json:"about"`
-	PopularFacilities string    `json:"popular_facilities"`
-	Latitude          float64   `json:"latitude"`
-	Longitude         float64   `json:"longitude"`
-	CreatedAt         time.Time `json:"createdAt"`
-	UpdatedAt         time.Time `json:"updatedAt"`
+	ID                int64      `json:"id"`
+	HostID            *int64     `json:"host_id"` // เจ้าของที่พัก; nil = ที่พักเดิมก่อนมีเจ้าของ (จัดการได้เฉพาะ admin)
+	Name              string     `json:"name"`
+	MainImage         string     `json:"main_image"` // object key ของรูป cover; เขียนผ่าน gallery SetCover เท่านั้น
+	VillageID         int64      `json:"village_id"`
+	About             string     `json:"about"`
+	PopularFacilities string     `json:"popular_facilities"` // ข้อความเดิมแบบ free-form; ใช้ Facilities แทน
+	PricePerNight     float64    `json:"price_per_night"`
+	Latitude          float64    `json:"latitude"`
+	Longitude         float64    `json:"longitude"`
+	RatingSum         int64      `json:"-"` // ผลรวมคะแนนของรีวิวที่แสดงอยู่ (ปรับโดย review module)
+	RatingCount       int        `json:"rating_count"`
+	CreatedAt         time.Time  `json:"createdAt"`
+	UpdatedAt         time.Time  `json:"updatedAt"`
+	Location          *Location  `json:"-"` // เติมเฉพาะเมื่อเรียก AttachLocations
+	Facilities        []Facility `json:"-"` // เติมเฉพาะเมื่อเรียก AttachFacilities
+}
+
+// OwnerID คือ user ID ของเจ้าของสำหรับ auth.CanManage (0 เมื่อไม่มีเจ้าของ)
+func (a Accommodation) OwnerID() int64 {
+	if a.HostID == nil {
+		return 0
+	}
+	return *a.HostID
+}
+
+// RatingAvg คือคะแนนเฉลี่ยจากยอดสะสม; 0 เมื่อยังไม่มีรีวิว
+func (a Accommodation) RatingAvg() float64 {
+	if a.RatingCount == 0 {
+		return 0
+	}
+	return float64(a.RatingSum) / float64(a.RatingCount)
+}
+
+// Location คือชื่อของ village → district → province ที่ที่พักตั้งอยู่
+type Location struct {
+	VillageID    int64
+	VillageName  string
+	DistrictID   int64
+	DistrictName string
+	ProvinceID   int64
+	ProvinceName string
+}
+
+// Facility คือสิ่งอำนวยความสะดวกจาก catalogue ที่ผูกกับที่พัก
+type Facility struct {
+	ID       int64
+	Code     string
+	Name     string
+	Category string
+	Icon     string
+}
+
+// AccommodationFilter คือเงื่อนไขของ list (field ที่เป็น nil/ว่าง = ไม่กรอง)
+type AccommodationFilter struct {
+	VillageID  *int64
+	BBox       *geo.Box // กรอบของแผนที่ (viewport)
+	Facilities []string // code ของ facility; ที่พักต้องมีครบทุกรายการ
+}
+
+// NearbyAccommodation คือที่พักพร้อมระยะทาง (กม.) จากจุดที่ค้นหา
+type NearbyAccommodation struct {
+	Accommodation
+	DistanceKm float64
 }
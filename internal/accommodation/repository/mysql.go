@@ -1,74 +1,315 @@
 package repository
 
 import (
+	"context"
 	"database/sql"
+	"fmt"
 	"myapp/internal/accommodation/model"
+	"myapp/internal/apperr"
+	"myapp/internal/database"
+	"myapp/internal/geo"
+	"strings"
+	"time"
 )
 
 type AccommodationRepository interface {
-	GetAll() ([]model.Accommodation, error)
-	GetByID(id int64) (model.Accommodation, error)
-	Create(model.Accommodation) error
-	Update(model.Accommodation) error
-	Delete(id int64) error
+	List(ctx context.Context, f model.AccommodationFilter, opts database.ListOptions) (database.Page[model.Accommodation], error)
+	Nearby(ctx context.Context, center geo.Point, radiusKm float64, limit int) ([]model.NearbyAccommodation, error)
+	Locations(ctx context.Context, villageIDs []int64) (map[int64]model.Location, error)
+	GetByID(ctx context.Context, id int64) (model.Accommodation, error)
+	// OwnerOf คือ host_id ของที่พัก (0 เมื่อไม่มีเจ้าของ) ใช้เป็น auth.OwnerFunc ของ module ที่แก้ข้อมูลของที่พัก
+	OwnerOf(ctx context.Context, id int64) (int64, error)
+	Create(ctx context.Context, a model.Accommodation) error
+	Update(ctx context.Context, a model.Accommodation) error
+	// Delete คืน object key ของรูปใน gallery ที่ถูกลบไปพร้อมที่พัก (ให้ผู้เรียกลบไฟล์หลัง commit)
+	Delete(ctx context.Context, id int64) ([]string, error)
+	// Facilities คืน facility ของที่พักหลายหลังในครั้งเดียว (key คือ accommodation_id)
+	Facilities(ctx context.Context, accommodationIDs []int64) (map[int64][]model.Facility, error)
+	// SetFacilities แทนที่ facility ทั้งหมดของที่พักด้วย facilityIDs
+	SetFacilities(ctx context.Context, accommodationID int64, facilityIDs []int64) error
 }
 
 type accommodationRepo struct {
-	db *sql.DB
+	db *database.DB
 }
 
-func NewAccommodationRepository(db *sql.DB) AccommodationRepository {
+func NewAccommodationRepository(db *database.DB) AccommodationRepository {
 	return &accommodationRepo{db: db}
 }
 
-func (r *accommodationRepo) GetAll() ([]model.Accommodation, error) {
-	rows, err := r.db.Query(`
-		SELECT accommodation_id, name, main_image, village_id, about, popular_facilities, latitude, longitude, createdAt, updatedAt 
-		FROM accommodation`)
+var accommodationColumns = []string{
+	"accommodation_id", "host_id", "name", "main_image", "village_id", "about", "popular_facilities", "price_per_night", "latitude", "longitude", "rating_sum", "rating_count", "createdAt", "updatedAt",
+}
+
+// accommodationList คือ sort key ที่ client ใช้ได้ใน ?sort= (ใส่ "-" นำหน้าเพื่อเรียงมากไปน้อย)
+var accommodationList = database.ListSpec[model.Accommodation]{
+	IDColumn: "accommodation_id",
+	ID:       func(a model.Accommodation) any { return a.ID },
+	Sorts: map[string]database.SortKey[model.Accommodation]{
+		"id":         {Column: "accommodation_id", Value: func(a model.Accommodation) any { return a.ID }},
+		"name":       {Column: "name", Value: func(a model.Accommodation) any { return a.Name }},
+		"created_at": {Column: "createdAt", Value: func(a model.Accommodation) any { return a.CreatedAt.Format(time.DateTime) }},
+		"updated_at": {Column: "updatedAt", Value: func(a model.Accommodation) any { return a.UpdatedAt.Format(time.DateTime) }},
+	},
+	DefaultSort: "id",
+	Scan: func(rows *sql.Rows) (model.Accommodation, error) {
+		var a model.Accommodation
+		err := rows.Scan(&a.ID, &a.HostID, &a.Name, &a.MainImage, &a.VillageID, &a.About, &a.PopularFacilities, &a.PricePerNight, &a.Latitude, &a.Longitude, &a.RatingSum, &a.RatingCount, &a.CreatedAt, &a.UpdatedAt)
+		return a, err
+	},
+}
+
+func (r *accommodationRepo) List(ctx context.Context, f model.AccommodationFilter, opts database.ListOptions) (database.Page[model.Accommodation], error) {
+	q := database.Select("accommodation", accommodationColumns...)
+	if f.VillageID != nil {
+		q.Where("village_id = ?", *f.VillageID)
+	}
+	if f.BBox != nil {
+		cond, args := boxCondition(*f.BBox)
+		q.Where(cond, args...)
+	}
+	if len(f.Facilities) > 0 {
+		cond, args := facilitiesCondition(f.Facilities)
+		q.Where(cond, args...)
+	}
+	return database.List(ctx, r.db, q, accommodationList, opts)
+}
+
+// facilitiesCondition คือเงื่อนไขว่าที่พักมี facility ครบทุก code (codes ต้องไม่ซ้ำกัน)
+func facilitiesCondition(codes []string) (string, []any) {
+	args := make([]any, 0, len(codes)+1)
+	for _, c := range codes {
+		args = append(args, c)
+	}
+	args = append(args, len(codes))
+	return "accommodation_id IN (" +
+		"SELECT af.accommodation_id FROM accommodation_facility af JOIN facility f ON f.facility_id = af.facility_id" +
+		" WHERE f.code IN (?" + strings.Repeat(", ?", len(codes)-1) + ")" +
+		" GROUP BY af.accommodation_id HAVING COUNT(*) = ?)", args
+}
+
+// boxCondition คือเงื่อนไขกรอบสี่เหลี่ยมที่ใช้ index (latitude, longitude) ได้
+func boxCondition(b geo.Box) (string, []any) {
+	if b.CrossesAntimeridian() {
+		return "latitude BETWEEN ? AND ? AND (longitude >= ? OR longitude <= ?)", []any{b.MinLat, b.MaxLat, b.MinLng, b.MaxLng}
+	}
+	return "latitude BETWEEN ? AND ? AND longitude BETWEEN ? AND ?", []any{b.MinLat, b.MaxLat, b.MinLng, b.MaxLng}
+}
+
+// haversineKm คือสูตรเดียวกับ geo.DistanceKm ในรูป SQL; args: lat, lat, lng ของจุดศูนย์กลาง
+var haversineKm = fmt.Sprintf(
+	"2 * %g * ASIN(LEAST(1, SQRT(POW(SIN(RADIANS(latitude - ?) / 2), 2) + COS(RADIANS(?)) * COS(RADIANS(latitude)) * POW(SIN(RADIANS(longitude - ?) / 2), 2))))",
+	geo.EarthRadiusKm,
+)
+
+// Nearby คืนที่พักในรัศมี radiusKm เรียงจากใกล้ไปไกล
+// กรองด้วย bounding box ก่อน (ใช้ index) แล้วค่อยคำนวณระยะจริงเฉพาะแถวที่ผ่าน
+func (r *accommodationRepo) Nearby(ctx context.Context, center geo.Point, radiusKm float64, limit int) ([]model.NearbyAccommodation, error) {
+	ctx, cancel := r.db.WithTimeout(ctx)
+	defer cancel()
+
+	cond, boxArgs := boxCondition(geo.BoundingBox(center, radiusKm))
+	query := "SELECT " + strings.Join(accommodationColumns, ", ") + ", " + haversineKm + " AS distance_km" +
+		" FROM accommodation WHERE " + cond +
+		" HAVING distance_km <= ? ORDER BY distance_km, accommodation_id LIMIT ?"
+
+	args := append([]any{center.Lat, center.Lat, center.Lng}, boxArgs...)
+	args = append(args, radiusKm, limit)
+
+	rows, err := r.db.Conn(ctx).QueryContext(ctx, query, args...)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
 
-	var list []model.Accommodation
+	list := []model.NearbyAccommodation{}
 	for rows.Next() {
-		var a model.Accommodation
-		err := rows.Scan(&a.ID, &a.Name, &a.MainImage, &a.VillageID, &a.About, &a.PopularFacilities, &a.Latitude, &a.Longitude, &a.CreatedAt, &a.UpdatedAt)
+		var n model.NearbyAccommodation
+		a := &n.Accommodation
+		err := rows.Scan(&a.ID, &a.HostID, &a.Name, &a.MainImage, &a.VillageID, &a.About, &a.PopularFacilities, &a.PricePerNight, &a.Latitude, &a.Longitude, &a.RatingSum, &a.RatingCount, &a.CreatedAt, &a.UpdatedAt, &n.DistanceKm)
 		if err != nil {
 			return nil, err
 		}
-		list = append(list, a)
+		list = append(list, n)
 	}
-	return list, nil
+	return list, rows.Err()
 }
 
-func (r *accommodationRepo) GetByID(id int64) (model.Accommodation, error) {
+func (r *accommodationRepo) GetByID(ctx context.Context, id int64) (model.Accommodation, error) {
+	ctx, cancel := r.db.WithTimeout(ctx)
+	defer cancel()
+
 	var a model.Accommodation
-	err := r.db.QueryRow(`
-		SELECT accommodation_id, name, main_image, village_id, about, popular_facilities, latitude, longitude, createdAt, updatedAt 
+	err := r.db.Conn(ctx).QueryRowContext(ctx, `
+		SELECT accommodation_id, host_id, name, main_image, village_id, about, popular_facilities, price_per_night, latitude, longitude, rating_sum, rating_count, createdAt, updatedAt 
 		FROM accommodation WHERE accommodation_id=?`, id).
-		Scan(&a.ID, &a.Name, &a.MainImage, &a.VillageID, &a.About, &a.PopularFacilities, &a.Latitude, &a.Longitude, &a.CreatedAt, &a.UpdatedAt)
-	return a, err
+		Scan(&a.ID, &a.HostID, &a.Name, &a.MainImage, &a.VillageID, &a.About, &a.PopularFacilities, &a.PricePerNight, &a.Latitude, &a.Longitude, &a.RatingSum, &a.RatingCount, &a.CreatedAt, &a.UpdatedAt)
+	return a, database.NotFound(err, "Accommodation not found")
+}
+
+func (r *accommodationRepo) OwnerOf(ctx context.Context, id int64) (int64, error) {
+	ctx, cancel := r.db.WithTimeout(ctx)
+	defer cancel()
+
+	var owner int64
+	err := r.db.Conn(ctx).QueryRowContext(ctx,
+		"SELECT COALESCE(host_id, 0) FROM accommodation WHERE accommodation_id = ?", id).Scan(&owner)
+	return owner, database.NotFound(err, "Accommodation not found")
 }
 
-func (r *accommodationRepo) Create(a model.Accommodation) error {
-	_, err := r.db.Exec(`
-		INSERT INTO accommodation (name, main_image, village_id, about, popular_facilities, latitude, longitude) 
-		VALUES (?, ?, ?, ?, ?, ?, ?)`,
-		a.Name, a.MainImage, a.VillageID, a.About, a.PopularFacilities, a.Latitude, a.Longitude)
+func (r *accommodationRepo) Create(ctx context.Context, a model.Accommodation) error {
+	ctx, cancel := r.db.WithTimeout(ctx)
+	defer cancel()
+
+	_, err := r.db.Conn(ctx).ExecContext(ctx, `
+		INSERT INTO accommodation (host_id, name, village_id, about, popular_facilities, price_per_night, latitude, longitude) 
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?)`,
+		a.HostID, a.Name, a.VillageID, a.About, a.PopularFacilities, a.PricePerNight, a.Latitude, a.Longitude)
 	return err
 }
 
-func (r *accommodationRepo) Update(a model.Accommodation) error {
-	_, err := r.db.Exec(`
+func (r *accommodationRepo) Update(ctx context.Context, a model.Accommodation) error {
+	ctx, cancel := r.db.WithTimeout(ctx)
+	defer cancel()
+
+	_, err := r.db.Conn(ctx).ExecContext(ctx, `
 		UPDATE accommodation SET 
-			name=?, main_image=?, village_id=?, about=?, popular_facilities=?, latitude=?, longitude=? 
+			name=?, village_id=?, about=?, popular_facilities=?, price_per_night=?, latitude=?, longitude=? 
 		WHERE accommodation_id=?`,
-		a.Name, a.MainImage, a.VillageID, a.About, a.PopularFacilities, a.Latitude, a.Longitude, a.ID)
+		a.Name, a.VillageID, a.About, a.PopularFacilities, a.PricePerNight, a.Latitude, a.Longitude, a.ID)
 	return err
 }
 
-func (r *accommodationRepo) Delete(id int64) error {
-	_, err := r.db.Exec("DELETE FROM accommodation WHERE accommodation_id=?", id)
-	return err
+// Delete ลบที่พักพร้อม favorites/wishlist item ที่ชี้มา ใน transaction เดียว (แถวของรูปถูกลบด้วย FK cascade)
+// ถ้ายังมีการจองอยู่จะ rollback ทั้งหมด (favorites ของผู้ใช้ไม่หาย)
+func (r *accommodationRepo) Delete(ctx context.Context, id int64) ([]string, error) {
+	ctx, cancel := r.db.WithTimeout(ctx)
+	defer cancel()
+
+	var keys []string
+	err := r.db.WithinTx(ctx, func(ctx context.Context) error {
+		rows, err := r.db.Conn(ctx).QueryContext(ctx,
+			"SELECT object_key FROM accommodation_image WHERE accommodation_id=? FOR UPDATE", id)
+		if err != nil {
+			return err
+		}
+		defer rows.Close()
+		for rows.Next() {
+			var key string
+			if err := rows.Scan(&key); err != nil {
+				return err
+			}
+			keys = append(keys, key)
+		}
+		if err := rows.Err(); err != nil {
+			return err
+		}
+
+		for _, query := range []string{
+			"DELETE FROM favorite WHERE accommodation_id=?",
+			"DELETE FROM wishlist_item WHERE accommodation_id=?",
+		} {
+			if _, err := r.db.Conn(ctx).ExecContext(ctx, query, id); err != nil {
+				return err
+			}
+		}
+
+		res, err := r.db.Conn(ctx).ExecContext(ctx, "DELETE FROM accommodation WHERE accommodation_id=?", id)
+		if database.IsReferenced(err) {
+			return apperr.Conflict("accommodation_has_bookings", "Accommodation has bookings and cannot be deleted").Wrap(err)
+		}
+		return database.RequireAffected(res, err, "Accommodation not found")
+	})
+	if err != nil {
+		return nil, err
+	}
+	return keys, nil
+}
+
+// Locations คืนชื่อ village/district/province ของ village ที่ระบุ (key คือ village_id)
+// village ที่ไม่มีในตารางหรือ district/province หายไปจะไม่อยู่ใน map
+func (r *accommodationRepo) Locations(ctx context.Context, villageIDs []int64) (map[int64]model.Location, error) {
+	ctx, cancel := r.db.WithTimeout(ctx)
+	defer cancel()
+
+	ids := make([]any, 0, len(villageIDs))
+	for _, id := range villageIDs {
+		ids = append(ids, id)
+	}
+	q := database.Select("village v JOIN district d ON d.district_id = v.district_id JOIN province p ON p.province_id = d.province_id",
+		"v.village_id", "v.name", "d.district_id", "d.name", "p.province_id", "p.name").
+		WhereIn("v.village_id", ids...)
+
+	query, args := q.SQL()
+	rows, err := r.db.Conn(ctx).QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	locations := make(map[int64]model.Location, len(villageIDs))
+	for rows.Next() {
+		var l model.Location
+		if err := rows.Scan(&l.VillageID, &l.VillageName, &l.DistrictID, &l.DistrictName, &l.ProvinceID, &l.ProvinceName); err != nil {
+			return nil, err
+		}
+		locations[l.VillageID] = l
+	}
+	return locations, rows.Err()
+}
+
+func (r *accommodationRepo) Facilities(ctx context.Context, accommodationIDs []int64) (map[int64][]model.Facility, error) {
+	facilities := make(map[int64][]model.Facility, len(accommodationIDs))
+	if len(accommodationIDs) == 0 {
+		return facilities, nil
+	}
+
+	ctx, cancel := r.db.WithTimeout(ctx)
+	defer cancel()
+
+	ids := make([]any, 0, len(accommodationIDs))
+	for _, id := range accommodationIDs {
+		ids = append(ids, id)
+	}
+	q := database.Select("accommodation_facility af JOIN facility f ON f.facility_id = af.facility_id",
+		"af.accommodation_id", "f.facility_id", "f.code", "f.name", "f.category", "f.icon").
+		WhereIn("af.accommodation_id", ids...).
+		OrderBy("f.category", false).
+		OrderBy("f.name", false)
+
+	query, args := q.SQL()
+	rows, err := r.db.Conn(ctx).QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var id int64
+		var f model.Facility
+		if err := rows.Scan(&id, &f.ID, &f.Code, &f.Name, &f.Category, &f.Icon); err != nil {
+			return nil, err
+		}
+		facilities[id] = append(facilities[id], f)
+	}
+	return facilities, rows.Err()
+}
+
+func (r *accommodationRepo) SetFacilities(ctx context.Context, accommodationID int64, facilityIDs []int64) error {
+	ctx, cancel := r.db.WithTimeout(ctx)
+	defer cancel()
+
+	return r.db.WithinTx(ctx, func(ctx context.Context) error {
+		if _, err := r.db.Conn(ctx).ExecContext(ctx,
+			"DELETE FROM accommodation_facility WHERE accommodation_id=?", accommodationID); err != nil {
+			return err
+		}
+		for _, id := range facilityIDs {
+			if _, err := r.db.Conn(ctx).ExecContext(ctx,
+				"INSERT INTO accommodation_facility (accommodation_id, facility_id) VALUES (?, ?)", accommodationID, id); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
 }
@@ -0,0 +1,75 @@
+package repository
+
+import (
+	"context"
+	"regexp"
+	"slices"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+
+	"myapp/internal/database"
+)
+
+func TestDeleteReturnsImageKeys(t *testing.T) {
+	sqlDB, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer sqlDB.Close()
+	repo := NewAccommodationRepository(database.New(sqlDB, 0))
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT object_key FROM accommodation_image WHERE accommodation_id=? FOR UPDATE")).
+		WithArgs(7).
+		WillReturnRows(sqlmock.NewRows([]string{"object_key"}).
+			AddRow("accommodations/7/a.jpg").
+			AddRow("accommodations/7/b.png"))
+	mock.ExpectExec(regexp.QuoteMeta("DELETE FROM favorite WHERE accommodation_id=?")).
+		WithArgs(7).WillReturnResult(sqlmock.NewResult(0, 2))
+	mock.ExpectExec(regexp.QuoteMeta("DELETE FROM wishlist_item WHERE accommodation_id=?")).
+		WithArgs(7).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(regexp.QuoteMeta("DELETE FROM accommodation WHERE accommodation_id=?")).
+		WithArgs(7).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	keys, err := repo.Delete(context.Background(), 7)
+	if err != nil {
+		t.Fatalf("Delete error = %v", err)
+	}
+	if want := []string{"accommodations/7/a.jpg", "accommodations/7/b.png"}; !slices.Equal(keys, want) {
+		t.Errorf("keys = %v, want %v", keys, want)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestDeleteNotFoundRollsBack(t *testing.T) {
+	sqlDB, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer sqlDB.Close()
+	repo := NewAccommodationRepository(database.New(sqlDB, 0))
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT object_key FROM accommodation_image")).
+		WithArgs(9).
+		WillReturnRows(sqlmock.NewRows([]string{"object_key"}).AddRow("accommodations/9/a.jpg"))
+	mock.ExpectExec("DELETE FROM favorite").WithArgs(9).WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec("DELETE FROM wishlist_item").WithArgs(9).WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec("DELETE FROM accommodation ").WithArgs(9).WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectRollback()
+
+	keys, err := repo.Delete(context.Background(), 9)
+	if err == nil {
+		t.Fatal("Delete error = nil, want not found")
+	}
+	if keys != nil {
+		t.Errorf("keys = %v, want none when nothing was deleted", keys)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
@@ -1,8 +1,6 @@
 package router
 
 import (
-	"database/sql"
-	"fmt"
 	"net/http"
 
 	"github.com/gorilla/mux"
@@ -10,23 +8,53 @@ import (
 	accHandler "myapp/internal/accommodation/handler"
 	accRepo "myapp/internal/accommodation/repository"
 	accUsecase "myapp/internal/accommodation/usecase"
+	"myapp/internal/auth"
+	"myapp/internal/database"
+	facilityRepo "myapp/internal/facility/repository"
+	"myapp/internal/httpx"
+	"myapp/internal/storage"
+	villageRepo "myapp/internal/village/repository"
 )
 
-func InitRouter(db *sql.DB) *mux.Router {
-	r := mux.NewRouter()
+type Module struct {
+	handler *accHandler.AccommodationHandler
+	owner   auth.OwnerFunc
+	tokens  *auth.TokenManager
+}
 
+func NewModule(db *database.DB, tokens *auth.TokenManager, files storage.Storage) *Module {
 	accRepository := accRepo.NewAccommodationRepository(db)
-	accUC := accUsecase.NewAccommodationUsecase(accRepository)
-	accH := accHandler.NewAccommodationHandler(accUC)
+	accUC := accUsecase.NewAccommodationUsecase(accRepository, villageRepo.NewVillageRepository(db), facilityRepo.NewFacilityRepository(db), files)
+	return &Module{
+		handler: accHandler.NewAccommodationHandler(accUC, files),
+		// ✅ เจ้าของที่พักจาก path {id}
+		owner: func(r *http.Request) (int64, error) {
+			id, err := httpx.PathID(r, "id")
+			if err != nil {
+				return 0, err
+			}
+			return accRepository.OwnerOf(r.Context(), id)
+		},
+		tokens: tokens,
+	}
+}
+
+func (m *Module) Name() string { return "accommodation" }
+
+func (m *Module) RegisterRoutes(r *mux.Router) {
+	accH := m.handler
 
 	r.HandleFunc("/accommodations", accH.GetAll).Methods("GET")
+	r.HandleFunc("/accommodations/nearby", accH.Nearby).Methods("GET") // ต้องมาก่อน /{id}
 	r.HandleFunc("/accommodations/{id}", accH.GetByID).Methods("GET")
-	r.HandleFunc("/accommodations", accH.Create).Methods("POST")
-	r.HandleFunc("/accommodations", accH.Update).Methods("PUT")
-	r.HandleFunc("/accommodations/{id}", accH.Delete).Methods("DELETE")
-	r.HandleFunc("/ping", func(w http.ResponseWriter, r *http.Request) {
-		fmt.Fprint(w, "pong")
-	}).Methods("GET")
-
-	return r
+
+	// ✅ เพิ่มได้เฉพาะ host หรือ admin; แก้ไข/ลบได้เฉพาะเจ้าของที่พักหรือ admin
+	protected := r.NewRoute().Subrouter()
+	protected.Use(auth.Middleware(m.tokens))
+	hostOrAdmin := auth.Roles(auth.RoleHost, auth.RoleAdmin)
+
+	protected.Handle("/accommodations", auth.Authorize(hostOrAdmin, accH.Create)).Methods("POST")
+	protected.Handle("/accommodations", auth.Authorize(hostOrAdmin, accH.Update)).Methods("PUT")
+	protected.Handle("/accommodations/{id}", auth.AuthorizeOwner(m.owner, accH.Delete)).Methods("DELETE")
+	protected.Handle("/accommodations/{id:[0-9]+}/facilities", auth.AuthorizeOwner(m.owner, accH.SetFacilities)).Methods("PUT")
 }
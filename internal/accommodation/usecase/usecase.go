@@ -1,42 +1,175 @@
 package usecase
 
 import (
+	"context"
+	"log/slog"
 	"myapp/internal/accommodation/model"
 	"myapp/internal/accommodation/repository"
+	"myapp/internal/apperr"
+	"myapp/internal/database"
+	facilityRepo "myapp/internal/facility/repository"
+	"myapp/internal/geo"
+	"myapp/internal/logging"
+	"myapp/internal/storage"
+	villageRepo "myapp/internal/village/repository"
+	"slices"
+	"strings"
 )
 
 type AccommodationUsecase interface {
-	GetAll() ([]model.Accommodation, error)
-	GetByID(int64) (model.Accommodation, error)
-	Create(model.Accommodation) error
-	Update(model.Accommodation) error
-	Delete(int64) error
+	List(ctx context.Context, f model.AccommodationFilter, opts database.ListOptions) (database.Page[model.Accommodation], error)
+	Nearby(ctx context.Context, center geo.Point, radiusKm float64, limit int) ([]model.NearbyAccommodation, error)
+	GetByID(ctx context.Context, id int64) (model.Accommodation, error)
+	Create(ctx context.Context, m model.Accommodation) error
+	Update(ctx context.Context, m model.Accommodation) error
+	Delete(ctx context.Context, id int64) error
+	AttachLocations(ctx context.Context, items ...*model.Accommodation) error
+	AttachFacilities(ctx context.Context, items ...*model.Accommodation) error
+	// SetFacilities แทนที่ facility ของที่พักด้วยรายการ code จาก catalogue
+	SetFacilities(ctx context.Context, id int64, codes []string) ([]model.Facility, error)
 }
 
 type accommodationUsecase struct {
-	repo repository.AccommodationRepository
+	repo       repository.AccommodationRepository
+	villages   villageRepo.VillageRepository
+	facilities facilityRepo.FacilityRepository
+	files      storage.Storage
 }
 
-func NewAccommodationUsecase(r repository.AccommodationRepository) AccommodationUsecase {
-	return &accommodationUsecase{repo: r}
+func NewAccommodationUsecase(r repository.AccommodationRepository, villages villageRepo.VillageRepository, facilities facilityRepo.FacilityRepository, files storage.Storage) AccommodationUsecase {
+	return &accommodationUsecase{repo: r, villages: villages, facilities: facilities, files: files}
 }
 
-func (u *accommodationUsecase) GetAll() ([]model.Accommodation, error) {
-	return u.repo.GetAll()
+func (u *accommodationUsecase) List(ctx context.Context, f model.AccommodationFilter, opts database.ListOptions) (database.Page[model.Accommodation], error) {
+	return u.repo.List(ctx, f, opts)
 }
 
-func (u *accommodationUsecase) GetByID(id int64) (model.Accommodation, error) {
-	return u.repo.GetByID(id)
+func (u *accommodationUsecase) Nearby(ctx context.Context, center geo.Point, radiusKm float64, limit int) ([]model.NearbyAccommodation, error) {
+	return u.repo.Nearby(ctx, center, radiusKm, limit)
 }
 
-func (u *accommodationUsecase) Create(a model.Accommodation) error {
-	return u.repo.Create(a)
+func (u *accommodationUsecase) GetByID(ctx context.Context, id int64) (model.Accommodation, error) {
+	return u.repo.GetByID(ctx, id)
 }
 
-func (u *accommodationUsecase) Update(a model.Accommodation) error {
-	return u.repo.Update(a)
+func (u *accommodationUsecase) Create(ctx context.Context, a model.Accommodation) error {
+	if err := u.checkVillage(ctx, a.VillageID); err != nil {
+		return err
+	}
+	return u.repo.Create(ctx, a)
 }
 
-func (u *accommodationUsecase) Delete(id int64) error {
-	return u.repo.Delete(id)
+func (u *accommodationUsecase) Update(ctx context.Context, a model.Accommodation) error {
+	if err := u.checkVillage(ctx, a.VillageID); err != nil {
+		return err
+	}
+	return u.repo.Update(ctx, a)
+}
+
+func (u *accommodationUsecase) Delete(ctx context.Context, id int64) error {
+	keys, err := u.repo.Delete(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	// ✅ ลบไฟล์รูปหลัง commit เท่านั้น; ถ้าลบไม่สำเร็จแค่เหลือไฟล์ค้าง ไม่กระทบข้อมูล
+	for _, key := range keys {
+		if err := u.files.Delete(ctx, key); err != nil {
+			slog.WarnContext(ctx, "failed to remove image file", "key", key, logging.Err(err))
+		}
+	}
+	return nil
+}
+
+// AttachLocations เติมชื่อ village/district/province ให้ทุก item ด้วย query เดียว
+func (u *accommodationUsecase) AttachLocations(ctx context.Context, items ...*model.Accommodation) error {
+	if len(items) == 0 {
+		return nil
+	}
+	ids := make([]int64, 0, len(items))
+	seen := make(map[int64]bool, len(items))
+	for _, a := range items {
+		if !seen[a.VillageID] {
+			seen[a.VillageID] = true
+			ids = append(ids, a.VillageID)
+		}
+	}
+
+	locations, err := u.repo.Locations(ctx, ids)
+	if err != nil {
+		return err
+	}
+	for _, a := range items {
+		if l, ok := locations[a.VillageID]; ok {
+			a.Location = &l
+		}
+	}
+	return nil
+}
+
+// AttachFacilities เติม facility ให้ทุก item ด้วย query เดียว
+func (u *accommodationUsecase) AttachFacilities(ctx context.Context, items ...*model.Accommodation) error {
+	if len(items) == 0 {
+		return nil
+	}
+	ids := make([]int64, 0, len(items))
+	for _, a := range items {
+		ids = append(ids, a.ID)
+	}
+
+	facilities, err := u.repo.Facilities(ctx, ids)
+	if err != nil {
+		return err
+	}
+	for _, a := range items {
+		a.Facilities = facilities[a.ID]
+	}
+	return nil
+}
+
+func (u *accommodationUsecase) SetFacilities(ctx context.Context, id int64, codes []string) ([]model.Facility, error) {
+	if _, err := u.repo.GetByID(ctx, id); err != nil {
+		return nil, err
+	}
+	found, err := u.facilities.ByCodes(ctx, codes)
+	if err != nil {
+		return nil, err
+	}
+
+	ids := make([]int64, 0, len(found))
+	known := make([]string, 0, len(found))
+	for _, f := range found {
+		ids = append(ids, f.ID)
+		known = append(known, f.Code)
+	}
+	var unknown []string
+	for _, c := range codes {
+		if !slices.Contains(known, c) {
+			unknown = append(unknown, c)
+		}
+	}
+	if len(unknown) > 0 {
+		return nil, apperr.Validation("Unknown facilities", map[string]string{"facilities": "unknown codes: " + strings.Join(unknown, ", ")})
+	}
+
+	if err := u.repo.SetFacilities(ctx, id, ids); err != nil {
+		return nil, err
+	}
+	facilities, err := u.repo.Facilities(ctx, []int64{id})
+	if err != nil {
+		return nil, err
+	}
+	return facilities[id], nil
+}
+
+// checkVillage ตรวจ foreign key ก่อนเขียน เพื่อคืน validation error ที่ระบุ field ได้
+func (u *accommodationUsecase) checkVillage(ctx context.Context, id int64) error {
+	ok, err := u.villages.Exists(ctx, id)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return apperr.Validation("Village does not exist", map[string]string{"village_id": "does not exist"})
+	}
+	return nil
 }
@@ -0,0 +1,90 @@
+package app
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"log/slog"
+	"net/http"
+
+	"github.com/gorilla/mux"
+
+	"myapp/internal/auth"
+	"myapp/internal/config"
+	"myapp/internal/database"
+	"myapp/internal/logging"
+	"myapp/internal/scheduler"
+	"myapp/internal/storage"
+)
+
+// Module คือส่วนของระบบที่ลงทะเบียน route ของตัวเองบน sub-router ที่ app สร้างให้
+type Module interface {
+	Name() string
+	RegisterRoutes(r *mux.Router)
+}
+
+// JobProvider คือ module ที่มี background job ให้ scheduler ของ app รัน
+type JobProvider interface {
+	Jobs() []scheduler.Job
+}
+
+// App คือ composition root: สร้าง dependency ที่ใช้ร่วมกันครั้งเดียว แล้ว mount ทุก module
+type App struct {
+	Config config.Config
+	DB     *sql.DB
+	Store  *database.DB
+	Tokens *auth.TokenManager
+	Jobs   *scheduler.Scheduler
+	Files  storage.Storage
+
+	router        *mux.Router
+	modules       []Module
+	shutdownHooks []func(ctx context.Context) error
+}
+
+// files คือ storage ที่สร้างจาก cfg.Storage (ดู storage.New)
+func New(cfg config.Config, db *sql.DB, files storage.Storage) *App {
+	a := &App{
+		Config: cfg,
+		DB:     db,
+		Store:  database.New(db, cfg.DB.QueryTimeout),
+		Tokens: auth.NewTokenManager([]byte(cfg.JWT.Secret), cfg.JWT.AccessTTL),
+		Jobs:   scheduler.New(),
+		Files:  files,
+		router: mux.NewRouter(),
+	}
+	a.OnShutdown(a.Jobs.Stop)
+
+	a.router.HandleFunc("/ping", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, "pong")
+	}).Methods("GET")
+
+	a.mountOps()
+	a.mountFiles()
+	a.Mount(a.defaultModules()...)
+	return a
+}
+
+// Mount ให้แต่ละ module ลงทะเบียน route บน sub-router ของตัวเอง
+func (a *App) Mount(modules ...Module) {
+	for _, m := range modules {
+		m.RegisterRoutes(a.router.NewRoute().Subrouter())
+		a.modules = append(a.modules, m)
+		slog.Info("module mounted", "module", m.Name())
+
+		if p, ok := m.(JobProvider); ok && a.Config.Jobs.Enabled {
+			if err := a.Jobs.Add(p.Jobs()...); err != nil {
+				slog.Error("module jobs not scheduled", "module", m.Name(), logging.Err(err))
+			}
+		}
+	}
+}
+
+func (a *App) Router() *mux.Router {
+	return a.router
+}
+
+// Handler คือ router ที่ครอบด้วย middleware ระดับ app แล้ว
+func (a *App) Handler() http.Handler {
+	return requestIDMiddleware(corsMiddleware(a.router))
+}
@@ -0,0 +1,9 @@
+package app
+
+import "myapp/internal/storage"
+
+// mountFiles เสิร์ฟไฟล์ที่ upload ที่ /files/{key}
+// เป็น URL default ของ local storage และใช้ proxy bucket ของ S3 ที่ไม่เปิด public ได้ (ตั้ง STORAGE_PUBLIC_URL=/files)
+func (a *App) mountFiles() {
+	a.router.PathPrefix(storage.FilesPath+"/").Handler(storage.Handler(a.Files, storage.FilesPath)).Methods("GET", "HEAD")
+}
@@ -0,0 +1,34 @@
+package app
+
+import (
+	accommodation "myapp/internal/accommodation/routes"
+	booking "myapp/internal/booking/routes"
+	district "myapp/internal/district/routes"
+	facility "myapp/internal/facility/routes"
+	favorite "myapp/internal/favorite/routes"
+	gallery "myapp/internal/gallery/routes"
+	notification "myapp/internal/notification/routes"
+	province "myapp/internal/province/routes"
+	review "myapp/internal/review/routes"
+	room "myapp/internal/room/routes"
+	user "myapp/internal/user/routes"
+	village "myapp/internal/village/routes"
+)
+
+// defaultModules คือรายการ module ทั้งหมดของระบบ; module ใหม่เพิ่มที่นี่ที่เดียว
+func (a *App) defaultModules() []Module {
+	return []Module{
+		user.NewModule(a.Store, a.Tokens, a.Config, a.Files),
+		accommodation.NewModule(a.Store, a.Tokens, a.Files),
+		facility.NewModule(a.Store, a.Tokens),
+		gallery.NewModule(a.Store, a.Tokens, a.Files),
+		room.NewModule(a.Store, a.Tokens),
+		province.NewModule(a.Store, a.Tokens),
+		district.NewModule(a.Store, a.Tokens),
+		village.NewModule(a.Store, a.Tokens),
+		notification.NewModule(a.Store, a.Tokens),
+		booking.NewModule(a.Store, a.Tokens),
+		review.NewModule(a.Store, a.Tokens),
+		favorite.NewModule(a.Store, a.Tokens, a.Files),
+	}
+}
@@ -0,0 +1,26 @@
+package app
+
+import (
+	"encoding/json"
+	"expvar"
+	"net/http"
+
+	"myapp/internal/auth"
+)
+
+// mountOps ลงทะเบียน endpoint สำหรับดูสถานะระบบ (admin เท่านั้น)
+func (a *App) mountOps() {
+	ops := a.router.NewRoute().Subrouter()
+	ops.Use(auth.Middleware(a.Tokens))
+
+	adminOnly := auth.Roles(auth.RoleAdmin)
+
+	// ✅ สถานะรอบล่าสุดของ background job
+	ops.Handle("/admin/jobs", auth.Authorize(adminOnly, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(a.Jobs.Statuses())
+	})).Methods("GET")
+
+	// ✅ metrics ของ expvar (รวม counter ของ scheduler)
+	ops.Handle("/debug/vars", auth.Authorize(adminOnly, expvar.Handler().ServeHTTP)).Methods("GET")
+}
@@ -0,0 +1,104 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net"
+	"net/http"
+	"strings"
+
+	"github.com/gorilla/mux"
+
+	"myapp/internal/logging"
+)
+
+// OnShutdown ลงทะเบียนงานที่ต้องหยุดหลัง server เลิกรับ request (เช่น background worker)
+// hook จะถูกเรียกย้อนลำดับการลงทะเบียน ก่อนปิด *sql.DB
+func (a *App) OnShutdown(fn func(ctx context.Context) error) {
+	a.shutdownHooks = append(a.shutdownHooks, fn)
+}
+
+// Run เปิด HTTP server จนกว่า ctx จะถูกยกเลิก (เช่นได้รับ SIGTERM)
+// จากนั้นหยุดรับ connection ใหม่, รอ request ที่ค้างอยู่ภายใน ShutdownTimeout,
+// หยุด background worker แล้วปิด database
+func (a *App) Run(ctx context.Context) error {
+	srv := &http.Server{
+		Addr:         a.Config.HTTP.Addr,
+		Handler:      a.Handler(),
+		ReadTimeout:  a.Config.HTTP.ReadTimeout,
+		WriteTimeout: a.Config.HTTP.WriteTimeout,
+		IdleTimeout:  a.Config.HTTP.IdleTimeout,
+	}
+
+	ln, err := net.Listen("tcp", srv.Addr)
+	if err != nil {
+		return err
+	}
+	slog.Info("server listening", "addr", ln.Addr().String())
+	a.logRoutes()
+
+	// job ไม่ผูกกับ ctx ของ signal: หยุดผ่าน shutdown hook หลัง drain request แล้ว
+	a.Jobs.Start(context.Background())
+
+	serveErr := make(chan error, 1)
+	go func() {
+		serveErr <- srv.Serve(ln)
+	}()
+
+	select {
+	case err := <-serveErr:
+		if !errors.Is(err, http.ErrServerClosed) {
+			a.stop(context.Background())
+			return err
+		}
+	case <-ctx.Done():
+		slog.Info("shutdown signal received, draining in-flight requests")
+	}
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), a.Config.HTTP.ShutdownTimeout)
+	defer cancel()
+
+	var errs []error
+	if err := srv.Shutdown(shutdownCtx); err != nil {
+		slog.Error("http server did not drain in time", logging.Err(err))
+		errs = append(errs, err)
+	}
+	if err := a.stop(shutdownCtx); err != nil {
+		errs = append(errs, err)
+	}
+	slog.Info("server stopped")
+	return errors.Join(errs...)
+}
+
+func (a *App) stop(ctx context.Context) error {
+	var errs []error
+	for i := len(a.shutdownHooks) - 1; i >= 0; i-- {
+		if err := a.shutdownHooks[i](ctx); err != nil {
+			slog.Error("shutdown hook failed", logging.Err(err))
+			errs = append(errs, err)
+		}
+	}
+	if a.DB != nil {
+		if err := a.DB.Close(); err != nil {
+			errs = append(errs, err)
+		}
+		slog.Info("database connection closed")
+	}
+	return errors.Join(errs...)
+}
+
+func (a *App) logRoutes() {
+	a.router.Walk(func(route *mux.Route, router *mux.Router, ancestors []*mux.Route) error {
+		path, err := route.GetPathTemplate()
+		if err != nil {
+			return nil
+		}
+		methods, err := route.GetMethods()
+		if err != nil {
+			return nil
+		}
+		slog.Debug("route", "methods", strings.Join(methods, ","), "path", path)
+		return nil
+	})
+}
@@ -0,0 +1,137 @@
+// Package apperr คือ error ของ domain ที่ usecase คืนให้ handler
+// handler ไม่ต้องรู้จัก error ของ database; httpx.WriteError แปลง Kind เป็น HTTP status ให้เอง
+package apperr
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+// Kind คือประเภทของ error ซึ่งกำหนด HTTP status
+type Kind string
+
+const (
+	KindNotFound     Kind = "not_found"
+	KindConflict     Kind = "conflict"
+	KindValidation   Kind = "validation"
+	KindUnauthorized Kind = "unauthorized"
+	KindForbidden    Kind = "forbidden"
+	KindRateLimited  Kind = "rate_limited"
+	KindUnavailable  Kind = "unavailable"
+	KindTooLarge     Kind = "too_large"
+)
+
+// Error คือ domain error พร้อมข้อความที่ส่งให้ client ได้
+// Code เป็นรหัสเฉพาะกรณี (เช่น email_taken) ถ้าว่างจะใช้ Kind แทน
+type Error struct {
+	Kind       Kind
+	Code       string
+	Message    string
+	Fields     map[string]string
+	RetryAfter time.Duration
+	Err        error
+}
+
+// sentinel สำหรับเช็กประเภท เช่น errors.Is(err, apperr.ErrNotFound)
+var (
+	ErrNotFound     = &Error{Kind: KindNotFound}
+	ErrConflict     = &Error{Kind: KindConflict}
+	ErrValidation   = &Error{Kind: KindValidation}
+	ErrUnauthorized = &Error{Kind: KindUnauthorized}
+	ErrForbidden    = &Error{Kind: KindForbidden}
+	ErrRateLimited  = &Error{Kind: KindRateLimited}
+	ErrUnavailable  = &Error{Kind: KindUnavailable}
+	ErrTooLarge     = &Error{Kind: KindTooLarge}
+)
+
+func (e *Error) Error() string {
+	msg := e.Message
+	if msg == "" {
+		msg = string(e.Kind)
+	}
+	if e.Err != nil {
+		return fmt.Sprintf("%s: %v", msg, e.Err)
+	}
+	return msg
+}
+
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+// Is ให้ sentinel ของแต่ละ Kind (ที่ไม่มี Code/Message) match error ทุกตัวใน Kind เดียวกัน
+func (e *Error) Is(target error) bool {
+	t, ok := target.(*Error)
+	if !ok {
+		return false
+	}
+	if t.Code == "" && t.Message == "" {
+		return t.Kind == e.Kind
+	}
+	return t.Kind == e.Kind && t.Code == e.Code
+}
+
+// CodeOrKind คือรหัสที่ส่งให้ client
+func (e *Error) CodeOrKind() string {
+	if e.Code != "" {
+		return e.Code
+	}
+	return string(e.Kind)
+}
+
+// Wrap ผูก cause (เช่น sql.ErrNoRows) ไว้ใน error ใหม่ โดยไม่แก้ตัวต้นฉบับ (มักเป็น sentinel ระดับ package)
+func (e *Error) Wrap(err error) *Error {
+	c := *e
+	c.Err = err
+	return &c
+}
+
+// As ดึง *Error ออกจาก chain
+func As(err error) (*Error, bool) {
+	var e *Error
+	ok := errors.As(err, &e)
+	return e, ok
+}
+
+func NotFound(message string) *Error {
+	return &Error{Kind: KindNotFound, Message: message}
+}
+
+func Conflict(code, message string) *Error {
+	return &Error{Kind: KindConflict, Code: code, Message: message}
+}
+
+// Invalid คือ validation error ที่ไม่ผูกกับ field ใด (เช่น JSON เสีย)
+func Invalid(message string) *Error {
+	return &Error{Kind: KindValidation, Message: message}
+}
+
+// Validation คือ validation error พร้อมข้อความราย field
+func Validation(message string, fields map[string]string) *Error {
+	return &Error{Kind: KindValidation, Message: message, Fields: fields}
+}
+
+func Unauthorized(code, message string) *Error {
+	return &Error{Kind: KindUnauthorized, Code: code, Message: message}
+}
+
+func Forbidden(message string) *Error {
+	return &Error{Kind: KindForbidden, Message: message}
+}
+
+func RateLimited(retryAfter time.Duration) *Error {
+	return &Error{
+		Kind:       KindRateLimited,
+		Message:    "Too many attempts, please try again later",
+		RetryAfter: retryAfter,
+	}
+}
+
+func Unavailable(code, message string) *Error {
+	return &Error{Kind: KindUnavailable, Code: code, Message: message}
+}
+
+func TooLarge(message string) *Error {
+	return &Error{Kind: KindTooLarge, Message: message}
+}
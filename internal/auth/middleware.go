@@ -0,0 +1,65 @@
+package auth
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"strings"
+
+	"github.com/gorilla/mux"
+
+	"myapp/internal/httpx"
+)
+
+// Principal คือผู้ใช้ที่ผ่านการยืนยันตัวตนแล้วของ request ปัจจุบัน
+type Principal struct {
+	UserID int64
+	Email  string
+	Role   string
+}
+
+type principalKey struct{}
+
+func WithPrincipal(ctx context.Context, p Principal) context.Context {
+	return context.WithValue(ctx, principalKey{}, p)
+}
+
+// PrincipalFrom ดึง principal ที่ middleware ใส่ไว้ใน context
+func PrincipalFrom(ctx context.Context) (Principal, bool) {
+	p, ok := ctx.Value(principalKey{}).(Principal)
+	return p, ok
+}
+
+// Middleware ตรวจ Bearer token ทุก request ของ router ที่ถูกครอบ
+func Middleware(tm *TokenManager) mux.MiddlewareFunc {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			tokenStr, err := bearerToken(r)
+			if err != nil {
+				httpx.WriteError(w, r, err)
+				return
+			}
+
+			p, err := tm.Parse(tokenStr)
+			if err != nil {
+				slog.InfoContext(r.Context(), "rejected bearer token", "method", r.Method, "path", r.URL.Path, "reason", err.Error())
+				httpx.WriteError(w, r, ErrInvalidToken)
+				return
+			}
+
+			next.ServeHTTP(w, r.WithContext(WithPrincipal(r.Context(), p)))
+		})
+	}
+}
+
+func bearerToken(r *http.Request) (string, error) {
+	header := r.Header.Get("Authorization")
+	if header == "" {
+		return "", ErrMissingToken
+	}
+	scheme, token, ok := strings.Cut(header, " ")
+	if !ok || !strings.EqualFold(scheme, "Bearer") || strings.TrimSpace(token) == "" {
+		return "", ErrInvalidToken
+	}
+	return strings.TrimSpace(token), nil
+}
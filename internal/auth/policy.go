@@ -0,0 +1,106 @@
+package auth
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gorilla/mux"
+
+	"myapp/internal/apperr"
+	"myapp/internal/httpx"
+)
+
+// ค่า role ที่เก็บใน users.role
+const (
+	RoleAdmin    = "admin"
+	RoleHost     = "host"
+	RoleTraveler = "traveler"
+)
+
+// ErrForbidden คือ error เมื่อ principal ไม่ผ่าน policy
+var ErrForbidden = apperr.Forbidden("You do not have permission to perform this action")
+
+// Policy ตัดสินว่า principal นี้มีสิทธิ์ทำ request นี้หรือไม่
+type Policy func(p Principal, r *http.Request) bool
+
+// Roles อนุญาตเฉพาะ principal ที่มี role อยู่ในรายการ
+func Roles(roles ...string) Policy {
+	return func(p Principal, r *http.Request) bool {
+		for _, role := range roles {
+			if p.Role == role {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+// Self อนุญาตเฉพาะเจ้าของ resource: path variable {param} ต้องตรงกับ user ID ใน token
+func Self(param string) Policy {
+	return func(p Principal, r *http.Request) bool {
+		id, err := strconv.ParseInt(mux.Vars(r)[param], 10, 64)
+		return err == nil && id == p.UserID
+	}
+}
+
+// AnyOf ผ่านถ้ามี policy ใด policy หนึ่งผ่าน
+func AnyOf(policies ...Policy) Policy {
+	return func(p Principal, r *http.Request) bool {
+		for _, policy := range policies {
+			if policy(p, r) {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+// Authorize ครอบ handler ด้วย policy; ต้องใช้หลัง Middleware เพื่อให้มี principal ใน context
+func Authorize(policy Policy, next http.HandlerFunc) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		p, ok := PrincipalFrom(r.Context())
+		if !ok {
+			httpx.WriteError(w, r, ErrMissingToken)
+			return
+		}
+		if !policy(p, r) {
+			httpx.WriteError(w, r, ErrForbidden)
+			return
+		}
+		next(w, r)
+	})
+}
+
+// OwnerFunc โหลด resource ที่ request อ้างถึงแล้วคืน user ID ของเจ้าของ (0 = ไม่มีเจ้าของ จัดการได้เฉพาะ admin)
+type OwnerFunc func(r *http.Request) (int64, error)
+
+// CanManage บอกว่า principal แก้ resource ของ ownerID ได้หรือไม่: admin ได้ทุกอัน ส่วน host ได้เฉพาะของตัวเอง
+func CanManage(p Principal, ownerID int64) bool {
+	return p.Role == RoleAdmin || (p.Role == RoleHost && ownerID != 0 && ownerID == p.UserID)
+}
+
+// AuthorizeOwner ครอบ handler ที่แก้ resource ของ host: โหลดเจ้าของด้วย owner ก่อน แล้วให้ผ่านตาม CanManage
+// error จาก owner (เช่น not found) ส่งให้ client ตามปกติ; ต้องใช้หลัง Middleware
+func AuthorizeOwner(owner OwnerFunc, next http.HandlerFunc) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		p, ok := PrincipalFrom(r.Context())
+		if !ok {
+			httpx.WriteError(w, r, ErrMissingToken)
+			return
+		}
+		if p.Role != RoleAdmin && p.Role != RoleHost {
+			httpx.WriteError(w, r, ErrForbidden)
+			return
+		}
+		ownerID, err := owner(r)
+		if err != nil {
+			httpx.WriteError(w, r, err)
+			return
+		}
+		if !CanManage(p, ownerID) {
+			httpx.WriteError(w, r, ErrForbidden)
+			return
+		}
+		next(w, r)
+	})
+}
@@ -0,0 +1,184 @@
+package auth
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gorilla/mux"
+
+	"myapp/internal/apperr"
+)
+
+func TestPolicies(t *testing.T) {
+	admin := Principal{UserID: 1, Role: RoleAdmin}
+	host := Principal{UserID: 2, Role: RoleHost}
+	traveler := Principal{UserID: 3, Role: RoleTraveler}
+
+	tests := []struct {
+		name   string
+		policy Policy
+		p      Principal
+		vars   map[string]string
+		want   bool
+	}{
+		{"admin only allows admin", Roles(RoleAdmin), admin, nil, true},
+		{"admin only denies host", Roles(RoleAdmin), host, nil, false},
+		{"admin only denies traveler", Roles(RoleAdmin), traveler, nil, false},
+		{"host or admin allows admin", Roles(RoleHost, RoleAdmin), admin, nil, true},
+		{"host or admin allows host", Roles(RoleHost, RoleAdmin), host, nil, true},
+		{"host or admin denies traveler", Roles(RoleHost, RoleAdmin), traveler, nil, false},
+		{"no roles denies everyone", Roles(), admin, nil, false},
+		{"unknown role is denied", Roles(RoleHost, RoleAdmin), Principal{UserID: 4, Role: "guest"}, nil, false},
+
+		{"self allows same id", Self("id"), traveler, map[string]string{"id": "3"}, true},
+		{"self denies different id", Self("id"), traveler, map[string]string{"id": "2"}, false},
+		{"self denies non-numeric id", Self("id"), traveler, map[string]string{"id": "abc"}, false},
+		{"self denies missing id", Self("id"), traveler, nil, false},
+		{"self denies admin on other id", Self("id"), admin, map[string]string{"id": "3"}, false},
+
+		{"any of allows self", AnyOf(Self("id"), Roles(RoleAdmin)), traveler, map[string]string{"id": "3"}, true},
+		{"any of allows admin", AnyOf(Self("id"), Roles(RoleAdmin)), admin, map[string]string{"id": "3"}, true},
+		{"any of denies other", AnyOf(Self("id"), Roles(RoleAdmin)), host, map[string]string{"id": "3"}, false},
+		{"any of empty denies", AnyOf(), admin, nil, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest(http.MethodGet, "/", nil)
+			if tt.vars != nil {
+				r = mux.SetURLVars(r, tt.vars)
+			}
+			if got := tt.policy(tt.p, r); got != tt.want {
+				t.Errorf("policy = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAuthorize(t *testing.T) {
+	tests := []struct {
+		name       string
+		principal  *Principal
+		policy     Policy
+		wantStatus int
+		wantBody   string
+	}{
+		{
+			name:       "missing principal",
+			policy:     Roles(RoleAdmin),
+			wantStatus: http.StatusUnauthorized,
+			wantBody:   `{"code":"unauthorized","message":"Authentication is required"}` + "\n",
+		},
+		{
+			name:       "denied",
+			principal:  &Principal{UserID: 3, Role: RoleTraveler},
+			policy:     Roles(RoleAdmin),
+			wantStatus: http.StatusForbidden,
+			wantBody:   `{"code":"forbidden","message":"You do not have permission to perform this action"}` + "\n",
+		},
+		{
+			name:       "allowed",
+			principal:  &Principal{UserID: 1, Role: RoleAdmin},
+			policy:     Roles(RoleAdmin),
+			wantStatus: http.StatusOK,
+			wantBody:   "ok",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := Authorize(tt.policy, func(w http.ResponseWriter, r *http.Request) {
+				w.Write([]byte("ok"))
+			})
+			r := httptest.NewRequest(http.MethodGet, "/", nil)
+			if tt.principal != nil {
+				r = r.WithContext(WithPrincipal(r.Context(), *tt.principal))
+			}
+			rec := httptest.NewRecorder()
+			h.ServeHTTP(rec, r)
+
+			if rec.Code != tt.wantStatus {
+				t.Errorf("status = %d, want %d", rec.Code, tt.wantStatus)
+			}
+			if rec.Body.String() != tt.wantBody {
+				t.Errorf("body = %q, want %q", rec.Body.String(), tt.wantBody)
+			}
+		})
+	}
+}
+
+func TestCanManage(t *testing.T) {
+	tests := []struct {
+		name    string
+		p       Principal
+		ownerID int64
+		want    bool
+	}{
+		{"admin manages any owner", Principal{UserID: 1, Role: RoleAdmin}, 2, true},
+		{"admin manages unowned", Principal{UserID: 1, Role: RoleAdmin}, 0, true},
+		{"host manages own", Principal{UserID: 2, Role: RoleHost}, 2, true},
+		{"host denied other host", Principal{UserID: 2, Role: RoleHost}, 5, false},
+		{"host denied unowned", Principal{UserID: 2, Role: RoleHost}, 0, false},
+		{"traveler denied even as owner", Principal{UserID: 3, Role: RoleTraveler}, 3, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := CanManage(tt.p, tt.ownerID); got != tt.want {
+				t.Errorf("CanManage = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAuthorizeOwner(t *testing.T) {
+	ownedBy := func(id int64) OwnerFunc {
+		return func(r *http.Request) (int64, error) { return id, nil }
+	}
+
+	tests := []struct {
+		name       string
+		principal  *Principal
+		owner      OwnerFunc
+		wantStatus int
+		wantLoaded bool
+	}{
+		{"missing principal", nil, ownedBy(2), http.StatusUnauthorized, false},
+		{"traveler is denied before loading", &Principal{UserID: 3, Role: RoleTraveler}, ownedBy(3), http.StatusForbidden, false},
+		{"host owns resource", &Principal{UserID: 2, Role: RoleHost}, ownedBy(2), http.StatusOK, true},
+		{"host does not own resource", &Principal{UserID: 2, Role: RoleHost}, ownedBy(5), http.StatusForbidden, true},
+		{"admin manages any resource", &Principal{UserID: 1, Role: RoleAdmin}, ownedBy(5), http.StatusOK, true},
+		{
+			"owner lookup fails", &Principal{UserID: 1, Role: RoleAdmin},
+			func(r *http.Request) (int64, error) { return 0, apperr.NotFound("Accommodation not found") },
+			http.StatusNotFound, true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			loaded := false
+			owner := func(r *http.Request) (int64, error) {
+				loaded = true
+				return tt.owner(r)
+			}
+			h := AuthorizeOwner(owner, func(w http.ResponseWriter, r *http.Request) {
+				w.Write([]byte("ok"))
+			})
+			r := httptest.NewRequest(http.MethodPut, "/", nil)
+			if tt.principal != nil {
+				r = r.WithContext(WithPrincipal(r.Context(), *tt.principal))
+			}
+			rec := httptest.NewRecorder()
+			h.ServeHTTP(rec, r)
+
+			if rec.Code != tt.wantStatus {
+				t.Errorf("status = %d, want %d", rec.Code, tt.wantStatus)
+			}
+			if loaded != tt.wantLoaded {
+				t.Errorf("owner loaded = %v, want %v", loaded, tt.wantLoaded)
+			}
+		})
+	}
+}
@@ -0,0 +1,69 @@
+package auth
+
+import (
+	"errors"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+
+	"myapp/internal/apperr"
+)
+
+var (
+	ErrMissingToken = apperr.Unauthorized("unauthorized", "Authentication is required")
+	ErrInvalidToken = apperr.Unauthorized("unauthorized", "Invalid or expired token")
+)
+
+// Claims คือ payload ของ JWT ที่ออกโดย UserHandler.Login
+type Claims struct {
+	UserID int64  `json:"user_id"`
+	Email  string `json:"email"`
+	Role   string `json:"role"`
+	jwt.RegisteredClaims
+}
+
+type TokenManager struct {
+	secret []byte
+	ttl    time.Duration
+}
+
+func NewTokenManager(secret []byte, ttl time.Duration) *TokenManager {
+	return &TokenManager{secret: secret, ttl: ttl}
+}
+
+func (m *TokenManager) TTL() time.Duration {
+	return m.ttl
+}
+
+// Issue สร้าง access token (HS256) สำหรับ principal
+func (m *TokenManager) Issue(p Principal) (string, error) {
+	now := time.Now()
+	claims := Claims{
+		UserID: p.UserID,
+		Email:  p.Email,
+		Role:   p.Role,
+		RegisteredClaims: jwt.RegisteredClaims{
+			IssuedAt:  jwt.NewNumericDate(now),
+			ExpiresAt: jwt.NewNumericDate(now.Add(m.ttl)),
+		},
+	}
+	return jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString(m.secret)
+}
+
+// Parse ตรวจสอบ signature + exp แล้วคืน principal
+func (m *TokenManager) Parse(tokenStr string) (Principal, error) {
+	var claims Claims
+	_, err := jwt.ParseWithClaims(tokenStr, &claims, func(t *jwt.Token) (interface{}, error) {
+		return m.secret, nil
+	},
+		jwt.WithValidMethods([]string{jwt.SigningMethodHS256.Alg()}),
+		jwt.WithExpirationRequired(),
+	)
+	if err != nil {
+		return Principal{}, ErrInvalidToken.Wrap(err)
+	}
+	if claims.UserID <= 0 {
+		return Principal{}, ErrInvalidToken.Wrap(errors.New("missing user_id"))
+	}
+	return Principal{UserID: claims.UserID, Email: claims.Email, Role: claims.Role}, nil
+}
@@ -0,0 +1,79 @@
+package handler
+
+import (
+	"time"
+
+	"myapp/internal/apperr"
+	"myapp/internal/booking/model"
+)
+
+// CreateBookingRequest คือ body ของ POST /bookings; วันที่อยู่ในรูป YYYY-MM-DD
+type CreateBookingRequest struct {
+	AccommodationID int64  `json:"accommodation_id" validate:"required,gte=1"`
+	RoomTypeID      *int64 `json:"room_type_id" validate:"gte=1"`
+	CheckIn         string `json:"check_in" validate:"required"`
+	CheckOut        string `json:"check_out" validate:"required"`
+	Guests          int    `json:"guests" validate:"required,gte=1,lte=50"`
+}
+
+func (req CreateBookingRequest) toModel(userID int64) (model.Booking, error) {
+	fields := map[string]string{}
+	checkIn, err := time.Parse(time.DateOnly, req.CheckIn)
+	if err != nil {
+		fields["check_in"] = "must be a date in YYYY-MM-DD format"
+	}
+	checkOut, err := time.Parse(time.DateOnly, req.CheckOut)
+	if err != nil {
+		fields["check_out"] = "must be a date in YYYY-MM-DD format"
+	}
+	if len(fields) > 0 {
+		return model.Booking{}, apperr.Validation("Validation failed", fields)
+	}
+	return model.Booking{
+		UserID:          userID,
+		AccommodationID: req.AccommodationID,
+		RoomTypeID:      req.RoomTypeID,
+		CheckIn:         checkIn,
+		CheckOut:        checkOut,
+		Guests:          req.Guests,
+	}, nil
+}
+
+// UpdateStatusRequest คือ body ของ PUT /bookings/{id}/status
+type UpdateStatusRequest struct {
+	Status string `json:"status" validate:"required,oneof=confirmed cancelled completed"`
+}
+
+type BookingResponse struct {
+	OrderID         int       `json:"order_id"`
+	UserID          int64     `json:"user_id"`
+	AccommodationID int64     `json:"accommodation_id"`
+	RoomTypeID      *int64    `json:"room_type_id,omitempty"`
+	CheckIn         string    `json:"check_in"`
+	CheckOut        string    `json:"check_out"`
+	Nights          int       `json:"nights"`
+	Guests          int       `json:"guests"`
+	NightlyPrice    float64   `json:"nightly_price"`
+	TotalPrice      float64   `json:"total_price"`
+	Status          string    `json:"status"`
+	CreatedAt       time.Time `json:"created_at"`
+	UpdatedAt       time.Time `json:"updated_at"`
+}
+
+func newBookingResponse(b model.Booking) BookingResponse {
+	return BookingResponse{
+		OrderID:         b.OrderID,
+		UserID:          b.UserID,
+		AccommodationID: b.AccommodationID,
+		RoomTypeID:      b.RoomTypeID,
+		CheckIn:         b.CheckIn.Format(time.DateOnly),
+		CheckOut:        b.CheckOut.Format(time.DateOnly),
+		Nights:          b.Nights(),
+		Guests:          b.Guests,
+		NightlyPrice:    b.NightlyPrice,
+		TotalPrice:      b.TotalPrice,
+		Status:          b.Status,
+		CreatedAt:       b.CreatedAt,
+		UpdatedAt:       b.UpdatedAt,
+	}
+}
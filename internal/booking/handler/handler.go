@@ -0,0 +1,134 @@
+package handler
+
+import (
+	"myapp/internal/auth"
+	"myapp/internal/httpx"
+	"net/http"
+
+	"myapp/internal/booking/model"
+	"myapp/internal/booking/usecase"
+	"myapp/internal/database"
+)
+
+type BookingHandler struct {
+	Usecase usecase.BookingUsecase
+}
+
+func NewBookingHandler(u usecase.BookingUsecase) *BookingHandler {
+	return &BookingHandler{Usecase: u}
+}
+
+// GetAll รองรับ ?status=&accommodation_id=&user_id=&limit=&offset=&cursor=&sort= (ผลลัพธ์จำกัดตามสิทธิ์ของ actor เสมอ)
+func (h *BookingHandler) GetAll(w http.ResponseWriter, r *http.Request) {
+	actor, err := actorFrom(r)
+	if err != nil {
+		httpx.WriteError(w, r, err)
+		return
+	}
+	opts, err := httpx.ListOptions(r)
+	if err != nil {
+		httpx.WriteError(w, r, err)
+		return
+	}
+
+	var f model.BookingFilter
+	if f.Status, err = httpx.QueryOneOf(r, "status",
+		model.StatusPending, model.StatusConfirmed, model.StatusCancelled, model.StatusCompleted); err != nil {
+		httpx.WriteError(w, r, err)
+		return
+	}
+	if f.AccommodationID, err = httpx.QueryID(r, "accommodation_id"); err != nil {
+		httpx.WriteError(w, r, err)
+		return
+	}
+	if f.UserID, err = httpx.QueryID(r, "user_id"); err != nil {
+		httpx.WriteError(w, r, err)
+		return
+	}
+
+	page, err := h.Usecase.List(r.Context(), actor, f, opts)
+	if err != nil {
+		httpx.WriteError(w, r, err)
+		return
+	}
+	httpx.WriteJSON(w, http.StatusOK, database.MapPage(page, newBookingResponse))
+}
+
+func (h *BookingHandler) GetByID(w http.ResponseWriter, r *http.Request) {
+	actor, err := actorFrom(r)
+	if err != nil {
+		httpx.WriteError(w, r, err)
+		return
+	}
+	id, err := httpx.PathID(r, "id")
+	if err != nil {
+		httpx.WriteError(w, r, err)
+		return
+	}
+	b, err := h.Usecase.GetByID(r.Context(), actor, int(id))
+	if err != nil {
+		httpx.WriteError(w, r, err)
+		return
+	}
+	httpx.WriteJSON(w, http.StatusOK, newBookingResponse(b))
+}
+
+// ✅ [POST] /bookings - จองที่พัก (สถานะเริ่มต้น pending)
+func (h *BookingHandler) Create(w http.ResponseWriter, r *http.Request) {
+	actor, err := actorFrom(r)
+	if err != nil {
+		httpx.WriteError(w, r, err)
+		return
+	}
+	var req CreateBookingRequest
+	if err := httpx.Decode(w, r, &req); err != nil {
+		httpx.WriteError(w, r, err)
+		return
+	}
+	b, err := req.toModel(actor.UserID)
+	if err != nil {
+		httpx.WriteError(w, r, err)
+		return
+	}
+
+	created, err := h.Usecase.Create(r.Context(), b)
+	if err != nil {
+		httpx.WriteError(w, r, err)
+		return
+	}
+	httpx.WriteJSON(w, http.StatusCreated, newBookingResponse(created))
+}
+
+// ✅ [PUT] /bookings/{id}/status - confirm / cancel / complete
+func (h *BookingHandler) UpdateStatus(w http.ResponseWriter, r *http.Request) {
+	actor, err := actorFrom(r)
+	if err != nil {
+		httpx.WriteError(w, r, err)
+		return
+	}
+	id, err := httpx.PathID(r, "id")
+	if err != nil {
+		httpx.WriteError(w, r, err)
+		return
+	}
+	var req UpdateStatusRequest
+	if err := httpx.Decode(w, r, &req); err != nil {
+		httpx.WriteError(w, r, err)
+		return
+	}
+
+	b, err := h.Usecase.UpdateStatus(r.Context(), actor, int(id), req.Status)
+	if err != nil {
+		httpx.WriteError(w, r, err)
+		return
+	}
+	httpx.WriteJSON(w, http.StatusOK, newBookingResponse(b))
+}
+
+func actorFrom(r *http.Request) (model.Actor, error) {
+	p, ok := auth.PrincipalFrom(r.Context())
+	if !ok {
+		return model.Actor{}, auth.ErrMissingToken
+	}
+	return model.Actor{UserID: p.UserID, Staff: p.Role == auth.RoleAdmin}, nil
+}
@@ -0,0 +1,63 @@
+package model
+
+import (
+	"slices"
+	"time"
+)
+
+// ค่าของ status
+const (
+	StatusPending   = "pending"
+	StatusConfirmed = "confirmed"
+	StatusCancelled = "cancelled"
+	StatusCompleted = "completed"
+)
+
+// transitions คือการเปลี่ยนสถานะที่อนุญาต; cancelled และ completed เป็นสถานะสุดท้าย
+var transitions = map[string][]string{
+	StatusPending:   {StatusConfirmed, StatusCancelled},
+	StatusConfirmed: {StatusCancelled, StatusCompleted},
+}
+
+// CanTransition บอกว่าเปลี่ยนจาก from เป็น to ได้หรือไม่
+func CanTransition(from, to string) bool {
+	return slices.Contains(transitions[from], to)
+}
+
+// Booking คือการจองหนึ่งรายการ (order); ราคาเป็น snapshot ตอนจอง ไม่เปลี่ยนตามราคาที่พัก/calendar ภายหลัง
+// NightlyPrice คือราคาเฉลี่ยต่อคืนเมื่อราคาแต่ละคืนไม่เท่ากัน
+// CheckIn/CheckOut เป็นวันที่ (UTC เที่ยงคืน); คืนสุดท้ายที่พักคือ CheckOut - 1 วัน
+type Booking struct {
+	OrderID         int
+	UserID          int64
+	AccommodationID int64
+	RoomTypeID      *int64 // nil = จองทั้งที่พัก (ที่พักที่ไม่มีประเภทห้อง)
+	CheckIn         time.Time
+	CheckOut        time.Time
+	Guests          int
+	NightlyPrice    float64
+	TotalPrice      float64
+	Status          string
+	CreatedAt       time.Time
+	UpdatedAt       time.Time
+}
+
+// Nights คือจำนวนคืนที่พัก
+func (b Booking) Nights() int {
+	return int(b.CheckOut.Sub(b.CheckIn).Hours() / 24)
+}
+
+// BookingFilter คือเงื่อนไขของ list (ค่าว่าง/nil = ไม่กรอง)
+type BookingFilter struct {
+	UserID          *int64
+	ParticipantID   *int64 // ผู้จองหรือเจ้าของที่พัก
+	AccommodationID *int64
+	Status          string
+}
+
+// Actor คือผู้ที่เรียก usecase; Staff (admin) จัดการการจองของทุกคนได้
+// คนอื่นเห็นการจองของตัวเองและการจองของที่พักที่ตนเป็นเจ้าของ
+type Actor struct {
+	UserID int64
+	Staff  bool
+}
@@ -0,0 +1,150 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"myapp/internal/booking/model"
+	"myapp/internal/database"
+	"time"
+)
+
+type BookingRepository interface {
+	List(ctx context.Context, f model.BookingFilter, opts database.ListOptions) (database.Page[model.Booking], error)
+	GetByID(ctx context.Context, id int) (model.Booking, error)
+	// Lock อ่าน booking พร้อม row lock (ต้องอยู่ใน transaction)
+	Lock(ctx context.Context, id int) (model.Booking, error)
+	// LockAccommodation lock แถวของที่พักและคืนราคาต่อคืน (ต้องอยู่ใน transaction)
+	// ทุกการจองของที่พักเดียวกันจึงต่อคิวกันที่แถวนี้ ทำให้ตรวจการจองซ้อนได้ถูกต้องแม้มี request พร้อมกัน
+	LockAccommodation(ctx context.Context, accommodationID int64) (float64, error)
+	// AccommodationOwner คือ host_id ของที่พัก (0 เมื่อไม่มีเจ้าของ)
+	AccommodationOwner(ctx context.Context, accommodationID int64) (int64, error)
+	HasOverlap(ctx context.Context, accommodationID int64, checkIn, checkOut time.Time) (bool, error)
+	Create(ctx context.Context, b model.Booking) (int, error)
+	UpdateStatus(ctx context.Context, id int, status string) error
+}
+
+type bookingRepo struct {
+	db *database.DB
+}
+
+func NewBookingRepository(db *database.DB) BookingRepository {
+	return &bookingRepo{db: db}
+}
+
+const bookingColumns = "order_id, user_id, accommodation_id, room_type_id, check_in, check_out, guests, nightly_price, total_price, status, created_at, updated_at"
+
+func scanBooking(row interface{ Scan(...any) error }) (model.Booking, error) {
+	var b model.Booking
+	err := row.Scan(&b.OrderID, &b.UserID, &b.AccommodationID, &b.RoomTypeID, &b.CheckIn, &b.CheckOut, &b.Guests,
+		&b.NightlyPrice, &b.TotalPrice, &b.Status, &b.CreatedAt, &b.UpdatedAt)
+	return b, err
+}
+
+// bookingList คือ sort key ที่ client ใช้ได้ใน ?sort= (ใส่ "-" นำหน้าเพื่อเรียงมากไปน้อย)
+var bookingList = database.ListSpec[model.Booking]{
+	IDColumn: "order_id",
+	ID:       func(b model.Booking) any { return b.OrderID },
+	Sorts: map[string]database.SortKey[model.Booking]{
+		"id":         {Column: "order_id", Value: func(b model.Booking) any { return b.OrderID }},
+		"check_in":   {Column: "check_in", Value: func(b model.Booking) any { return b.CheckIn.Format(time.DateOnly) }},
+		"created_at": {Column: "created_at", Value: func(b model.Booking) any { return b.CreatedAt.Format(time.DateTime) }},
+	},
+	DefaultSort: "-id",
+	Scan:        func(rows *sql.Rows) (model.Booking, error) { return scanBooking(rows) },
+}
+
+func (r *bookingRepo) List(ctx context.Context, f model.BookingFilter, opts database.ListOptions) (database.Page[model.Booking], error) {
+	q := database.Select("booking",
+		"order_id", "user_id", "accommodation_id", "room_type_id", "check_in", "check_out", "guests", "nightly_price", "total_price", "status", "created_at", "updated_at")
+	if f.UserID != nil {
+		q.Where("user_id = ?", *f.UserID)
+	}
+	if f.ParticipantID != nil {
+		q.Where("(user_id = ? OR accommodation_id IN (SELECT accommodation_id FROM accommodation WHERE host_id = ?))",
+			*f.ParticipantID, *f.ParticipantID)
+	}
+	if f.AccommodationID != nil {
+		q.Where("accommodation_id = ?", *f.AccommodationID)
+	}
+	if f.Status != "" {
+		q.Where("status = ?", f.Status)
+	}
+	return database.List(ctx, r.db, q, bookingList, opts)
+}
+
+func (r *bookingRepo) GetByID(ctx context.Context, id int) (model.Booking, error) {
+	ctx, cancel := r.db.WithTimeout(ctx)
+	defer cancel()
+
+	b, err := scanBooking(r.db.Conn(ctx).QueryRowContext(ctx, "SELECT "+bookingColumns+" FROM booking WHERE order_id = ?", id))
+	return b, database.NotFound(err, "Booking not found")
+}
+
+func (r *bookingRepo) Lock(ctx context.Context, id int) (model.Booking, error) {
+	ctx, cancel := r.db.WithTimeout(ctx)
+	defer cancel()
+
+	b, err := scanBooking(r.db.Conn(ctx).QueryRowContext(ctx, "SELECT "+bookingColumns+" FROM booking WHERE order_id = ? FOR UPDATE", id))
+	return b, database.NotFound(err, "Booking not found")
+}
+
+func (r *bookingRepo) LockAccommodation(ctx context.Context, accommodationID int64) (float64, error) {
+	ctx, cancel := r.db.WithTimeout(ctx)
+	defer cancel()
+
+	var price float64
+	err := r.db.Conn(ctx).QueryRowContext(ctx,
+		"SELECT price_per_night FROM accommodation WHERE accommodation_id = ? FOR UPDATE", accommodationID).Scan(&price)
+	return price, database.NotFound(err, "Accommodation not found")
+}
+
+func (r *bookingRepo) AccommodationOwner(ctx context.Context, accommodationID int64) (int64, error) {
+	ctx, cancel := r.db.WithTimeout(ctx)
+	defer cancel()
+
+	var owner int64
+	err := r.db.Conn(ctx).QueryRowContext(ctx,
+		"SELECT COALESCE(host_id, 0) FROM accommodation WHERE accommodation_id = ?", accommodationID).Scan(&owner)
+	return owner, database.NotFound(err, "Accommodation not found")
+}
+
+// HasOverlap บอกว่ามีการจองที่ยัง active (ทุกประเภทห้อง) ทับช่วง [checkIn, checkOut) หรือไม่
+// วัน check-out ชนกับ check-in ของคนถัดไปได้
+func (r *bookingRepo) HasOverlap(ctx context.Context, accommodationID int64, checkIn, checkOut time.Time) (bool, error) {
+	ctx, cancel := r.db.WithTimeout(ctx)
+	defer cancel()
+
+	var exists bool
+	err := r.db.Conn(ctx).QueryRowContext(ctx, `
+		SELECT EXISTS(
+			SELECT 1 FROM booking
+			WHERE accommodation_id = ? AND status IN (?, ?) AND check_in < ? AND check_out > ?
+		)`,
+		accommodationID, model.StatusPending, model.StatusConfirmed,
+		checkOut.Format(time.DateOnly), checkIn.Format(time.DateOnly)).Scan(&exists)
+	return exists, err
+}
+
+func (r *bookingRepo) Create(ctx context.Context, b model.Booking) (int, error) {
+	ctx, cancel := r.db.WithTimeout(ctx)
+	defer cancel()
+
+	res, err := r.db.Conn(ctx).ExecContext(ctx, `
+		INSERT INTO booking (user_id, accommodation_id, room_type_id, check_in, check_out, guests, nightly_price, total_price, status)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+		b.UserID, b.AccommodationID, b.RoomTypeID, b.CheckIn.Format(time.DateOnly), b.CheckOut.Format(time.DateOnly),
+		b.Guests, b.NightlyPrice, b.TotalPrice, b.Status)
+	if err != nil {
+		return 0, err
+	}
+	id, err := res.LastInsertId()
+	return int(id), err
+}
+
+func (r *bookingRepo) UpdateStatus(ctx context.Context, id int, status string) error {
+	ctx, cancel := r.db.WithTimeout(ctx)
+	defer cancel()
+
+	_, err := r.db.Conn(ctx).ExecContext(ctx, "UPDATE booking SET status = ?, updated_at = NOW() WHERE order_id = ?", status, id)
+	return err
+}
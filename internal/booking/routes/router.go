@@ -0,0 +1,42 @@
+package router
+
+import (
+	"github.com/gorilla/mux"
+
+	"myapp/internal/auth"
+	bookingHandler "myapp/internal/booking/handler"
+	bookingRepo "myapp/internal/booking/repository"
+	bookingUsecase "myapp/internal/booking/usecase"
+	"myapp/internal/database"
+	notificationRepo "myapp/internal/notification/repository"
+	roomRepo "myapp/internal/room/repository"
+)
+
+type Module struct {
+	handler *bookingHandler.BookingHandler
+	tokens  *auth.TokenManager
+}
+
+func NewModule(db *database.DB, tokens *auth.TokenManager) *Module {
+	bRepo := bookingRepo.NewBookingRepository(db)
+	bUC := bookingUsecase.NewBookingUsecase(db, bRepo, roomRepo.NewRoomRepository(db), notificationRepo.NewNotificationRepository(db))
+	return &Module{
+		handler: bookingHandler.NewBookingHandler(bUC),
+		tokens:  tokens,
+	}
+}
+
+func (m *Module) Name() string { return "booking" }
+
+func (m *Module) RegisterRoutes(r *mux.Router) {
+	bH := m.handler
+
+	// ✅ ทุก route ของ bookings ต้อง login; สิทธิ์ต่อรายการ (ผู้จอง/เจ้าของที่พัก/admin) ตรวจใน usecase
+	protected := r.PathPrefix("/bookings").Subrouter()
+	protected.Use(auth.Middleware(m.tokens))
+
+	protected.HandleFunc("", bH.GetAll).Methods("GET")
+	protected.HandleFunc("", bH.Create).Methods("POST")
+	protected.HandleFunc("/{id:[0-9]+}", bH.GetByID).Methods("GET")
+	protected.HandleFunc("/{id:[0-9]+}/status", bH.UpdateStatus).Methods("PUT")
+}
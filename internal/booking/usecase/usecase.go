@@ -0,0 +1,244 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"math"
+	"myapp/internal/apperr"
+	"myapp/internal/booking/model"
+	"myapp/internal/booking/repository"
+	"myapp/internal/database"
+	notificationModel "myapp/internal/notification/model"
+	notificationRepo "myapp/internal/notification/repository"
+	roomModel "myapp/internal/room/model"
+	roomRepo "myapp/internal/room/repository"
+	"slices"
+	"strconv"
+	"time"
+)
+
+// MaxNights คือจำนวนคืนสูงสุดต่อการจองหนึ่งครั้ง
+const MaxNights = 90
+
+var (
+	ErrOverlap           = apperr.Conflict("booking_overlap", "The accommodation is already booked for some of these dates")
+	ErrRoomUnavailable   = apperr.Conflict("room_unavailable", "The room type is closed or sold out for some of these dates")
+	ErrInvalidTransition = apperr.Conflict("invalid_status_transition", "The booking cannot change to this status")
+)
+
+type BookingUsecase interface {
+	List(ctx context.Context, actor model.Actor, f model.BookingFilter, opts database.ListOptions) (database.Page[model.Booking], error)
+	GetByID(ctx context.Context, actor model.Actor, id int) (model.Booking, error)
+	Create(ctx context.Context, b model.Booking) (model.Booking, error)
+	UpdateStatus(ctx context.Context, actor model.Actor, id int, status string) (model.Booking, error)
+}
+
+type bookingUsecase struct {
+	tx            database.Transactor
+	repo          repository.BookingRepository
+	rooms         roomRepo.RoomRepository
+	notifications notificationRepo.NotificationRepository
+}
+
+func NewBookingUsecase(tx database.Transactor, r repository.BookingRepository, rooms roomRepo.RoomRepository, notifications notificationRepo.NotificationRepository) BookingUsecase {
+	return &bookingUsecase{tx: tx, repo: r, rooms: rooms, notifications: notifications}
+}
+
+// List: ผู้ที่ไม่ใช่ staff เห็นเฉพาะการจองของตัวเองและการจองของที่พักที่ตนเป็นเจ้าของ
+func (u *bookingUsecase) List(ctx context.Context, actor model.Actor, f model.BookingFilter, opts database.ListOptions) (database.Page[model.Booking], error) {
+	if !actor.Staff {
+		f.ParticipantID = &actor.UserID
+	}
+	return u.repo.List(ctx, f, opts)
+}
+
+// GetByID: การจองของคนอื่นตอบเป็น not found เพื่อไม่บอกว่ามี order นี้อยู่
+func (u *bookingUsecase) GetByID(ctx context.Context, actor model.Actor, id int) (model.Booking, error) {
+	b, err := u.repo.GetByID(ctx, id)
+	if err != nil {
+		return b, err
+	}
+	if actor.Staff || b.UserID == actor.UserID {
+		return b, nil
+	}
+	host, err := u.isHost(ctx, actor, b)
+	if err != nil {
+		return model.Booking{}, err
+	}
+	if !host {
+		return model.Booking{}, apperr.NotFound("Booking not found")
+	}
+	return b, nil
+}
+
+// Create จองที่พักในสถานะ pending พร้อม snapshot ราคา แล้วสร้าง notification ของ order นี้
+// ทั้งหมดอยู่ใน transaction เดียว และ lock แถวที่พักก่อนตรวจวันซ้อน ทำให้ request พร้อมกันจองวันเดียวกันได้แค่รายการเดียว
+func (u *bookingUsecase) Create(ctx context.Context, b model.Booking) (model.Booking, error) {
+	if err := validateStay(b.CheckIn, b.CheckOut); err != nil {
+		return model.Booking{}, err
+	}
+
+	var id int
+	err := u.tx.WithinTx(ctx, func(ctx context.Context) error {
+		price, err := u.repo.LockAccommodation(ctx, b.AccommodationID)
+		if errors.Is(err, apperr.ErrNotFound) {
+			return apperr.Validation("Accommodation does not exist", map[string]string{"accommodation_id": "does not exist"})
+		}
+		if err != nil {
+			return err
+		}
+		if err := u.price(ctx, &b, price); err != nil {
+			return err
+		}
+
+		b.Status = model.StatusPending
+		if id, err = u.repo.Create(ctx, b); err != nil {
+			return err
+		}
+		b.OrderID = id
+		return u.notify(ctx, b)
+	})
+	if err != nil {
+		return model.Booking{}, err
+	}
+	return u.repo.GetByID(ctx, id)
+}
+
+// UpdateStatus เปลี่ยนสถานะตาม lifecycle; ผู้จองยกเลิกได้อย่างเดียว ส่วน staff และเจ้าของที่พักเปลี่ยนได้ทุกแบบที่ lifecycle อนุญาต
+func (u *bookingUsecase) UpdateStatus(ctx context.Context, actor model.Actor, id int, status string) (model.Booking, error) {
+	err := u.tx.WithinTx(ctx, func(ctx context.Context) error {
+		b, err := u.repo.Lock(ctx, id)
+		if err != nil {
+			return err
+		}
+		manage := actor.Staff
+		if !manage {
+			if manage, err = u.isHost(ctx, actor, b); err != nil {
+				return err
+			}
+		}
+		if !manage {
+			if b.UserID != actor.UserID {
+				return apperr.NotFound("Booking not found")
+			}
+			if status != model.StatusCancelled {
+				return apperr.Forbidden("Only the host or an admin can change a booking to " + status)
+			}
+		}
+		if !model.CanTransition(b.Status, status) {
+			return ErrInvalidTransition
+		}
+
+		if err := u.repo.UpdateStatus(ctx, id, status); err != nil {
+			return err
+		}
+		return u.notify(ctx, b)
+	})
+	if err != nil {
+		return model.Booking{}, err
+	}
+	return u.repo.GetByID(ctx, id)
+}
+
+// price ตรวจว่าจองได้และตั้งราคา snapshot ให้ b (ต้องเรียกหลัง lock แถวที่พักแล้ว)
+// ที่พักที่มีประเภทห้องต้องระบุ room_type_id และใช้ราคา/ห้องว่างจาก calendar; ที่พักที่ไม่มีจะจองทั้งหลังด้วย price_per_night
+func (u *bookingUsecase) price(ctx context.Context, b *model.Booking, pricePerNight float64) error {
+	types, err := u.rooms.ListByAccommodation(ctx, b.AccommodationID)
+	if err != nil {
+		return err
+	}
+
+	if b.RoomTypeID == nil {
+		if len(types) > 0 {
+			return apperr.Validation("Room type is required", map[string]string{"room_type_id": "is required for this accommodation"})
+		}
+		overlap, err := u.repo.HasOverlap(ctx, b.AccommodationID, b.CheckIn, b.CheckOut)
+		if err != nil {
+			return err
+		}
+		if overlap {
+			return ErrOverlap
+		}
+		b.NightlyPrice = pricePerNight
+		b.TotalPrice = math.Round(pricePerNight*float64(b.Nights())*100) / 100
+		return nil
+	}
+
+	i := slices.IndexFunc(types, func(rt roomModel.RoomType) bool { return rt.ID == *b.RoomTypeID })
+	if i < 0 {
+		return apperr.Validation("Room type does not exist", map[string]string{"room_type_id": "does not belong to this accommodation"})
+	}
+	days, err := u.rooms.Calendar(ctx, []int64{*b.RoomTypeID}, b.CheckIn, b.CheckOut)
+	if err != nil {
+		return err
+	}
+	stays, err := u.rooms.Stays(ctx, b.AccommodationID, b.CheckIn, b.CheckOut)
+	if err != nil {
+		return err
+	}
+
+	av := roomModel.Compute(types[i], days, stays, b.CheckIn, b.CheckOut, b.Guests)
+	switch av.Reason {
+	case roomModel.ReasonCapacity:
+		return apperr.Validation("Too many guests", map[string]string{"guests": "must not exceed " + strconv.Itoa(types[i].Capacity)})
+	case roomModel.ReasonMinStay:
+		return apperr.Validation("Stay is too short", map[string]string{"check_out": "stay must be at least " + strconv.Itoa(av.MinStay) + " nights"})
+	case roomModel.ReasonClosed, roomModel.ReasonSoldOut:
+		return ErrRoomUnavailable
+	}
+	b.TotalPrice = av.TotalPrice
+	b.NightlyPrice = math.Round(av.TotalPrice/float64(b.Nights())*100) / 100
+	return nil
+}
+
+// isHost บอกว่า actor เป็นเจ้าของที่พักของการจองนี้หรือไม่
+func (u *bookingUsecase) isHost(ctx context.Context, actor model.Actor, b model.Booking) (bool, error) {
+	owner, err := u.repo.AccommodationOwner(ctx, b.AccommodationID)
+	if err != nil {
+		return false, err
+	}
+	return owner != 0 && owner == actor.UserID, nil
+}
+
+// notify แจ้งผู้จองและเจ้าของที่พัก (ถ้ามี) ว่าการจองนี้มีการเปลี่ยนแปลง
+func (u *bookingUsecase) notify(ctx context.Context, b model.Booking) error {
+	recipients := []int64{b.UserID}
+	host, err := u.repo.AccommodationOwner(ctx, b.AccommodationID)
+	if err != nil {
+		return err
+	}
+	if host != 0 && host != b.UserID {
+		recipients = append(recipients, host)
+	}
+
+	for _, userID := range recipients {
+		err := u.notifications.Create(ctx, notificationModel.Notification{
+			UserID:             &userID,
+			StatusNotification: notificationModel.StatusUnread,
+			OrderID:            &b.OrderID,
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func validateStay(checkIn, checkOut time.Time) error {
+	today := time.Now().UTC().Truncate(24 * time.Hour)
+	fields := map[string]string{}
+	if checkIn.Before(today) {
+		fields["check_in"] = "must not be in the past"
+	}
+	nights := int(checkOut.Sub(checkIn).Hours() / 24)
+	switch {
+	case nights < 1:
+		fields["check_out"] = "must be after check_in"
+	case nights > MaxNights:
+		fields["check_out"] = "stay must not exceed " + strconv.Itoa(MaxNights) + " nights"
+	}
+	if len(fields) > 0 {
+		return apperr.Validation("Invalid stay dates", fields)
+	}
+	return nil
+}
@@ -0,0 +1,322 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/joho/godotenv"
+)
+
+type Config struct {
+	HTTP    HTTPConfig
+	DB      DBConfig
+	JWT     JWTConfig
+	SMTP    SMTPConfig
+	OTP     OTPConfig
+	Jobs    JobsConfig
+	Log     LogConfig
+	Storage StorageConfig
+}
+
+// driver ของ STORAGE_DRIVER
+const (
+	StorageLocal = "local"
+	StorageS3    = "s3"
+)
+
+// StorageConfig คือที่เก็บไฟล์ที่ upload; PublicURL คือ base URL ที่ต่อกับ object key เป็น URL ให้ client
+// (ว่าง = /files ของ server เองสำหรับ local หรือ URL ของ bucket สำหรับ s3)
+type StorageConfig struct {
+	Driver    string
+	LocalDir  string
+	PublicURL string
+	S3        S3Config
+}
+
+// S3Config คือ bucket ของ S3 หรือ service ที่ใช้ API เดียวกัน เช่น MinIO (PathStyle = true)
+type S3Config struct {
+	Endpoint  string
+	Region    string
+	Bucket    string
+	AccessKey string
+	SecretKey string
+	PathStyle bool
+}
+
+// LogConfig คือระดับและรูปแบบของ log (text หรือ json)
+type LogConfig struct {
+	Level  slog.Level
+	Format string
+}
+
+type HTTPConfig struct {
+	Addr            string
+	ReadTimeout     time.Duration
+	WriteTimeout    time.Duration
+	IdleTimeout     time.Duration
+	ShutdownTimeout time.Duration
+}
+
+type DBConfig struct {
+	Host     string
+	Port     int
+	User     string
+	Password string
+	Name     string
+	Params   string
+
+	QueryTimeout time.Duration
+}
+
+type JWTConfig struct {
+	Secret     string
+	AccessTTL  time.Duration
+	RefreshTTL time.Duration
+}
+
+// OTPConfig คือ limit ของการขอและตรวจ OTP
+type OTPConfig struct {
+	MaxAttempts         int
+	SendCooldown        time.Duration
+	SendWindow          time.Duration
+	SendLimitPerEmail   int
+	SendLimitPerIP      int
+	VerifyWindow        time.Duration
+	VerifyLimitPerEmail int
+	VerifyLimitPerIP    int
+	LockoutDuration     time.Duration
+}
+
+// JobsConfig คือรอบการทำงานของ background job
+type JobsConfig struct {
+	Enabled            bool
+	Jitter             time.Duration
+	Timeout            time.Duration
+	OTPCleanupInterval time.Duration
+}
+
+type SMTPConfig struct {
+	From     string
+	Password string
+	Host     string
+	Port     string
+}
+
+// Load อ่าน .env (ถ้ามี), ไฟล์ที่ระบุใน CONFIG_FILE (ถ้ามี) แล้วตามด้วย environment variables
+// ค่าใน environment จริงมีลำดับความสำคัญสูงกว่าค่าในไฟล์เสมอ
+func Load() (Config, error) {
+	if file := os.Getenv("CONFIG_FILE"); file != "" {
+		if err := godotenv.Load(file); err != nil {
+			return Config{}, fmt.Errorf("load config file %s: %w", file, err)
+		}
+	}
+	if err := godotenv.Load(); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return Config{}, fmt.Errorf("load .env: %w", err)
+	}
+
+	var errs []error
+	cfg := Config{
+		HTTP: HTTPConfig{
+			Addr:            getString("HTTP_ADDR", "0.0.0.0:5000"),
+			ReadTimeout:     getDuration("HTTP_READ_TIMEOUT", 15*time.Second, &errs),
+			WriteTimeout:    getDuration("HTTP_WRITE_TIMEOUT", 60*time.Second, &errs),
+			IdleTimeout:     getDuration("HTTP_IDLE_TIMEOUT", 120*time.Second, &errs),
+			ShutdownTimeout: getDuration("HTTP_SHUTDOWN_TIMEOUT", 30*time.Second, &errs),
+		},
+		DB: DBConfig{
+			Host:     getString("DB_HOST", ""),
+			Port:     getInt("DB_PORT", 3306, &errs),
+			User:     getString("DB_USER", ""),
+			Password: getString("DB_PASSWORD", ""),
+			Name:     getString("DB_NAME", ""),
+			Params:   getString("DB_PARAMS", "parseTime=true"),
+
+			QueryTimeout: getDuration("DB_QUERY_TIMEOUT", 5*time.Second, &errs),
+		},
+		JWT: JWTConfig{
+			Secret:     getString("JWT_SECRET", ""),
+			AccessTTL:  getDuration("JWT_ACCESS_TTL", 15*time.Minute, &errs),
+			RefreshTTL: getDuration("JWT_REFRESH_TTL", 30*24*time.Hour, &errs),
+		},
+		SMTP: SMTPConfig{
+			From:     getString("SMTP_FROM", ""),
+			Password: getString("SMTP_PASSWORD", ""),
+			Host:     getString("SMTP_HOST", ""),
+			Port:     getString("SMTP_PORT", "587"),
+		},
+		OTP: OTPConfig{
+			MaxAttempts:         getInt("OTP_MAX_ATTEMPTS", 5, &errs),
+			SendCooldown:        getDuration("OTP_SEND_COOLDOWN", time.Minute, &errs),
+			SendWindow:          getDuration("OTP_SEND_WINDOW", time.Hour, &errs),
+			SendLimitPerEmail:   getInt("OTP_SEND_LIMIT_PER_EMAIL", 5, &errs),
+			SendLimitPerIP:      getInt("OTP_SEND_LIMIT_PER_IP", 20, &errs),
+			VerifyWindow:        getDuration("OTP_VERIFY_WINDOW", 15*time.Minute, &errs),
+			VerifyLimitPerEmail: getInt("OTP_VERIFY_LIMIT_PER_EMAIL", 10, &errs),
+			VerifyLimitPerIP:    getInt("OTP_VERIFY_LIMIT_PER_IP", 50, &errs),
+			LockoutDuration:     getDuration("OTP_LOCKOUT_DURATION", 15*time.Minute, &errs),
+		},
+		Jobs: JobsConfig{
+			Enabled:            getBool("JOBS_ENABLED", true, &errs),
+			Jitter:             getDuration("JOBS_JITTER", 30*time.Second, &errs),
+			Timeout:            getDuration("JOBS_TIMEOUT", time.Minute, &errs),
+			OTPCleanupInterval: getDuration("JOBS_OTP_CLEANUP_INTERVAL", 5*time.Minute, &errs),
+		},
+		Log: LogConfig{
+			Level:  getLevel("LOG_LEVEL", slog.LevelInfo, &errs),
+			Format: strings.ToLower(getString("LOG_FORMAT", "text")),
+		},
+		Storage: StorageConfig{
+			Driver:    strings.ToLower(getString("STORAGE_DRIVER", StorageLocal)),
+			LocalDir:  getString("STORAGE_LOCAL_DIR", "uploads"),
+			PublicURL: getString("STORAGE_PUBLIC_URL", ""),
+			S3: S3Config{
+				Endpoint:  getString("S3_ENDPOINT", ""),
+				Region:    getString("S3_REGION", "us-east-1"),
+				Bucket:    getString("S3_BUCKET", ""),
+				AccessKey: getString("S3_ACCESS_KEY", ""),
+				SecretKey: getString("S3_SECRET_KEY", ""),
+				PathStyle: getBool("S3_PATH_STYLE", true, &errs),
+			},
+		},
+	}
+
+	if err := cfg.Validate(); err != nil {
+		errs = append(errs, err)
+	}
+	if len(errs) > 0 {
+		return Config{}, errors.Join(errs...)
+	}
+	return cfg, nil
+}
+
+// Validate ตรวจค่าที่จำเป็นต้องมีก่อนเริ่ม server
+func (c Config) Validate() error {
+	var missing []string
+	required := []struct{ key, value string }{
+		{"DB_HOST", c.DB.Host},
+		{"DB_USER", c.DB.User},
+		{"DB_NAME", c.DB.Name},
+		{"JWT_SECRET", c.JWT.Secret},
+		{"SMTP_FROM", c.SMTP.From},
+		{"SMTP_HOST", c.SMTP.Host},
+	}
+	for _, r := range required {
+		if strings.TrimSpace(r.value) == "" {
+			missing = append(missing, r.key)
+		}
+	}
+	if len(missing) > 0 {
+		return fmt.Errorf("missing required config: %s", strings.Join(missing, ", "))
+	}
+	if len(c.JWT.Secret) < 16 {
+		return errors.New("JWT_SECRET must be at least 16 characters")
+	}
+	if c.Log.Format != "text" && c.Log.Format != "json" {
+		return fmt.Errorf("LOG_FORMAT must be text or json: %q", c.Log.Format)
+	}
+	return c.Storage.Validate()
+}
+
+// Validate ตรวจค่าของ driver ที่เลือก
+func (c StorageConfig) Validate() error {
+	switch c.Driver {
+	case StorageLocal:
+		if strings.TrimSpace(c.LocalDir) == "" {
+			return errors.New("STORAGE_LOCAL_DIR must not be empty")
+		}
+	case StorageS3:
+		var missing []string
+		required := []struct{ key, value string }{
+			{"S3_ENDPOINT", c.S3.Endpoint},
+			{"S3_REGION", c.S3.Region},
+			{"S3_BUCKET", c.S3.Bucket},
+			{"S3_ACCESS_KEY", c.S3.AccessKey},
+			{"S3_SECRET_KEY", c.S3.SecretKey},
+		}
+		for _, r := range required {
+			if strings.TrimSpace(r.value) == "" {
+				missing = append(missing, r.key)
+			}
+		}
+		if len(missing) > 0 {
+			return fmt.Errorf("missing required config for STORAGE_DRIVER=s3: %s", strings.Join(missing, ", "))
+		}
+	default:
+		return fmt.Errorf("STORAGE_DRIVER must be local or s3: %q", c.Driver)
+	}
+	return nil
+}
+
+// DSN สำหรับ go-sql-driver/mysql (มี password ห้ามนำไป log)
+func (c DBConfig) DSN() string {
+	return fmt.Sprintf("%s:%s@tcp(%s:%d)/%s?%s", c.User, c.Password, c.Host, c.Port, c.Name, c.Params)
+}
+
+// Redacted คือ DSN ที่ซ่อน password แล้ว ใช้สำหรับ log ได้
+func (c DBConfig) Redacted() string {
+	return fmt.Sprintf("%s:***@tcp(%s:%d)/%s", c.User, c.Host, c.Port, c.Name)
+}
+
+func getString(key, fallback string) string {
+	if v, ok := os.LookupEnv(key); ok && v != "" {
+		return v
+	}
+	return fallback
+}
+
+func getInt(key string, fallback int, errs *[]error) int {
+	v, ok := os.LookupEnv(key)
+	if !ok || v == "" {
+		return fallback
+	}
+	n, err := strconv.Atoi(v)
+	if err != nil {
+		*errs = append(*errs, fmt.Errorf("%s must be an integer: %q", key, v))
+		return fallback
+	}
+	return n
+}
+
+func getBool(key string, fallback bool, errs *[]error) bool {
+	v, ok := os.LookupEnv(key)
+	if !ok || v == "" {
+		return fallback
+	}
+	b, err := strconv.ParseBool(v)
+	if err != nil {
+		*errs = append(*errs, fmt.Errorf("%s must be true or false: %q", key, v))
+		return fallback
+	}
+	return b
+}
+
+func getLevel(key string, fallback slog.Level, errs *[]error) slog.Level {
+	v, ok := os.LookupEnv(key)
+	if !ok || v == "" {
+		return fallback
+	}
+	var l slog.Level
+	if err := l.UnmarshalText([]byte(v)); err != nil {
+		*errs = append(*errs, fmt.Errorf("%s must be debug, info, warn or error: %q", key, v))
+		return fallback
+	}
+	return l
+}
+
+func getDuration(key string, fallback time.Duration, errs *[]error) time.Duration {
+	v, ok := os.LookupEnv(key)
+	if !ok || v == "" {
+		return fallback
+	}
+	d, err := time.ParseDuration(v)
+	if err != nil {
+		*errs = append(*errs, fmt.Errorf("%s must be a duration such as 15m: %q", key, v))
+		return fallback
+	}
+	return d
+}
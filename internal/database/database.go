@@ -0,0 +1,26 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"time"
+)
+
+// DB คือ *sql.DB ที่ repository ใช้ร่วมกัน พร้อม timeout ต่อ query
+type DB struct {
+	*sql.DB
+	QueryTimeout time.Duration
+}
+
+func New(db *sql.DB, queryTimeout time.Duration) *DB {
+	return &DB{DB: db, QueryTimeout: queryTimeout}
+}
+
+// WithTimeout ผูก deadline ต่อ query เข้ากับ context ของ request
+// ต้องเรียก cancel หลังอ่าน rows เสร็จแล้วเท่านั้น
+func (d *DB) WithTimeout(ctx context.Context) (context.Context, context.CancelFunc) {
+	if d.QueryTimeout <= 0 {
+		return context.WithCancel(ctx)
+	}
+	return context.WithTimeout(ctx, d.QueryTimeout)
+}
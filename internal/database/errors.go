@@ -0,0 +1,52 @@
+package database
+
+import (
+	"database/sql"
+	"errors"
+
+	"github.com/go-sql-driver/mysql"
+
+	"myapp/internal/apperr"
+)
+
+// error number ของ MySQL
+const (
+	mysqlDuplicateEntry  = 1062 // ชน unique key
+	mysqlRowIsReferenced = 1451 // ลบ/แก้แถวที่ยังมี foreign key ชี้อยู่
+)
+
+// NotFound แปลง sql.ErrNoRows เป็น apperr not found (ยังเช็ก errors.Is(err, sql.ErrNoRows) ได้); error อื่นคืนตามเดิม
+func NotFound(err error, message string) error {
+	if errors.Is(err, sql.ErrNoRows) {
+		return apperr.NotFound(message).Wrap(err)
+	}
+	return err
+}
+
+// IsDuplicate บอกว่า err เกิดจากการ insert/update ชน unique key
+func IsDuplicate(err error) bool {
+	var me *mysql.MySQLError
+	return errors.As(err, &me) && me.Number == mysqlDuplicateEntry
+}
+
+// IsReferenced บอกว่า err เกิดจากการลบแถวที่ตารางอื่นยังอ้างถึงผ่าน foreign key
+func IsReferenced(err error) bool {
+	var me *mysql.MySQLError
+	return errors.As(err, &me) && me.Number == mysqlRowIsReferenced
+}
+
+// RequireAffected คืน not found เมื่อ statement ไม่โดนแถวใดเลย
+// ใช้กับ DELETE เท่านั้น: UPDATE ของ MySQL นับ 0 แถวเมื่อค่าไม่เปลี่ยน
+func RequireAffected(res sql.Result, err error, message string) error {
+	if err != nil {
+		return err
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return apperr.NotFound(message)
+	}
+	return nil
+}
@@ -0,0 +1,207 @@
+package database
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"encoding/base64"
+	"encoding/json"
+	"slices"
+	"strings"
+
+	"myapp/internal/apperr"
+)
+
+const (
+	DefaultPageLimit = 20
+	MaxPageLimit     = 100
+)
+
+// ListOptions คือ pagination + sort ที่ client ขอ
+// ใช้ Cursor (keyset) หรือ Offset อย่างใดอย่างหนึ่ง; Sort คือ key ใน whitelist เช่น "name" หรือ "-name" (มากไปน้อย)
+type ListOptions struct {
+	Limit  int
+	Offset int
+	Cursor string
+	Sort   string
+}
+
+// Page คือผลลัพธ์ของ list endpoint
+// Total นับทุกแถวที่ตรง filter; NextCursor ว่างเมื่อไม่มีหน้าถัดไป
+type Page[T any] struct {
+	Items      []T    `json:"items"`
+	Total      int64  `json:"total"`
+	Limit      int    `json:"limit"`
+	Offset     int    `json:"offset,omitempty"`
+	NextCursor string `json:"next_cursor,omitempty"`
+}
+
+// MapPage แปลง item ในหน้า (เช่น model -> response DTO) โดยคง metadata เดิม
+func MapPage[T, R any](p Page[T], fn func(T) R) Page[R] {
+	items := make([]R, 0, len(p.Items))
+	for _, it := range p.Items {
+		items = append(items, fn(it))
+	}
+	return Page[R]{Items: items, Total: p.Total, Limit: p.Limit, Offset: p.Offset, NextCursor: p.NextCursor}
+}
+
+// SortKey คือ column ที่ยอมให้ sort ได้ และวิธีอ่านค่าของ column นั้นจาก item เพื่อสร้าง cursor
+// column ที่ใช้ควรเป็น NOT NULL (keyset เทียบ NULL ไม่ได้)
+type SortKey[T any] struct {
+	Column string
+	Value  func(T) any
+}
+
+// ListSpec อธิบายวิธี list ของ repository หนึ่งตัว
+type ListSpec[T any] struct {
+	IDColumn    string
+	ID          func(T) any
+	Sorts       map[string]SortKey[T]
+	DefaultSort string
+	Scan        func(*sql.Rows) (T, error)
+}
+
+type cursor struct {
+	Sort  string          `json:"s"`
+	Value json.RawMessage `json:"v,omitempty"`
+	ID    json.RawMessage `json:"id"`
+}
+
+// List รัน q แบบแบ่งหน้าตาม opts (q ต้องมีแค่ SELECT/FROM/WHERE ห้ามมี ORDER BY/LIMIT)
+// ดึงเกิน 1 แถวเพื่อรู้ว่ามีหน้าถัดไปหรือไม่ แล้วนับ total ด้วยเงื่อนไขเดียวกัน (ไม่รวมเงื่อนไขของ cursor)
+func List[T any](ctx context.Context, db *DB, q *Query, spec ListSpec[T], opts ListOptions) (Page[T], error) {
+	ctx, cancel := db.WithTimeout(ctx)
+	defer cancel()
+
+	limit := opts.Limit
+	if limit <= 0 {
+		limit = DefaultPageLimit
+	}
+	if limit > MaxPageLimit {
+		limit = MaxPageLimit
+	}
+	page := Page[T]{Items: []T{}, Limit: limit, Offset: opts.Offset}
+
+	sortName := opts.Sort
+	if sortName == "" {
+		sortName = spec.DefaultSort
+	}
+	key, desc := strings.TrimPrefix(sortName, "-"), strings.HasPrefix(sortName, "-")
+	sk, ok := spec.Sorts[key]
+	if !ok {
+		return page, apperr.Validation("Invalid sort", map[string]string{"sort": "must be one of " + sortKeys(spec.Sorts)})
+	}
+
+	countSQL, countArgs := q.CountSQL()
+	if err := db.Conn(ctx).QueryRowContext(ctx, countSQL, countArgs...).Scan(&page.Total); err != nil {
+		return page, err
+	}
+
+	sel := q.Clone()
+	if opts.Cursor != "" {
+		if err := applyCursor(sel, spec, sk, sortName, desc, opts.Cursor); err != nil {
+			return page, err
+		}
+	}
+	if sk.Column != spec.IDColumn {
+		sel.OrderBy(sk.Column, desc)
+	}
+	sel.OrderBy(spec.IDColumn, desc).Limit(limit + 1).Offset(opts.Offset)
+
+	query, args := sel.SQL()
+	rows, err := db.Conn(ctx).QueryContext(ctx, query, args...)
+	if err != nil {
+		return page, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		it, err := spec.Scan(rows)
+		if err != nil {
+			return page, err
+		}
+		page.Items = append(page.Items, it)
+	}
+	if err := rows.Err(); err != nil {
+		return page, err
+	}
+
+	if len(page.Items) > limit {
+		page.Items = page.Items[:limit]
+		last := page.Items[limit-1]
+		page.NextCursor, err = encodeCursor(sortName, sk.Value(last), spec.ID(last), sk.Column == spec.IDColumn)
+		if err != nil {
+			return page, err
+		}
+	}
+	return page, nil
+}
+
+// applyCursor เพิ่มเงื่อนไข keyset: (col, id) > (v, id) สำหรับ ASC และ < สำหรับ DESC
+func applyCursor[T any](q *Query, spec ListSpec[T], sk SortKey[T], sortName string, desc bool, raw string) error {
+	invalid := apperr.Validation("Invalid cursor", map[string]string{"cursor": "is malformed or does not match sort"})
+
+	b, err := base64.RawURLEncoding.DecodeString(raw)
+	if err != nil {
+		return invalid
+	}
+	var c cursor
+	if err := json.Unmarshal(b, &c); err != nil || c.Sort != sortName || len(c.ID) == 0 {
+		return invalid
+	}
+	id, err := decodeValue(c.ID)
+	if err != nil {
+		return invalid
+	}
+
+	op := ">"
+	if desc {
+		op = "<"
+	}
+	if sk.Column == spec.IDColumn {
+		q.Where(spec.IDColumn+" "+op+" ?", id)
+		return nil
+	}
+	v, err := decodeValue(c.Value)
+	if err != nil || v == nil {
+		return invalid
+	}
+	q.Where(sk.Column+" "+op+" ? OR ("+sk.Column+" = ? AND "+spec.IDColumn+" "+op+" ?)", v, v, id)
+	return nil
+}
+
+func encodeCursor(sortName string, value, id any, idOnly bool) (string, error) {
+	c := map[string]any{"s": sortName, "id": id}
+	if !idOnly {
+		c["v"] = value
+	}
+	b, err := json.Marshal(c)
+	if err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+// decodeValue อ่านค่าใน cursor เป็น string หรือ json.Number (ส่งเป็น arg ให้ MySQL เทียบเอง)
+func decodeValue(raw json.RawMessage) (any, error) {
+	dec := json.NewDecoder(bytes.NewReader(raw))
+	dec.UseNumber()
+	var v any
+	if err := dec.Decode(&v); err != nil {
+		return nil, err
+	}
+	switch v.(type) {
+	case string, json.Number:
+		return v, nil
+	}
+	return nil, apperr.Invalid("unsupported cursor value")
+}
+
+func sortKeys[T any](sorts map[string]SortKey[T]) string {
+	keys := make([]string, 0, len(sorts))
+	for k := range sorts {
+		keys = append(keys, k)
+	}
+	slices.Sort(keys) // เรียงเพื่อให้ข้อความ error คงที่
+	return strings.Join(keys, ", ")
+}
@@ -0,0 +1,126 @@
+package database
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// identRe คือรูปแบบชื่อ column/table ที่ยอมให้ต่อเข้า SQL ได้ตรงๆ (เช่น "a.name", "createdAt")
+var identRe = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*(\.[A-Za-z_][A-Za-z0-9_]*)?$`)
+
+// Query ประกอบ SELECT แบบปลอดภัย: ค่าจาก client ต้องผ่าน args (placeholder ?) เท่านั้น
+// ส่วนชื่อ table/column ต้องเป็นค่าคงที่ในโค้ดหรือมาจาก whitelist และผ่าน identRe
+type Query struct {
+	from    string
+	columns []string
+	where   []string
+	args    []any
+	orderBy []string
+	limit   int
+	offset  int
+}
+
+// Select เริ่ม query จาก from (table หรือ table + JOIN ที่เขียนไว้ในโค้ด)
+func Select(from string, columns ...string) *Query {
+	for _, c := range columns {
+		mustIdent(c)
+	}
+	return &Query{from: from, columns: columns}
+}
+
+// Where เพิ่มเงื่อนไข (AND กันทั้งหมด); cond ต้องเป็น literal ในโค้ด ค่าให้ส่งผ่าน args
+func (q *Query) Where(cond string, args ...any) *Query {
+	if strings.Count(cond, "?") != len(args) {
+		panic(fmt.Sprintf("database: %q expects %d args, got %d", cond, strings.Count(cond, "?"), len(args)))
+	}
+	q.where = append(q.where, "("+cond+")")
+	q.args = append(q.args, args...)
+	return q
+}
+
+// WhereIn เพิ่มเงื่อนไข column IN (?, ?, ...); values ว่างจะไม่ตรงแถวใดเลย
+func (q *Query) WhereIn(column string, values ...any) *Query {
+	mustIdent(column)
+	if len(values) == 0 {
+		return q.Where("1 = 0")
+	}
+	return q.Where(column+" IN (?"+strings.Repeat(", ?", len(values)-1)+")", values...)
+}
+
+// OrderBy เรียงตาม column (ต้องมาจาก whitelist ไม่ใช่ input ของ client ตรงๆ)
+func (q *Query) OrderBy(column string, desc bool) *Query {
+	mustIdent(column)
+	dir := "ASC"
+	if desc {
+		dir = "DESC"
+	}
+	q.orderBy = append(q.orderBy, column+" "+dir)
+	return q
+}
+
+func (q *Query) Limit(n int) *Query {
+	q.limit = n
+	return q
+}
+
+func (q *Query) Offset(n int) *Query {
+	q.offset = n
+	return q
+}
+
+// SQL คืน statement พร้อม args ตามลำดับ placeholder
+func (q *Query) SQL() (string, []any) {
+	var b strings.Builder
+	b.WriteString("SELECT ")
+	b.WriteString(strings.Join(q.columns, ", "))
+	b.WriteString(" FROM ")
+	b.WriteString(q.from)
+	args := q.writeWhere(&b)
+	if len(q.orderBy) > 0 {
+		b.WriteString(" ORDER BY ")
+		b.WriteString(strings.Join(q.orderBy, ", "))
+	}
+	if q.limit > 0 {
+		b.WriteString(" LIMIT ?")
+		args = append(args, q.limit)
+		if q.offset > 0 {
+			b.WriteString(" OFFSET ?")
+			args = append(args, q.offset)
+		}
+	}
+	return b.String(), args
+}
+
+// CountSQL คืน SELECT COUNT(*) ที่ใช้เงื่อนไขเดียวกัน (ไม่สน ORDER BY/LIMIT)
+func (q *Query) CountSQL() (string, []any) {
+	var b strings.Builder
+	b.WriteString("SELECT COUNT(*) FROM ")
+	b.WriteString(q.from)
+	args := q.writeWhere(&b)
+	return b.String(), args
+}
+
+// Clone คัดลอก query เพื่อเพิ่มเงื่อนไขต่อโดยไม่กระทบตัวเดิม
+func (q *Query) Clone() *Query {
+	c := *q
+	c.columns = append([]string(nil), q.columns...)
+	c.where = append([]string(nil), q.where...)
+	c.args = append([]any(nil), q.args...)
+	c.orderBy = append([]string(nil), q.orderBy...)
+	return &c
+}
+
+func (q *Query) writeWhere(b *strings.Builder) []any {
+	if len(q.where) > 0 {
+		b.WriteString(" WHERE ")
+		b.WriteString(strings.Join(q.where, " AND "))
+	}
+	return append([]any(nil), q.args...)
+}
+
+func mustIdent(name string) {
+	if !identRe.MatchString(name) {
+		panic(fmt.Sprintf("database: invalid identifier %q", name))
+	}
+}
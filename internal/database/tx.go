@@ -0,0 +1,61 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+)
+
+// Executor คือสิ่งที่ repository ใช้รัน SQL ได้ทั้ง *sql.DB และ *sql.Tx
+type Executor interface {
+	ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error)
+	QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error)
+	QueryRowContext(ctx context.Context, query string, args ...any) *sql.Row
+}
+
+// Transactor ให้ usecase รวมหลาย repository call เป็น unit of work เดียว
+// โดยไม่ต้องรู้จัก *sql.Tx: repository ที่ใช้ Conn(ctx) จะหยิบ transaction จาก ctx เอง
+type Transactor interface {
+	WithinTx(ctx context.Context, fn func(ctx context.Context) error) error
+}
+
+type txKey struct{}
+
+// Conn คืน transaction ที่ผูกกับ ctx (ถ้ามี) ไม่งั้นคืน connection pool ปกติ
+func (d *DB) Conn(ctx context.Context) Executor {
+	if tx, ok := ctx.Value(txKey{}).(*sql.Tx); ok {
+		return tx
+	}
+	return d.DB
+}
+
+// WithinTx รัน fn ใน transaction: commit เมื่อ fn คืน nil, rollback เมื่อ error หรือ panic
+// ถ้า ctx อยู่ใน transaction อยู่แล้วจะใช้ transaction เดิม (nested call ไม่เปิดใหม่)
+func (d *DB) WithinTx(ctx context.Context, fn func(ctx context.Context) error) (err error) {
+	if _, ok := ctx.Value(txKey{}).(*sql.Tx); ok {
+		return fn(ctx)
+	}
+
+	tx, err := d.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("begin tx: %w", err)
+	}
+
+	defer func() {
+		if p := recover(); p != nil {
+			tx.Rollback()
+			panic(p)
+		}
+		if err != nil {
+			tx.Rollback()
+		}
+	}()
+
+	if err = fn(context.WithValue(ctx, txKey{}, tx)); err != nil {
+		return err
+	}
+	if err = tx.Commit(); err != nil {
+		return fmt.Errorf("commit tx: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,29 @@
+package handler
+
+import "myapp/internal/district/model"
+
+// DistrictRequest คือ body ของ POST /districts
+type DistrictRequest struct {
+	Name       string `json:"name" validate:"required,max=255"`
+	ProvinceID int64  `json:"province_id" validate:"required,gte=1"`
+}
+
+// UpdateDistrictRequest คือ body ของ PUT /districts (ระบุ id ใน body)
+type UpdateDistrictRequest struct {
+	ID int64 `json:"id" validate:"required,gte=1"`
+	DistrictRequest
+}
+
+func (req DistrictRequest) toModel() model.District {
+	return model.District{Name: req.Name, ProvinceID: req.ProvinceID}
+}
+
+type DistrictResponse struct {
+	ID         int64  `json:"id"`
+	Name       string `json:"name"`
+	ProvinceID int64  `json:"province_id"`
+}
+
+func newDistrictResponse(d model.District) DistrictResponse {
+	return DistrictResponse{ID: d.ID, Name: d.Name, ProvinceID: d.ProvinceID}
+}
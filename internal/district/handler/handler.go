@@ -1,11 +1,10 @@
 package handler
 
 import (
-	"encoding/json"
+	"myapp/internal/httpx"
 	"net/http"
-	"strconv"
 
-	"github.com/gorilla/mux"
+	"myapp/internal/database"
 	"myapp/internal/district/model"
 	"myapp/internal/district/usecase"
 )
@@ -18,46 +17,74 @@ func NewDistrictHandler(u usecase.DistrictUsecase) *DistrictHandler {
 	return &DistrictHandler{Usecase: u}
 }
 
+// GetAll รองรับ ?province_id=&limit=&offset=&cursor=&sort=
 func (h *DistrictHandler) GetAll(w http.ResponseWriter, r *http.Request) {
-	list, err := h.Usecase.GetAll()
+	opts, err := httpx.ListOptions(r)
 	if err != nil {
-		http.Error(w, err.Error(), 500)
+		httpx.WriteError(w, r, err)
 		return
 	}
-	json.NewEncoder(w).Encode(list)
+	provinceID, err := httpx.QueryID(r, "province_id")
+	if err != nil {
+		httpx.WriteError(w, r, err)
+		return
+	}
+
+	page, err := h.Usecase.List(r.Context(), model.DistrictFilter{ProvinceID: provinceID}, opts)
+	if err != nil {
+		httpx.WriteError(w, r, err)
+		return
+	}
+	httpx.WriteJSON(w, http.StatusOK, database.MapPage(page, newDistrictResponse))
 }
 
 func (h *DistrictHandler) GetByID(w http.ResponseWriter, r *http.Request) {
-	id, _ := strconv.ParseInt(mux.Vars(r)["id"], 10, 64)
-	data, err := h.Usecase.GetByID(id)
+	id, err := httpx.PathID(r, "id")
 	if err != nil {
-		http.Error(w, err.Error(), 404)
+		httpx.WriteError(w, r, err)
 		return
 	}
-	json.NewEncoder(w).Encode(data)
+	data, err := h.Usecase.GetByID(r.Context(), id)
+	if err != nil {
+		httpx.WriteError(w, r, err)
+		return
+	}
+	httpx.WriteJSON(w, http.StatusOK, newDistrictResponse(data))
 }
 
 func (h *DistrictHandler) Create(w http.ResponseWriter, r *http.Request) {
-	var d model.District
-	json.NewDecoder(r.Body).Decode(&d)
-	if err := h.Usecase.Create(d); err != nil {
-		http.Error(w, err.Error(), 500)
+	var req DistrictRequest
+	if err := httpx.Decode(w, r, &req); err != nil {
+		httpx.WriteError(w, r, err)
+		return
+	}
+	if err := h.Usecase.Create(r.Context(), req.toModel()); err != nil {
+		httpx.WriteError(w, r, err)
 		return
 	}
 	w.WriteHeader(http.StatusCreated)
 }
 
 func (h *DistrictHandler) Update(w http.ResponseWriter, r *http.Request) {
-	var d model.District
-	json.NewDecoder(r.Body).Decode(&d)
-	if err := h.Usecase.Update(d); err != nil {
-		http.Error(w, err.Error(), 500)
+	var req UpdateDistrictRequest
+	if err := httpx.Decode(w, r, &req); err != nil {
+		httpx.WriteError(w, r, err)
+		return
+	}
+	d := req.toModel()
+	d.ID = req.ID
+	if err := h.Usecase.Update(r.Context(), d); err != nil {
+		httpx.WriteError(w, r, err)
 	}
 }
 
 func (h *DistrictHandler) Delete(w http.ResponseWriter, r *http.Request) {
-	id, _ := strconv.ParseInt(mux.Vars(r)["id"], 10, 64)
-	if err := h.Usecase.Delete(id); err != nil {
-		http.Error(w, err.Error(), 500)
+	id, err := httpx.PathID(r, "id")
+	if err != nil {
+		httpx.WriteError(w, r, err)
+		return
+	}
+	if err := h.Usecase.Delete(r.Context(), id); err != nil {
+		httpx.WriteError(w, r, err)
 	}
 }
@@ -5,3 +5,8 @@ type District struct {
 	Name       string `json:"name"`
 	ProvinceID int64  `json:"province_id"`
 }
+
+// DistrictFilter คือเงื่อนไขของ list (field ที่เป็น nil = ไม่กรอง)
+type DistrictFilter struct {
+	ProvinceID *int64
+}
@@ -1,63 +1,93 @@
 package repository
 
 import (
+	"context"
 	"database/sql"
+	"myapp/internal/database"
 	"myapp/internal/district/model"
 )
 
 type DistrictRepository interface {
-	GetAll() ([]model.District, error)
-	GetByID(id int64) (model.District, error)
-	Create(model.District) error
-	Update(model.District) error
-	Delete(id int64) error
+	List(ctx context.Context, f model.DistrictFilter, opts database.ListOptions) (database.Page[model.District], error)
+	GetByID(ctx context.Context, id int64) (model.District, error)
+	Exists(ctx context.Context, id int64) (bool, error)
+	Create(ctx context.Context, d model.District) error
+	Update(ctx context.Context, d model.District) error
+	Delete(ctx context.Context, id int64) error
 }
 
 type districtRepo struct {
-	db *sql.DB
+	db *database.DB
 }
 
-func NewDistrictRepository(db *sql.DB) DistrictRepository {
+func NewDistrictRepository(db *database.DB) DistrictRepository {
 	return &districtRepo{db: db}
 }
 
-func (r *districtRepo) GetAll() ([]model.District, error) {
-	rows, err := r.db.Query("SELECT district_id, name, province_id FROM district")
-	if err != nil {
-		return nil, err
-	}
-	defer rows.Close()
-
-	var list []model.District
-	for rows.Next() {
+// districtList คือ sort key ที่ client ใช้ได้ใน ?sort= (ใส่ "-" นำหน้าเพื่อเรียงมากไปน้อย)
+var districtList = database.ListSpec[model.District]{
+	IDColumn: "district_id",
+	ID:       func(d model.District) any { return d.ID },
+	Sorts: map[string]database.SortKey[model.District]{
+		"id":          {Column: "district_id", Value: func(d model.District) any { return d.ID }},
+		"name":        {Column: "name", Value: func(d model.District) any { return d.Name }},
+		"province_id": {Column: "province_id", Value: func(d model.District) any { return d.ProvinceID }},
+	},
+	DefaultSort: "id",
+	Scan: func(rows *sql.Rows) (model.District, error) {
 		var d model.District
 		err := rows.Scan(&d.ID, &d.Name, &d.ProvinceID)
-		if err != nil {
-			return nil, err
-		}
-		list = append(list, d)
+		return d, err
+	},
+}
+
+func (r *districtRepo) List(ctx context.Context, f model.DistrictFilter, opts database.ListOptions) (database.Page[model.District], error) {
+	q := database.Select("district", "district_id", "name", "province_id")
+	if f.ProvinceID != nil {
+		q.Where("province_id = ?", *f.ProvinceID)
 	}
-	return list, nil
+	return database.List(ctx, r.db, q, districtList, opts)
 }
 
-func (r *districtRepo) GetByID(id int64) (model.District, error) {
+func (r *districtRepo) GetByID(ctx context.Context, id int64) (model.District, error) {
+	ctx, cancel := r.db.WithTimeout(ctx)
+	defer cancel()
+
 	var d model.District
-	err := r.db.QueryRow("SELECT district_id, name, province_id FROM district WHERE district_id=?", id).
+	err := r.db.Conn(ctx).QueryRowContext(ctx, "SELECT district_id, name, province_id FROM district WHERE district_id=?", id).
 		Scan(&d.ID, &d.Name, &d.ProvinceID)
-	return d, err
+	return d, database.NotFound(err, "District not found")
 }
 
-func (r *districtRepo) Create(d model.District) error {
-	_, err := r.db.Exec("INSERT INTO district (name, province_id) VALUES (?, ?)", d.Name, d.ProvinceID)
-	return err
+func (r *districtRepo) Exists(ctx context.Context, id int64) (bool, error) {
+	ctx, cancel := r.db.WithTimeout(ctx)
+	defer cancel()
+
+	var exists bool
+	err := r.db.Conn(ctx).QueryRowContext(ctx, "SELECT EXISTS(SELECT 1 FROM district WHERE district_id=?)", id).Scan(&exists)
+	return exists, err
 }
 
-func (r *districtRepo) Update(d model.District) error {
-	_, err := r.db.Exec("UPDATE district SET name=?, province_id=? WHERE district_id=?", d.Name, d.ProvinceID, d.ID)
+func (r *districtRepo) Create(ctx context.Context, d model.District) error {
+	ctx, cancel := r.db.WithTimeout(ctx)
+	defer cancel()
+
+	_, err := r.db.Conn(ctx).ExecContext(ctx, "INSERT INTO district (name, province_id) VALUES (?, ?)", d.Name, d.ProvinceID)
 	return err
 }
 
-func (r *districtRepo) Delete(id int64) error {
-	_, err := r.db.Exec("DELETE FROM district WHERE district_id=?", id)
+func (r *districtRepo) Update(ctx context.Context, d model.District) error {
+	ctx, cancel := r.db.WithTimeout(ctx)
+	defer cancel()
+
+	_, err := r.db.Conn(ctx).ExecContext(ctx, "UPDATE district SET name=?, province_id=? WHERE district_id=?", d.Name, d.ProvinceID, d.ID)
 	return err
 }
+
+func (r *districtRepo) Delete(ctx context.Context, id int64) error {
+	ctx, cancel := r.db.WithTimeout(ctx)
+	defer cancel()
+
+	res, err := r.db.Conn(ctx).ExecContext(ctx, "DELETE FROM district WHERE district_id=?", id)
+	return database.RequireAffected(res, err, "District not found")
+}
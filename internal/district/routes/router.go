@@ -1,51 +1,44 @@
 package router
 
 import (
-	"database/sql"
-	"net/http"
-
 	"github.com/gorilla/mux"
 
-	// accommodation
-	accHandler "myapp/internal/accommodation/handler"
-	accRepo "myapp/internal/accommodation/repository"
-	accUsecase "myapp/internal/accommodation/usecase"
-
-	// district
+	"myapp/internal/auth"
+	"myapp/internal/database"
 	districtHandler "myapp/internal/district/handler"
 	districtRepo "myapp/internal/district/repository"
 	districtUsecase "myapp/internal/district/usecase"
+	provinceRepo "myapp/internal/province/repository"
 )
 
-func InitRouter(db *sql.DB) *mux.Router {
-	r := mux.NewRouter()
+type Module struct {
+	handler *districtHandler.DistrictHandler
+	tokens  *auth.TokenManager
+}
+
+func NewModule(db *database.DB, tokens *auth.TokenManager) *Module {
+	dRepo := districtRepo.NewDistrictRepository(db)
+	dUC := districtUsecase.NewDistrictUsecase(dRepo, provinceRepo.NewProvinceRepository(db))
+	return &Module{
+		handler: districtHandler.NewDistrictHandler(dUC),
+		tokens:  tokens,
+	}
+}
 
-	// ✅ Accommodation routes
-	accRepository := accRepo.NewAccommodationRepository(db)
-	accUC := accUsecase.NewAccommodationUsecase(accRepository)
-	accH := accHandler.NewAccommodationHandler(accUC)
+func (m *Module) Name() string { return "district" }
 
-	r.HandleFunc("/accommodations", accH.GetAll).Methods("GET")
-	r.HandleFunc("/accommodations/{id}", accH.GetByID).Methods("GET")
-	r.HandleFunc("/accommodations", accH.Create).Methods("POST")
-	r.HandleFunc("/accommodations", accH.Update).Methods("PUT")
-	r.HandleFunc("/accommodations/{id}", accH.Delete).Methods("DELETE")
+func (m *Module) RegisterRoutes(r *mux.Router) {
+	dH := m.handler
 
 	// ✅ District routes
-	dRepo := districtRepo.NewDistrictRepository(db)
-	dUC := districtUsecase.NewDistrictUsecase(dRepo)
-	dH := districtHandler.NewDistrictHandler(dUC)
-
 	r.HandleFunc("/districts", dH.GetAll).Methods("GET")
 	r.HandleFunc("/districts/{id}", dH.GetByID).Methods("GET")
-	r.HandleFunc("/districts", dH.Create).Methods("POST")
-	r.HandleFunc("/districts", dH.Update).Methods("PUT")
-	r.HandleFunc("/districts/{id}", dH.Delete).Methods("DELETE")
 
-	// ✅ Test route
-	r.HandleFunc("/ping", func(w http.ResponseWriter, r *http.Request) {
-		w.Write([]byte("pong"))
-	}).Methods("GET")
+	protected := r.NewRoute().Subrouter()
+	protected.Use(auth.Middleware(m.tokens))
+	adminOnly := auth.Roles(auth.RoleAdmin)
 
-	return r
+	protected.Handle("/districts", auth.Authorize(adminOnly, dH.Create)).Methods("POST")
+	protected.Handle("/districts", auth.Authorize(adminOnly, dH.Update)).Methods("PUT")
+	protected.Handle("/districts/{id}", auth.Authorize(adminOnly, dH.Delete)).Methods("DELETE")
 }
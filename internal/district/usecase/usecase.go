@@ -1,42 +1,65 @@
 package usecase
 
 import (
+	"context"
+	"myapp/internal/apperr"
+	"myapp/internal/database"
 	"myapp/internal/district/model"
 	"myapp/internal/district/repository"
+	provinceRepo "myapp/internal/province/repository"
 )
 
 type DistrictUsecase interface {
-	GetAll() ([]model.District, error)
-	GetByID(int64) (model.District, error)
-	Create(model.District) error
-	Update(model.District) error
-	Delete(int64) error
+	List(ctx context.Context, f model.DistrictFilter, opts database.ListOptions) (database.Page[model.District], error)
+	GetByID(ctx context.Context, id int64) (model.District, error)
+	Create(ctx context.Context, m model.District) error
+	Update(ctx context.Context, m model.District) error
+	Delete(ctx context.Context, id int64) error
 }
 
 type districtUsecase struct {
-	repo repository.DistrictRepository
+	repo      repository.DistrictRepository
+	provinces provinceRepo.ProvinceRepository
 }
 
-func NewDistrictUsecase(r repository.DistrictRepository) DistrictUsecase {
-	return &districtUsecase{repo: r}
+func NewDistrictUsecase(r repository.DistrictRepository, provinces provinceRepo.ProvinceRepository) DistrictUsecase {
+	return &districtUsecase{repo: r, provinces: provinces}
 }
 
-func (u *districtUsecase) GetAll() ([]model.District, error) {
-	return u.repo.GetAll()
+func (u *districtUsecase) List(ctx context.Context, f model.DistrictFilter, opts database.ListOptions) (database.Page[model.District], error) {
+	return u.repo.List(ctx, f, opts)
 }
 
-func (u *districtUsecase) GetByID(id int64) (model.District, error) {
-	return u.repo.GetByID(id)
+func (u *districtUsecase) GetByID(ctx context.Context, id int64) (model.District, error) {
+	return u.repo.GetByID(ctx, id)
 }
 
-func (u *districtUsecase) Create(d model.District) error {
-	return u.repo.Create(d)
+func (u *districtUsecase) Create(ctx context.Context, d model.District) error {
+	if err := u.checkProvince(ctx, d.ProvinceID); err != nil {
+		return err
+	}
+	return u.repo.Create(ctx, d)
 }
 
-func (u *districtUsecase) Update(d model.District) error {
-	return u.repo.Update(d)
+func (u *districtUsecase) Update(ctx context.Context, d model.District) error {
+	if err := u.checkProvince(ctx, d.ProvinceID); err != nil {
+		return err
+	}
+	return u.repo.Update(ctx, d)
 }
 
-func (u *districtUsecase) Delete(id int64) error {
-	return u.repo.Delete(id)
+func (u *districtUsecase) Delete(ctx context.Context, id int64) error {
+	return u.repo.Delete(ctx, id)
+}
+
+// checkProvince ตรวจ foreign key ก่อนเขียน เพื่อคืน validation error ที่ระบุ field ได้
+func (u *districtUsecase) checkProvince(ctx context.Context, id int64) error {
+	ok, err := u.provinces.Exists(ctx, id)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return apperr.Validation("Province does not exist", map[string]string{"province_id": "does not exist"})
+	}
+	return nil
 }
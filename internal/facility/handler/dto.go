@@ -0,0 +1,58 @@
+package handler
+
+import (
+	"strings"
+	"time"
+
+	"myapp/internal/apperr"
+	"myapp/internal/facility/model"
+)
+
+// FacilityRequest คือ body ของ POST /facilities และ PUT /facilities/{id}
+type FacilityRequest struct {
+	Code     string `json:"code" validate:"required,max=50"`
+	Name     string `json:"name" validate:"required,max=100"`
+	Category string `json:"category" validate:"required,max=50"`
+	Icon     string `json:"icon" validate:"max=50"`
+}
+
+func (req FacilityRequest) toModel() (model.Facility, error) {
+	code := strings.ToLower(strings.TrimSpace(req.Code))
+	if !model.CodeRe.MatchString(code) {
+		return model.Facility{}, apperr.Validation("Validation failed", map[string]string{
+			"code": "must contain only lowercase letters, digits and underscores",
+		})
+	}
+	icon := strings.TrimSpace(req.Icon)
+	if icon == "" {
+		icon = "generic"
+	}
+	return model.Facility{
+		Code:     code,
+		Name:     strings.TrimSpace(req.Name),
+		Category: strings.ToLower(strings.TrimSpace(req.Category)),
+		Icon:     icon,
+	}, nil
+}
+
+type FacilityResponse struct {
+	ID        int64     `json:"id"`
+	Code      string    `json:"code"`
+	Name      string    `json:"name"`
+	Category  string    `json:"category"`
+	Icon      string    `json:"icon"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+func newFacilityResponse(f model.Facility) FacilityResponse {
+	return FacilityResponse{
+		ID:        f.ID,
+		Code:      f.Code,
+		Name:      f.Name,
+		Category:  f.Category,
+		Icon:      f.Icon,
+		CreatedAt: f.CreatedAt,
+		UpdatedAt: f.UpdatedAt,
+	}
+}
@@ -0,0 +1,110 @@
+package handler
+
+import (
+	"myapp/internal/httpx"
+	"net/http"
+	"strings"
+
+	"myapp/internal/database"
+	"myapp/internal/facility/model"
+	"myapp/internal/facility/usecase"
+)
+
+type FacilityHandler struct {
+	Usecase usecase.FacilityUsecase
+}
+
+func NewFacilityHandler(u usecase.FacilityUsecase) *FacilityHandler {
+	return &FacilityHandler{Usecase: u}
+}
+
+// GetAll รองรับ ?category=&limit=&offset=&cursor=&sort=
+func (h *FacilityHandler) GetAll(w http.ResponseWriter, r *http.Request) {
+	opts, err := httpx.ListOptions(r)
+	if err != nil {
+		httpx.WriteError(w, r, err)
+		return
+	}
+	f := model.FacilityFilter{Category: strings.ToLower(strings.TrimSpace(r.URL.Query().Get("category")))}
+
+	page, err := h.Usecase.List(r.Context(), f, opts)
+	if err != nil {
+		httpx.WriteError(w, r, err)
+		return
+	}
+	httpx.WriteJSON(w, http.StatusOK, database.MapPage(page, newFacilityResponse))
+}
+
+func (h *FacilityHandler) GetByID(w http.ResponseWriter, r *http.Request) {
+	id, err := httpx.PathID(r, "id")
+	if err != nil {
+		httpx.WriteError(w, r, err)
+		return
+	}
+	f, err := h.Usecase.GetByID(r.Context(), id)
+	if err != nil {
+		httpx.WriteError(w, r, err)
+		return
+	}
+	httpx.WriteJSON(w, http.StatusOK, newFacilityResponse(f))
+}
+
+// ✅ [POST] /facilities - เพิ่มรายการใน catalogue (admin)
+func (h *FacilityHandler) Create(w http.ResponseWriter, r *http.Request) {
+	var req FacilityRequest
+	if err := httpx.Decode(w, r, &req); err != nil {
+		httpx.WriteError(w, r, err)
+		return
+	}
+	f, err := req.toModel()
+	if err != nil {
+		httpx.WriteError(w, r, err)
+		return
+	}
+
+	created, err := h.Usecase.Create(r.Context(), f)
+	if err != nil {
+		httpx.WriteError(w, r, err)
+		return
+	}
+	httpx.WriteJSON(w, http.StatusCreated, newFacilityResponse(created))
+}
+
+// ✅ [PUT] /facilities/{id} (admin)
+func (h *FacilityHandler) Update(w http.ResponseWriter, r *http.Request) {
+	id, err := httpx.PathID(r, "id")
+	if err != nil {
+		httpx.WriteError(w, r, err)
+		return
+	}
+	var req FacilityRequest
+	if err := httpx.Decode(w, r, &req); err != nil {
+		httpx.WriteError(w, r, err)
+		return
+	}
+	f, err := req.toModel()
+	if err != nil {
+		httpx.WriteError(w, r, err)
+		return
+	}
+	f.ID = id
+
+	updated, err := h.Usecase.Update(r.Context(), f)
+	if err != nil {
+		httpx.WriteError(w, r, err)
+		return
+	}
+	httpx.WriteJSON(w, http.StatusOK, newFacilityResponse(updated))
+}
+
+// ✅ [DELETE] /facilities/{id} (admin) - ลบได้เมื่อไม่มีที่พักใช้อยู่
+func (h *FacilityHandler) Delete(w http.ResponseWriter, r *http.Request) {
+	id, err := httpx.PathID(r, "id")
+	if err != nil {
+		httpx.WriteError(w, r, err)
+		return
+	}
+	if err := h.Usecase.Delete(r.Context(), id); err != nil {
+		httpx.WriteError(w, r, err)
+	}
+}
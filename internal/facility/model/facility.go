@@ -0,0 +1,25 @@
+package model
+
+import (
+	"regexp"
+	"time"
+)
+
+// CodeRe คือรูปแบบของ code (ใช้ใน ?facilities= และ API ของที่พัก) เช่น "wifi", "air_conditioning"
+var CodeRe = regexp.MustCompile(`^[a-z0-9_]{1,50}$`)
+
+// Facility คือสิ่งอำนวยความสะดวกใน catalogue; Icon คือ key ที่แอปใช้เลือก icon
+type Facility struct {
+	ID        int64
+	Code      string
+	Name      string
+	Category  string
+	Icon      string
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// FacilityFilter คือเงื่อนไขของ list (ค่าว่าง = ไม่กรอง)
+type FacilityFilter struct {
+	Category string
+}
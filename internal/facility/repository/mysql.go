@@ -0,0 +1,136 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"myapp/internal/apperr"
+	"myapp/internal/database"
+	"myapp/internal/facility/model"
+	"strings"
+	"time"
+)
+
+type FacilityRepository interface {
+	List(ctx context.Context, f model.FacilityFilter, opts database.ListOptions) (database.Page[model.Facility], error)
+	GetByID(ctx context.Context, id int64) (model.Facility, error)
+	// ByCodes คืน facility ที่มี code ตรงกับที่ระบุ (code ที่ไม่มีใน catalogue จะไม่อยู่ในผลลัพธ์)
+	ByCodes(ctx context.Context, codes []string) ([]model.Facility, error)
+	Create(ctx context.Context, f model.Facility) (int64, error)
+	Update(ctx context.Context, f model.Facility) error
+	Delete(ctx context.Context, id int64) error
+}
+
+type facilityRepo struct {
+	db *database.DB
+}
+
+func NewFacilityRepository(db *database.DB) FacilityRepository {
+	return &facilityRepo{db: db}
+}
+
+const facilityColumns = "facility_id, code, name, category, icon, created_at, updated_at"
+
+func scanFacility(row interface{ Scan(...any) error }) (model.Facility, error) {
+	var f model.Facility
+	err := row.Scan(&f.ID, &f.Code, &f.Name, &f.Category, &f.Icon, &f.CreatedAt, &f.UpdatedAt)
+	return f, err
+}
+
+// facilityList คือ sort key ที่ client ใช้ได้ใน ?sort= (ใส่ "-" นำหน้าเพื่อเรียงมากไปน้อย)
+var facilityList = database.ListSpec[model.Facility]{
+	IDColumn: "facility_id",
+	ID:       func(f model.Facility) any { return f.ID },
+	Sorts: map[string]database.SortKey[model.Facility]{
+		"id":         {Column: "facility_id", Value: func(f model.Facility) any { return f.ID }},
+		"code":       {Column: "code", Value: func(f model.Facility) any { return f.Code }},
+		"name":       {Column: "name", Value: func(f model.Facility) any { return f.Name }},
+		"category":   {Column: "category", Value: func(f model.Facility) any { return f.Category }},
+		"updated_at": {Column: "updated_at", Value: func(f model.Facility) any { return f.UpdatedAt.Format(time.DateTime) }},
+	},
+	DefaultSort: "id",
+	Scan:        func(rows *sql.Rows) (model.Facility, error) { return scanFacility(rows) },
+}
+
+func (r *facilityRepo) List(ctx context.Context, f model.FacilityFilter, opts database.ListOptions) (database.Page[model.Facility], error) {
+	q := database.Select("facility", "facility_id", "code", "name", "category", "icon", "created_at", "updated_at")
+	if f.Category != "" {
+		q.Where("category = ?", f.Category)
+	}
+	return database.List(ctx, r.db, q, facilityList, opts)
+}
+
+func (r *facilityRepo) GetByID(ctx context.Context, id int64) (model.Facility, error) {
+	ctx, cancel := r.db.WithTimeout(ctx)
+	defer cancel()
+
+	f, err := scanFacility(r.db.Conn(ctx).QueryRowContext(ctx, "SELECT "+facilityColumns+" FROM facility WHERE facility_id = ?", id))
+	return f, database.NotFound(err, "Facility not found")
+}
+
+func (r *facilityRepo) ByCodes(ctx context.Context, codes []string) ([]model.Facility, error) {
+	list := []model.Facility{}
+	if len(codes) == 0 {
+		return list, nil
+	}
+
+	ctx, cancel := r.db.WithTimeout(ctx)
+	defer cancel()
+
+	args := make([]any, 0, len(codes))
+	for _, c := range codes {
+		args = append(args, c)
+	}
+	rows, err := r.db.Conn(ctx).QueryContext(ctx,
+		"SELECT "+facilityColumns+" FROM facility WHERE code IN (?"+strings.Repeat(", ?", len(args)-1)+")", args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		f, err := scanFacility(rows)
+		if err != nil {
+			return nil, err
+		}
+		list = append(list, f)
+	}
+	return list, rows.Err()
+}
+
+func (r *facilityRepo) Create(ctx context.Context, f model.Facility) (int64, error) {
+	ctx, cancel := r.db.WithTimeout(ctx)
+	defer cancel()
+
+	res, err := r.db.Conn(ctx).ExecContext(ctx,
+		"INSERT INTO facility (code, name, category, icon) VALUES (?, ?, ?, ?)", f.Code, f.Name, f.Category, f.Icon)
+	if database.IsDuplicate(err) {
+		return 0, apperr.Conflict("facility_code_taken", "Facility code already exists").Wrap(err)
+	}
+	if err != nil {
+		return 0, err
+	}
+	return res.LastInsertId()
+}
+
+func (r *facilityRepo) Update(ctx context.Context, f model.Facility) error {
+	ctx, cancel := r.db.WithTimeout(ctx)
+	defer cancel()
+
+	_, err := r.db.Conn(ctx).ExecContext(ctx,
+		"UPDATE facility SET code = ?, name = ?, category = ?, icon = ? WHERE facility_id = ?", f.Code, f.Name, f.Category, f.Icon, f.ID)
+	if database.IsDuplicate(err) {
+		return apperr.Conflict("facility_code_taken", "Facility code already exists").Wrap(err)
+	}
+	return err
+}
+
+func (r *facilityRepo) Delete(ctx context.Context, id int64) error {
+	ctx, cancel := r.db.WithTimeout(ctx)
+	defer cancel()
+
+	res, err := r.db.Conn(ctx).ExecContext(ctx, "DELETE FROM facility WHERE facility_id = ?", id)
+	if database.IsReferenced(err) {
+		return apperr.Conflict("facility_in_use", "Facility is linked to accommodations and cannot be deleted").Wrap(err)
+	}
+	return database.RequireAffected(res, err, "Facility not found")
+}
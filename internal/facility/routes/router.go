@@ -0,0 +1,44 @@
+package router
+
+import (
+	"github.com/gorilla/mux"
+
+	"myapp/internal/auth"
+	"myapp/internal/database"
+	facilityHandler "myapp/internal/facility/handler"
+	facilityRepo "myapp/internal/facility/repository"
+	facilityUsecase "myapp/internal/facility/usecase"
+)
+
+type Module struct {
+	handler *facilityHandler.FacilityHandler
+	tokens  *auth.TokenManager
+}
+
+func NewModule(db *database.DB, tokens *auth.TokenManager) *Module {
+	fRepo := facilityRepo.NewFacilityRepository(db)
+	fUC := facilityUsecase.NewFacilityUsecase(fRepo)
+	return &Module{
+		handler: facilityHandler.NewFacilityHandler(fUC),
+		tokens:  tokens,
+	}
+}
+
+func (m *Module) Name() string { return "facility" }
+
+func (m *Module) RegisterRoutes(r *mux.Router) {
+	fH := m.handler
+
+	// ✅ catalogue ของสิ่งอำนวยความสะดวก
+	r.HandleFunc("/facilities", fH.GetAll).Methods("GET")
+	r.HandleFunc("/facilities/{id:[0-9]+}", fH.GetByID).Methods("GET")
+
+	// ✅ แก้ไข catalogue ได้เฉพาะ admin
+	protected := r.NewRoute().Subrouter()
+	protected.Use(auth.Middleware(m.tokens))
+	adminOnly := auth.Roles(auth.RoleAdmin)
+
+	protected.Handle("/facilities", auth.Authorize(adminOnly, fH.Create)).Methods("POST")
+	protected.Handle("/facilities/{id:[0-9]+}", auth.Authorize(adminOnly, fH.Update)).Methods("PUT")
+	protected.Handle("/facilities/{id:[0-9]+}", auth.Authorize(adminOnly, fH.Delete)).Methods("DELETE")
+}
@@ -0,0 +1,54 @@
+package usecase
+
+import (
+	"context"
+	"myapp/internal/database"
+	"myapp/internal/facility/model"
+	"myapp/internal/facility/repository"
+)
+
+type FacilityUsecase interface {
+	List(ctx context.Context, f model.FacilityFilter, opts database.ListOptions) (database.Page[model.Facility], error)
+	GetByID(ctx context.Context, id int64) (model.Facility, error)
+	Create(ctx context.Context, f model.Facility) (model.Facility, error)
+	Update(ctx context.Context, f model.Facility) (model.Facility, error)
+	Delete(ctx context.Context, id int64) error
+}
+
+type facilityUsecase struct {
+	repo repository.FacilityRepository
+}
+
+func NewFacilityUsecase(r repository.FacilityRepository) FacilityUsecase {
+	return &facilityUsecase{repo: r}
+}
+
+func (u *facilityUsecase) List(ctx context.Context, f model.FacilityFilter, opts database.ListOptions) (database.Page[model.Facility], error) {
+	return u.repo.List(ctx, f, opts)
+}
+
+func (u *facilityUsecase) GetByID(ctx context.Context, id int64) (model.Facility, error) {
+	return u.repo.GetByID(ctx, id)
+}
+
+func (u *facilityUsecase) Create(ctx context.Context, f model.Facility) (model.Facility, error) {
+	id, err := u.repo.Create(ctx, f)
+	if err != nil {
+		return model.Facility{}, err
+	}
+	return u.repo.GetByID(ctx, id)
+}
+
+func (u *facilityUsecase) Update(ctx context.Context, f model.Facility) (model.Facility, error) {
+	if _, err := u.repo.GetByID(ctx, f.ID); err != nil {
+		return model.Facility{}, err
+	}
+	if err := u.repo.Update(ctx, f); err != nil {
+		return model.Facility{}, err
+	}
+	return u.repo.GetByID(ctx, f.ID)
+}
+
+func (u *facilityUsecase) Delete(ctx context.Context, id int64) error {
+	return u.repo.Delete(ctx, id)
+}
@@ -0,0 +1,70 @@
+package handler
+
+import (
+	"math"
+	"time"
+
+	"myapp/internal/favorite/model"
+	"myapp/internal/storage"
+)
+
+// WishlistRequest คือ body ของ POST /users/me/wishlists และ PUT /users/me/wishlists/{id}
+type WishlistRequest struct {
+	Name string `json:"name" validate:"required,max=100"`
+}
+
+type SavedAccommodationResponse struct {
+	ID            int64     `json:"id"`
+	Name          string    `json:"name"`
+	MainImage     string    `json:"main_image"`
+	MainImageURL  string    `json:"main_image_url"`
+	VillageID     int64     `json:"village_id"`
+	PricePerNight float64   `json:"price_per_night"`
+	RatingAvg     float64   `json:"rating_avg"`
+	RatingCount   int       `json:"rating_count"`
+	SavedAt       time.Time `json:"saved_at"`
+}
+
+func newSavedAccommodationResponse(s model.SavedAccommodation, files storage.Storage) SavedAccommodationResponse {
+	return SavedAccommodationResponse{
+		ID:            s.AccommodationID,
+		Name:          s.Name,
+		MainImage:     s.MainImage,
+		MainImageURL:  files.URL(s.MainImage),
+		VillageID:     s.VillageID,
+		PricePerNight: s.PricePerNight,
+		RatingAvg:     math.Round(s.RatingAvg()*100) / 100,
+		RatingCount:   s.RatingCount,
+		SavedAt:       s.SavedAt,
+	}
+}
+
+// WishlistResponse: share_path คือ path ของลิงก์แชร์ (มีเฉพาะเมื่อเปิดแชร์)
+type WishlistResponse struct {
+	ID        int64     `json:"id"`
+	Name      string    `json:"name"`
+	ItemCount int       `json:"item_count"`
+	SharePath string    `json:"share_path,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+func newWishlistResponse(w model.Wishlist) WishlistResponse {
+	res := WishlistResponse{
+		ID:        w.ID,
+		Name:      w.Name,
+		ItemCount: w.ItemCount,
+		CreatedAt: w.CreatedAt,
+		UpdatedAt: w.UpdatedAt,
+	}
+	if w.ShareToken != nil {
+		res.SharePath = "/wishlists/shared/" + *w.ShareToken
+	}
+	return res
+}
+
+// SharedWishlistResponse คือ wishlist ที่เปิดผ่านลิงก์แชร์ (ไม่แสดงเจ้าของ)
+type SharedWishlistResponse struct {
+	Name      string `json:"name"`
+	ItemCount int    `json:"item_count"`
+}
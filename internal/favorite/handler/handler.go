@@ -0,0 +1,305 @@
+package handler
+
+import (
+	"myapp/internal/auth"
+	"myapp/internal/httpx"
+	"net/http"
+	"strings"
+
+	"github.com/gorilla/mux"
+
+	"myapp/internal/database"
+	"myapp/internal/favorite/model"
+	"myapp/internal/favorite/usecase"
+	"myapp/internal/storage"
+)
+
+type FavoriteHandler struct {
+	Favorites usecase.FavoriteUsecase
+	Wishlists usecase.WishlistUsecase
+	Files     storage.Storage
+}
+
+func NewFavoriteHandler(f usecase.FavoriteUsecase, w usecase.WishlistUsecase, files storage.Storage) *FavoriteHandler {
+	return &FavoriteHandler{Favorites: f, Wishlists: w, Files: files}
+}
+
+// saved แปลงที่พักที่บันทึกไว้เป็น response DTO พร้อม URL ของรูปหลัก
+func (h *FavoriteHandler) saved(s model.SavedAccommodation) SavedAccommodationResponse {
+	return newSavedAccommodationResponse(s, h.Files)
+}
+
+// ✅ [GET] /users/me/favorites - ที่พักที่บันทึกไว้ รองรับ ?limit=&offset=&cursor=&sort= (sort: saved_at, name, price, id)
+func (h *FavoriteHandler) ListFavorites(w http.ResponseWriter, r *http.Request) {
+	userID, err := userFrom(r)
+	if err != nil {
+		httpx.WriteError(w, r, err)
+		return
+	}
+	opts, err := httpx.ListOptions(r)
+	if err != nil {
+		httpx.WriteError(w, r, err)
+		return
+	}
+	page, err := h.Favorites.List(r.Context(), userID, opts)
+	if err != nil {
+		httpx.WriteError(w, r, err)
+		return
+	}
+	httpx.WriteJSON(w, http.StatusOK, database.MapPage(page, h.saved))
+}
+
+// ✅ [POST] /users/me/favorites/{accommodationId}
+func (h *FavoriteHandler) AddFavorite(w http.ResponseWriter, r *http.Request) {
+	userID, err := userFrom(r)
+	if err != nil {
+		httpx.WriteError(w, r, err)
+		return
+	}
+	accommodationID, err := httpx.PathID(r, "accommodationId")
+	if err != nil {
+		httpx.WriteError(w, r, err)
+		return
+	}
+	if err := h.Favorites.Add(r.Context(), userID, accommodationID); err != nil {
+		httpx.WriteError(w, r, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// ✅ [DELETE] /users/me/favorites/{accommodationId}
+func (h *FavoriteHandler) RemoveFavorite(w http.ResponseWriter, r *http.Request) {
+	userID, err := userFrom(r)
+	if err != nil {
+		httpx.WriteError(w, r, err)
+		return
+	}
+	accommodationID, err := httpx.PathID(r, "accommodationId")
+	if err != nil {
+		httpx.WriteError(w, r, err)
+		return
+	}
+	if err := h.Favorites.Remove(r.Context(), userID, accommodationID); err != nil {
+		httpx.WriteError(w, r, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// ✅ [GET] /users/me/wishlists
+func (h *FavoriteHandler) ListWishlists(w http.ResponseWriter, r *http.Request) {
+	userID, err := userFrom(r)
+	if err != nil {
+		httpx.WriteError(w, r, err)
+		return
+	}
+	list, err := h.Wishlists.List(r.Context(), userID)
+	if err != nil {
+		httpx.WriteError(w, r, err)
+		return
+	}
+	res := make([]WishlistResponse, 0, len(list))
+	for _, wl := range list {
+		res = append(res, newWishlistResponse(wl))
+	}
+	httpx.WriteJSON(w, http.StatusOK, res)
+}
+
+func (h *FavoriteHandler) GetWishlist(w http.ResponseWriter, r *http.Request) {
+	userID, id, err := wishlistFrom(r)
+	if err != nil {
+		httpx.WriteError(w, r, err)
+		return
+	}
+	wl, err := h.Wishlists.GetByID(r.Context(), userID, id)
+	if err != nil {
+		httpx.WriteError(w, r, err)
+		return
+	}
+	httpx.WriteJSON(w, http.StatusOK, newWishlistResponse(wl))
+}
+
+// ✅ [POST] /users/me/wishlists
+func (h *FavoriteHandler) CreateWishlist(w http.ResponseWriter, r *http.Request) {
+	userID, err := userFrom(r)
+	if err != nil {
+		httpx.WriteError(w, r, err)
+		return
+	}
+	var req WishlistRequest
+	if err := httpx.Decode(w, r, &req); err != nil {
+		httpx.WriteError(w, r, err)
+		return
+	}
+	wl, err := h.Wishlists.Create(r.Context(), userID, strings.TrimSpace(req.Name))
+	if err != nil {
+		httpx.WriteError(w, r, err)
+		return
+	}
+	httpx.WriteJSON(w, http.StatusCreated, newWishlistResponse(wl))
+}
+
+// ✅ [PUT] /users/me/wishlists/{id} - เปลี่ยนชื่อ
+func (h *FavoriteHandler) RenameWishlist(w http.ResponseWriter, r *http.Request) {
+	userID, id, err := wishlistFrom(r)
+	if err != nil {
+		httpx.WriteError(w, r, err)
+		return
+	}
+	var req WishlistRequest
+	if err := httpx.Decode(w, r, &req); err != nil {
+		httpx.WriteError(w, r, err)
+		return
+	}
+	wl, err := h.Wishlists.Rename(r.Context(), userID, id, strings.TrimSpace(req.Name))
+	if err != nil {
+		httpx.WriteError(w, r, err)
+		return
+	}
+	httpx.WriteJSON(w, http.StatusOK, newWishlistResponse(wl))
+}
+
+func (h *FavoriteHandler) DeleteWishlist(w http.ResponseWriter, r *http.Request) {
+	userID, id, err := wishlistFrom(r)
+	if err != nil {
+		httpx.WriteError(w, r, err)
+		return
+	}
+	if err := h.Wishlists.Delete(r.Context(), userID, id); err != nil {
+		httpx.WriteError(w, r, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// ✅ [POST] /users/me/wishlists/{id}/share - สร้างลิงก์แชร์ใหม่ (ลิงก์เดิมใช้ไม่ได้อีก)
+func (h *FavoriteHandler) ShareWishlist(w http.ResponseWriter, r *http.Request) {
+	userID, id, err := wishlistFrom(r)
+	if err != nil {
+		httpx.WriteError(w, r, err)
+		return
+	}
+	wl, err := h.Wishlists.Share(r.Context(), userID, id)
+	if err != nil {
+		httpx.WriteError(w, r, err)
+		return
+	}
+	httpx.WriteJSON(w, http.StatusOK, newWishlistResponse(wl))
+}
+
+// ✅ [DELETE] /users/me/wishlists/{id}/share - ปิดลิงก์แชร์
+func (h *FavoriteHandler) UnshareWishlist(w http.ResponseWriter, r *http.Request) {
+	userID, id, err := wishlistFrom(r)
+	if err != nil {
+		httpx.WriteError(w, r, err)
+		return
+	}
+	wl, err := h.Wishlists.Unshare(r.Context(), userID, id)
+	if err != nil {
+		httpx.WriteError(w, r, err)
+		return
+	}
+	httpx.WriteJSON(w, http.StatusOK, newWishlistResponse(wl))
+}
+
+// ✅ [GET] /users/me/wishlists/{id}/accommodations - รองรับ pagination/sort เหมือน favorites
+func (h *FavoriteHandler) WishlistItems(w http.ResponseWriter, r *http.Request) {
+	userID, id, err := wishlistFrom(r)
+	if err != nil {
+		httpx.WriteError(w, r, err)
+		return
+	}
+	opts, err := httpx.ListOptions(r)
+	if err != nil {
+		httpx.WriteError(w, r, err)
+		return
+	}
+	page, err := h.Wishlists.Items(r.Context(), userID, id, opts)
+	if err != nil {
+		httpx.WriteError(w, r, err)
+		return
+	}
+	httpx.WriteJSON(w, http.StatusOK, database.MapPage(page, h.saved))
+}
+
+// ✅ [POST] /users/me/wishlists/{id}/accommodations/{accommodationId}
+func (h *FavoriteHandler) AddWishlistItem(w http.ResponseWriter, r *http.Request) {
+	userID, id, err := wishlistFrom(r)
+	if err != nil {
+		httpx.WriteError(w, r, err)
+		return
+	}
+	accommodationID, err := httpx.PathID(r, "accommodationId")
+	if err != nil {
+		httpx.WriteError(w, r, err)
+		return
+	}
+	if err := h.Wishlists.AddItem(r.Context(), userID, id, accommodationID); err != nil {
+		httpx.WriteError(w, r, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// ✅ [DELETE] /users/me/wishlists/{id}/accommodations/{accommodationId}
+func (h *FavoriteHandler) RemoveWishlistItem(w http.ResponseWriter, r *http.Request) {
+	userID, id, err := wishlistFrom(r)
+	if err != nil {
+		httpx.WriteError(w, r, err)
+		return
+	}
+	accommodationID, err := httpx.PathID(r, "accommodationId")
+	if err != nil {
+		httpx.WriteError(w, r, err)
+		return
+	}
+	if err := h.Wishlists.RemoveItem(r.Context(), userID, id, accommodationID); err != nil {
+		httpx.WriteError(w, r, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// ✅ [GET] /wishlists/shared/{token} - เปิดดู wishlist ผ่านลิงก์แชร์ (ไม่ต้อง login)
+func (h *FavoriteHandler) GetShared(w http.ResponseWriter, r *http.Request) {
+	wl, err := h.Wishlists.Shared(r.Context(), mux.Vars(r)["token"])
+	if err != nil {
+		httpx.WriteError(w, r, err)
+		return
+	}
+	httpx.WriteJSON(w, http.StatusOK, SharedWishlistResponse{Name: wl.Name, ItemCount: wl.ItemCount})
+}
+
+// ✅ [GET] /wishlists/shared/{token}/accommodations
+func (h *FavoriteHandler) SharedItems(w http.ResponseWriter, r *http.Request) {
+	opts, err := httpx.ListOptions(r)
+	if err != nil {
+		httpx.WriteError(w, r, err)
+		return
+	}
+	page, err := h.Wishlists.SharedItems(r.Context(), mux.Vars(r)["token"], opts)
+	if err != nil {
+		httpx.WriteError(w, r, err)
+		return
+	}
+	httpx.WriteJSON(w, http.StatusOK, database.MapPage(page, h.saved))
+}
+
+func userFrom(r *http.Request) (int64, error) {
+	p, ok := auth.PrincipalFrom(r.Context())
+	if !ok {
+		return 0, auth.ErrMissingToken
+	}
+	return p.UserID, nil
+}
+
+// wishlistFrom อ่านผู้ใช้ที่ login และ {id} ของ wishlist
+func wishlistFrom(r *http.Request) (int64, int64, error) {
+	userID, err := userFrom(r)
+	if err != nil {
+		return 0, 0, err
+	}
+	id, err := httpx.PathID(r, "id")
+	return userID, id, err
+}
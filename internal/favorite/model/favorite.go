@@ -0,0 +1,34 @@
+package model
+
+import "time"
+
+// SavedAccommodation คือที่พักที่ถูกบันทึกไว้ (ใน favorites หรือ wishlist) พร้อมเวลาที่บันทึก
+type SavedAccommodation struct {
+	AccommodationID int64
+	Name            string
+	MainImage       string
+	VillageID       int64
+	PricePerNight   float64
+	RatingSum       int64
+	RatingCount     int
+	SavedAt         time.Time
+}
+
+// RatingAvg คือคะแนนเฉลี่ยจากยอดสะสม; 0 เมื่อยังไม่มีรีวิว
+func (s SavedAccommodation) RatingAvg() float64 {
+	if s.RatingCount == 0 {
+		return 0
+	}
+	return float64(s.RatingSum) / float64(s.RatingCount)
+}
+
+// Wishlist คือรายการที่พักที่ผู้ใช้ตั้งชื่อเอง; ShareToken ไม่เป็น nil เมื่อเปิดแชร์ด้วยลิงก์
+type Wishlist struct {
+	ID         int64
+	UserID     int64
+	Name       string
+	ShareToken *string
+	ItemCount  int
+	CreatedAt  time.Time
+	UpdatedAt  time.Time
+}
@@ -0,0 +1,46 @@
+package repository
+
+import (
+	"context"
+	"myapp/internal/database"
+	"myapp/internal/favorite/model"
+)
+
+type FavoriteRepository interface {
+	List(ctx context.Context, userID int64, opts database.ListOptions) (database.Page[model.SavedAccommodation], error)
+	// Add ไม่ error เมื่อบันทึกไว้อยู่แล้ว
+	Add(ctx context.Context, userID, accommodationID int64) error
+	Remove(ctx context.Context, userID, accommodationID int64) error
+}
+
+type favoriteRepo struct {
+	db *database.DB
+}
+
+func NewFavoriteRepository(db *database.DB) FavoriteRepository {
+	return &favoriteRepo{db: db}
+}
+
+func (r *favoriteRepo) List(ctx context.Context, userID int64, opts database.ListOptions) (database.Page[model.SavedAccommodation], error) {
+	q := database.Select("favorite s JOIN accommodation a ON a.accommodation_id = s.accommodation_id", savedColumns...).
+		Where("s.user_id = ?", userID)
+	return database.List(ctx, r.db, q, savedList, opts)
+}
+
+func (r *favoriteRepo) Add(ctx context.Context, userID, accommodationID int64) error {
+	ctx, cancel := r.db.WithTimeout(ctx)
+	defer cancel()
+
+	_, err := r.db.Conn(ctx).ExecContext(ctx,
+		"INSERT INTO favorite (user_id, accommodation_id) VALUES (?, ?) ON DUPLICATE KEY UPDATE user_id = user_id", userID, accommodationID)
+	return err
+}
+
+func (r *favoriteRepo) Remove(ctx context.Context, userID, accommodationID int64) error {
+	ctx, cancel := r.db.WithTimeout(ctx)
+	defer cancel()
+
+	res, err := r.db.Conn(ctx).ExecContext(ctx,
+		"DELETE FROM favorite WHERE user_id = ? AND accommodation_id = ?", userID, accommodationID)
+	return database.RequireAffected(res, err, "Favorite not found")
+}
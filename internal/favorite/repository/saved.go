@@ -0,0 +1,31 @@
+package repository
+
+import (
+	"database/sql"
+	"myapp/internal/database"
+	"myapp/internal/favorite/model"
+	"time"
+)
+
+// savedColumns คือ column ของที่พักที่บันทึกไว้ (s = favorite หรือ wishlist_item, a = accommodation)
+var savedColumns = []string{
+	"a.accommodation_id", "a.name", "a.main_image", "a.village_id", "a.price_per_night", "a.rating_sum", "a.rating_count", "s.created_at",
+}
+
+// savedList คือ sort key ที่ client ใช้ได้ใน ?sort= (ใส่ "-" นำหน้าเพื่อเรียงมากไปน้อย); ค่าเริ่มต้นคือบันทึกล่าสุดก่อน
+var savedList = database.ListSpec[model.SavedAccommodation]{
+	IDColumn: "a.accommodation_id",
+	ID:       func(s model.SavedAccommodation) any { return s.AccommodationID },
+	Sorts: map[string]database.SortKey[model.SavedAccommodation]{
+		"id":       {Column: "a.accommodation_id", Value: func(s model.SavedAccommodation) any { return s.AccommodationID }},
+		"name":     {Column: "a.name", Value: func(s model.SavedAccommodation) any { return s.Name }},
+		"price":    {Column: "a.price_per_night", Value: func(s model.SavedAccommodation) any { return s.PricePerNight }},
+		"saved_at": {Column: "s.created_at", Value: func(s model.SavedAccommodation) any { return s.SavedAt.Format(time.DateTime) }},
+	},
+	DefaultSort: "-saved_at",
+	Scan: func(rows *sql.Rows) (model.SavedAccommodation, error) {
+		var s model.SavedAccommodation
+		err := rows.Scan(&s.AccommodationID, &s.Name, &s.MainImage, &s.VillageID, &s.PricePerNight, &s.RatingSum, &s.RatingCount, &s.SavedAt)
+		return s, err
+	},
+}
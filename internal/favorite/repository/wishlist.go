@@ -0,0 +1,138 @@
+package repository
+
+import (
+	"context"
+	"myapp/internal/database"
+	"myapp/internal/favorite/model"
+)
+
+type WishlistRepository interface {
+	ListByUser(ctx context.Context, userID int64) ([]model.Wishlist, error)
+	GetByID(ctx context.Context, id int64) (model.Wishlist, error)
+	GetByShareToken(ctx context.Context, token string) (model.Wishlist, error)
+	Create(ctx context.Context, w model.Wishlist) (int64, error)
+	Rename(ctx context.Context, id int64, name string) error
+	// SetShareToken ตั้ง token ใหม่ (เปิดแชร์) หรือ nil (ปิดแชร์)
+	SetShareToken(ctx context.Context, id int64, token *string) error
+	Delete(ctx context.Context, id int64) error
+	Items(ctx context.Context, wishlistID int64, opts database.ListOptions) (database.Page[model.SavedAccommodation], error)
+	// AddItem ไม่ error เมื่อมีที่พักนี้อยู่แล้ว
+	AddItem(ctx context.Context, wishlistID, accommodationID int64) error
+	RemoveItem(ctx context.Context, wishlistID, accommodationID int64) error
+}
+
+type wishlistRepo struct {
+	db *database.DB
+}
+
+func NewWishlistRepository(db *database.DB) WishlistRepository {
+	return &wishlistRepo{db: db}
+}
+
+const wishlistSelect = `
+	SELECT w.wishlist_id, w.user_id, w.name, w.share_token,
+		(SELECT COUNT(*) FROM wishlist_item i WHERE i.wishlist_id = w.wishlist_id),
+		w.created_at, w.updated_at
+	FROM wishlist w`
+
+func scanWishlist(row interface{ Scan(...any) error }) (model.Wishlist, error) {
+	var w model.Wishlist
+	err := row.Scan(&w.ID, &w.UserID, &w.Name, &w.ShareToken, &w.ItemCount, &w.CreatedAt, &w.UpdatedAt)
+	return w, err
+}
+
+func (r *wishlistRepo) ListByUser(ctx context.Context, userID int64) ([]model.Wishlist, error) {
+	ctx, cancel := r.db.WithTimeout(ctx)
+	defer cancel()
+
+	rows, err := r.db.Conn(ctx).QueryContext(ctx, wishlistSelect+" WHERE w.user_id = ? ORDER BY w.wishlist_id", userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	list := []model.Wishlist{}
+	for rows.Next() {
+		w, err := scanWishlist(rows)
+		if err != nil {
+			return nil, err
+		}
+		list = append(list, w)
+	}
+	return list, rows.Err()
+}
+
+func (r *wishlistRepo) GetByID(ctx context.Context, id int64) (model.Wishlist, error) {
+	ctx, cancel := r.db.WithTimeout(ctx)
+	defer cancel()
+
+	w, err := scanWishlist(r.db.Conn(ctx).QueryRowContext(ctx, wishlistSelect+" WHERE w.wishlist_id = ?", id))
+	return w, database.NotFound(err, "Wishlist not found")
+}
+
+func (r *wishlistRepo) GetByShareToken(ctx context.Context, token string) (model.Wishlist, error) {
+	ctx, cancel := r.db.WithTimeout(ctx)
+	defer cancel()
+
+	w, err := scanWishlist(r.db.Conn(ctx).QueryRowContext(ctx, wishlistSelect+" WHERE w.share_token = ?", token))
+	return w, database.NotFound(err, "Wishlist not found")
+}
+
+func (r *wishlistRepo) Create(ctx context.Context, w model.Wishlist) (int64, error) {
+	ctx, cancel := r.db.WithTimeout(ctx)
+	defer cancel()
+
+	res, err := r.db.Conn(ctx).ExecContext(ctx, "INSERT INTO wishlist (user_id, name) VALUES (?, ?)", w.UserID, w.Name)
+	if err != nil {
+		return 0, err
+	}
+	return res.LastInsertId()
+}
+
+func (r *wishlistRepo) Rename(ctx context.Context, id int64, name string) error {
+	ctx, cancel := r.db.WithTimeout(ctx)
+	defer cancel()
+
+	_, err := r.db.Conn(ctx).ExecContext(ctx, "UPDATE wishlist SET name = ? WHERE wishlist_id = ?", name, id)
+	return err
+}
+
+func (r *wishlistRepo) SetShareToken(ctx context.Context, id int64, token *string) error {
+	ctx, cancel := r.db.WithTimeout(ctx)
+	defer cancel()
+
+	_, err := r.db.Conn(ctx).ExecContext(ctx, "UPDATE wishlist SET share_token = ? WHERE wishlist_id = ?", token, id)
+	return err
+}
+
+func (r *wishlistRepo) Delete(ctx context.Context, id int64) error {
+	ctx, cancel := r.db.WithTimeout(ctx)
+	defer cancel()
+
+	res, err := r.db.Conn(ctx).ExecContext(ctx, "DELETE FROM wishlist WHERE wishlist_id = ?", id)
+	return database.RequireAffected(res, err, "Wishlist not found")
+}
+
+func (r *wishlistRepo) Items(ctx context.Context, wishlistID int64, opts database.ListOptions) (database.Page[model.SavedAccommodation], error) {
+	q := database.Select("wishlist_item s JOIN accommodation a ON a.accommodation_id = s.accommodation_id", savedColumns...).
+		Where("s.wishlist_id = ?", wishlistID)
+	return database.List(ctx, r.db, q, savedList, opts)
+}
+
+func (r *wishlistRepo) AddItem(ctx context.Context, wishlistID, accommodationID int64) error {
+	ctx, cancel := r.db.WithTimeout(ctx)
+	defer cancel()
+
+	_, err := r.db.Conn(ctx).ExecContext(ctx,
+		"INSERT INTO wishlist_item (wishlist_id, accommodation_id) VALUES (?, ?) ON DUPLICATE KEY UPDATE wishlist_id = wishlist_id", wishlistID, accommodationID)
+	return err
+}
+
+func (r *wishlistRepo) RemoveItem(ctx context.Context, wishlistID, accommodationID int64) error {
+	ctx, cancel := r.db.WithTimeout(ctx)
+	defer cancel()
+
+	res, err := r.db.Conn(ctx).ExecContext(ctx,
+		"DELETE FROM wishlist_item WHERE wishlist_id = ? AND accommodation_id = ?", wishlistID, accommodationID)
+	return database.RequireAffected(res, err, "Wishlist item not found")
+}
@@ -0,0 +1,57 @@
+package router
+
+import (
+	"github.com/gorilla/mux"
+
+	accRepo "myapp/internal/accommodation/repository"
+	"myapp/internal/auth"
+	"myapp/internal/database"
+	favoriteHandler "myapp/internal/favorite/handler"
+	favoriteRepo "myapp/internal/favorite/repository"
+	favoriteUsecase "myapp/internal/favorite/usecase"
+	"myapp/internal/storage"
+)
+
+type Module struct {
+	handler *favoriteHandler.FavoriteHandler
+	tokens  *auth.TokenManager
+}
+
+func NewModule(db *database.DB, tokens *auth.TokenManager, files storage.Storage) *Module {
+	accommodations := accRepo.NewAccommodationRepository(db)
+	fUC := favoriteUsecase.NewFavoriteUsecase(favoriteRepo.NewFavoriteRepository(db), accommodations)
+	wUC := favoriteUsecase.NewWishlistUsecase(favoriteRepo.NewWishlistRepository(db), accommodations)
+	return &Module{
+		handler: favoriteHandler.NewFavoriteHandler(fUC, wUC, files),
+		tokens:  tokens,
+	}
+}
+
+func (m *Module) Name() string { return "favorite" }
+
+func (m *Module) RegisterRoutes(r *mux.Router) {
+	fH := m.handler
+
+	// ✅ ลิงก์แชร์ wishlist เปิดดูได้โดยไม่ต้อง login
+	r.HandleFunc("/wishlists/shared/{token}", fH.GetShared).Methods("GET")
+	r.HandleFunc("/wishlists/shared/{token}/accommodations", fH.SharedItems).Methods("GET")
+
+	// ✅ favorites และ wishlists ของผู้ใช้ที่ login อยู่
+	me := r.PathPrefix("/users/me").Subrouter()
+	me.Use(auth.Middleware(m.tokens))
+
+	me.HandleFunc("/favorites", fH.ListFavorites).Methods("GET")
+	me.HandleFunc("/favorites/{accommodationId:[0-9]+}", fH.AddFavorite).Methods("POST")
+	me.HandleFunc("/favorites/{accommodationId:[0-9]+}", fH.RemoveFavorite).Methods("DELETE")
+
+	me.HandleFunc("/wishlists", fH.ListWishlists).Methods("GET")
+	me.HandleFunc("/wishlists", fH.CreateWishlist).Methods("POST")
+	me.HandleFunc("/wishlists/{id:[0-9]+}", fH.GetWishlist).Methods("GET")
+	me.HandleFunc("/wishlists/{id:[0-9]+}", fH.RenameWishlist).Methods("PUT")
+	me.HandleFunc("/wishlists/{id:[0-9]+}", fH.DeleteWishlist).Methods("DELETE")
+	me.HandleFunc("/wishlists/{id:[0-9]+}/share", fH.ShareWishlist).Methods("POST")
+	me.HandleFunc("/wishlists/{id:[0-9]+}/share", fH.UnshareWishlist).Methods("DELETE")
+	me.HandleFunc("/wishlists/{id:[0-9]+}/accommodations", fH.WishlistItems).Methods("GET")
+	me.HandleFunc("/wishlists/{id:[0-9]+}/accommodations/{accommodationId:[0-9]+}", fH.AddWishlistItem).Methods("POST")
+	me.HandleFunc("/wishlists/{id:[0-9]+}/accommodations/{accommodationId:[0-9]+}", fH.RemoveWishlistItem).Methods("DELETE")
+}
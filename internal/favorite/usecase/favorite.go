@@ -0,0 +1,40 @@
+package usecase
+
+import (
+	"context"
+	accRepo "myapp/internal/accommodation/repository"
+	"myapp/internal/database"
+	"myapp/internal/favorite/model"
+	"myapp/internal/favorite/repository"
+)
+
+type FavoriteUsecase interface {
+	List(ctx context.Context, userID int64, opts database.ListOptions) (database.Page[model.SavedAccommodation], error)
+	Add(ctx context.Context, userID, accommodationID int64) error
+	Remove(ctx context.Context, userID, accommodationID int64) error
+}
+
+type favoriteUsecase struct {
+	repo           repository.FavoriteRepository
+	accommodations accRepo.AccommodationRepository
+}
+
+func NewFavoriteUsecase(r repository.FavoriteRepository, accommodations accRepo.AccommodationRepository) FavoriteUsecase {
+	return &favoriteUsecase{repo: r, accommodations: accommodations}
+}
+
+func (u *favoriteUsecase) List(ctx context.Context, userID int64, opts database.ListOptions) (database.Page[model.SavedAccommodation], error) {
+	return u.repo.List(ctx, userID, opts)
+}
+
+// Add บันทึกที่พักลง favorites; บันทึกซ้ำถือว่าสำเร็จ
+func (u *favoriteUsecase) Add(ctx context.Context, userID, accommodationID int64) error {
+	if _, err := u.accommodations.GetByID(ctx, accommodationID); err != nil {
+		return err
+	}
+	return u.repo.Add(ctx, userID, accommodationID)
+}
+
+func (u *favoriteUsecase) Remove(ctx context.Context, userID, accommodationID int64) error {
+	return u.repo.Remove(ctx, userID, accommodationID)
+}
@@ -0,0 +1,149 @@
+package usecase
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	accRepo "myapp/internal/accommodation/repository"
+	"myapp/internal/apperr"
+	"myapp/internal/database"
+	"myapp/internal/favorite/model"
+	"myapp/internal/favorite/repository"
+)
+
+type WishlistUsecase interface {
+	List(ctx context.Context, userID int64) ([]model.Wishlist, error)
+	GetByID(ctx context.Context, userID, id int64) (model.Wishlist, error)
+	Create(ctx context.Context, userID int64, name string) (model.Wishlist, error)
+	Rename(ctx context.Context, userID, id int64, name string) (model.Wishlist, error)
+	Delete(ctx context.Context, userID, id int64) error
+	// Share สร้างลิงก์แชร์ใหม่ทุกครั้ง (ลิงก์เดิมใช้ไม่ได้อีก); Unshare ปิดลิงก์
+	Share(ctx context.Context, userID, id int64) (model.Wishlist, error)
+	Unshare(ctx context.Context, userID, id int64) (model.Wishlist, error)
+	Items(ctx context.Context, userID, id int64, opts database.ListOptions) (database.Page[model.SavedAccommodation], error)
+	AddItem(ctx context.Context, userID, id, accommodationID int64) error
+	RemoveItem(ctx context.Context, userID, id, accommodationID int64) error
+	// Shared/SharedItems เปิดดูผ่านลิงก์แชร์โดยไม่ต้อง login
+	Shared(ctx context.Context, token string) (model.Wishlist, error)
+	SharedItems(ctx context.Context, token string, opts database.ListOptions) (database.Page[model.SavedAccommodation], error)
+}
+
+type wishlistUsecase struct {
+	repo           repository.WishlistRepository
+	accommodations accRepo.AccommodationRepository
+}
+
+func NewWishlistUsecase(r repository.WishlistRepository, accommodations accRepo.AccommodationRepository) WishlistUsecase {
+	return &wishlistUsecase{repo: r, accommodations: accommodations}
+}
+
+func (u *wishlistUsecase) List(ctx context.Context, userID int64) ([]model.Wishlist, error) {
+	return u.repo.ListByUser(ctx, userID)
+}
+
+// GetByID: wishlist ของคนอื่นตอบเป็น not found เพื่อไม่บอกว่ามีอยู่
+func (u *wishlistUsecase) GetByID(ctx context.Context, userID, id int64) (model.Wishlist, error) {
+	w, err := u.repo.GetByID(ctx, id)
+	if err != nil {
+		return w, err
+	}
+	if w.UserID != userID {
+		return model.Wishlist{}, apperr.NotFound("Wishlist not found")
+	}
+	return w, nil
+}
+
+func (u *wishlistUsecase) Create(ctx context.Context, userID int64, name string) (model.Wishlist, error) {
+	id, err := u.repo.Create(ctx, model.Wishlist{UserID: userID, Name: name})
+	if err != nil {
+		return model.Wishlist{}, err
+	}
+	return u.repo.GetByID(ctx, id)
+}
+
+func (u *wishlistUsecase) Rename(ctx context.Context, userID, id int64, name string) (model.Wishlist, error) {
+	if _, err := u.GetByID(ctx, userID, id); err != nil {
+		return model.Wishlist{}, err
+	}
+	if err := u.repo.Rename(ctx, id, name); err != nil {
+		return model.Wishlist{}, err
+	}
+	return u.repo.GetByID(ctx, id)
+}
+
+func (u *wishlistUsecase) Delete(ctx context.Context, userID, id int64) error {
+	if _, err := u.GetByID(ctx, userID, id); err != nil {
+		return err
+	}
+	return u.repo.Delete(ctx, id)
+}
+
+func (u *wishlistUsecase) Share(ctx context.Context, userID, id int64) (model.Wishlist, error) {
+	if _, err := u.GetByID(ctx, userID, id); err != nil {
+		return model.Wishlist{}, err
+	}
+	token, err := shareToken()
+	if err != nil {
+		return model.Wishlist{}, err
+	}
+	if err := u.repo.SetShareToken(ctx, id, &token); err != nil {
+		return model.Wishlist{}, err
+	}
+	return u.repo.GetByID(ctx, id)
+}
+
+func (u *wishlistUsecase) Unshare(ctx context.Context, userID, id int64) (model.Wishlist, error) {
+	if _, err := u.GetByID(ctx, userID, id); err != nil {
+		return model.Wishlist{}, err
+	}
+	if err := u.repo.SetShareToken(ctx, id, nil); err != nil {
+		return model.Wishlist{}, err
+	}
+	return u.repo.GetByID(ctx, id)
+}
+
+func (u *wishlistUsecase) Items(ctx context.Context, userID, id int64, opts database.ListOptions) (database.Page[model.SavedAccommodation], error) {
+	if _, err := u.GetByID(ctx, userID, id); err != nil {
+		return database.Page[model.SavedAccommodation]{}, err
+	}
+	return u.repo.Items(ctx, id, opts)
+}
+
+// AddItem เพิ่มที่พักลง wishlist; เพิ่มซ้ำถือว่าสำเร็จ
+func (u *wishlistUsecase) AddItem(ctx context.Context, userID, id, accommodationID int64) error {
+	if _, err := u.GetByID(ctx, userID, id); err != nil {
+		return err
+	}
+	if _, err := u.accommodations.GetByID(ctx, accommodationID); err != nil {
+		return err
+	}
+	return u.repo.AddItem(ctx, id, accommodationID)
+}
+
+func (u *wishlistUsecase) RemoveItem(ctx context.Context, userID, id, accommodationID int64) error {
+	if _, err := u.GetByID(ctx, userID, id); err != nil {
+		return err
+	}
+	return u.repo.RemoveItem(ctx, id, accommodationID)
+}
+
+func (u *wishlistUsecase) Shared(ctx context.Context, token string) (model.Wishlist, error) {
+	return u.repo.GetByShareToken(ctx, token)
+}
+
+func (u *wishlistUsecase) SharedItems(ctx context.Context, token string, opts database.ListOptions) (database.Page[model.SavedAccommodation], error) {
+	w, err := u.repo.GetByShareToken(ctx, token)
+	if err != nil {
+		return database.Page[model.SavedAccommodation]{}, err
+	}
+	return u.repo.Items(ctx, w.ID, opts)
+}
+
+// shareToken สุ่ม token 128 bit สำหรับลิงก์แชร์ (เดาไม่ได้)
+func shareToken() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
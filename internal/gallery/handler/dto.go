@@ -0,0 +1,53 @@
+package handler
+
+import (
+	"time"
+
+	"myapp/internal/gallery/model"
+	"myapp/internal/storage"
+)
+
+// UpdateImageRequest คือ body ของ PUT /images/{id}
+type UpdateImageRequest struct {
+	Caption   string `json:"caption" validate:"max=255"`
+	SortOrder *int   `json:"sort_order" validate:"required,gte=0,lte=10000"`
+}
+
+// ReorderRequest คือ body ของ PUT /accommodations/{id}/images/order; รูปแรกใน image_ids แสดงก่อน
+type ReorderRequest struct {
+	ImageIDs []int64 `json:"image_ids" validate:"required,max=500"`
+}
+
+type ImageResponse struct {
+	ID              int64     `json:"id"`
+	AccommodationID int64     `json:"accommodation_id"`
+	Key             string    `json:"key"`
+	URL             string    `json:"url"`
+	Caption         string    `json:"caption"`
+	SortOrder       int       `json:"sort_order"`
+	IsCover         bool      `json:"is_cover"`
+	CreatedAt       time.Time `json:"created_at"`
+	UpdatedAt       time.Time `json:"updated_at"`
+}
+
+func newImageResponse(img model.Image, files storage.Storage) ImageResponse {
+	return ImageResponse{
+		ID:              img.ID,
+		AccommodationID: img.AccommodationID,
+		Key:             img.Key,
+		URL:             files.URL(img.Key),
+		Caption:         img.Caption,
+		SortOrder:       img.SortOrder,
+		IsCover:         img.IsCover,
+		CreatedAt:       img.CreatedAt,
+		UpdatedAt:       img.UpdatedAt,
+	}
+}
+
+func newImageResponses(list []model.Image, files storage.Storage) []ImageResponse {
+	res := make([]ImageResponse, 0, len(list))
+	for _, img := range list {
+		res = append(res, newImageResponse(img, files))
+	}
+	return res
+}
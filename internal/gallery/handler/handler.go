@@ -0,0 +1,160 @@
+package handler
+
+import (
+	"myapp/internal/apperr"
+	"myapp/internal/httpx"
+	"myapp/internal/storage"
+	"myapp/internal/upload"
+	"net/http"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"myapp/internal/gallery/model"
+	"myapp/internal/gallery/usecase"
+)
+
+// maxCaptionLength คือความยาวสูงสุดของ caption (ตัวอักษร)
+const maxCaptionLength = 255
+
+type ImageHandler struct {
+	Usecase usecase.ImageUsecase
+	Files   storage.Storage
+}
+
+func NewImageHandler(u usecase.ImageUsecase, files storage.Storage) *ImageHandler {
+	return &ImageHandler{Usecase: u, Files: files}
+}
+
+// ✅ [GET] /accommodations/{id}/images - รูปทั้งหมดเรียงตาม sort_order
+func (h *ImageHandler) List(w http.ResponseWriter, r *http.Request) {
+	id, err := httpx.PathID(r, "id")
+	if err != nil {
+		httpx.WriteError(w, r, err)
+		return
+	}
+	list, err := h.Usecase.List(r.Context(), id)
+	if err != nil {
+		httpx.WriteError(w, r, err)
+		return
+	}
+	httpx.WriteJSON(w, http.StatusOK, newImageResponses(list, h.Files))
+}
+
+// ✅ [POST] /accommodations/{id}/images - multipart: images (หลายไฟล์) และ captions (ตามลำดับเดียวกัน ไม่บังคับ)
+func (h *ImageHandler) Upload(w http.ResponseWriter, r *http.Request) {
+	id, err := httpx.PathID(r, "id")
+	if err != nil {
+		httpx.WriteError(w, r, err)
+		return
+	}
+	if err := upload.ParseForm(w, r, upload.FormLimit(model.MaxImagesPerUpload)); err != nil {
+		httpx.WriteError(w, r, err)
+		return
+	}
+	files, err := upload.Images(r, "images", model.MaxImagesPerUpload)
+	if err != nil {
+		httpx.WriteError(w, r, err)
+		return
+	}
+	captions, err := parseCaptions(r.MultipartForm.Value["captions"], len(files))
+	if err != nil {
+		httpx.WriteError(w, r, err)
+		return
+	}
+
+	created, err := h.Usecase.Upload(r.Context(), id, files, captions)
+	if err != nil {
+		httpx.WriteError(w, r, err)
+		return
+	}
+	httpx.WriteJSON(w, http.StatusCreated, newImageResponses(created, h.Files))
+}
+
+// ✅ [PUT] /images/{id} - แก้ caption และ sort_order
+func (h *ImageHandler) Update(w http.ResponseWriter, r *http.Request) {
+	id, err := httpx.PathID(r, "id")
+	if err != nil {
+		httpx.WriteError(w, r, err)
+		return
+	}
+	var req UpdateImageRequest
+	if err := httpx.Decode(w, r, &req); err != nil {
+		httpx.WriteError(w, r, err)
+		return
+	}
+
+	img, err := h.Usecase.Update(r.Context(), model.Image{ID: id, Caption: strings.TrimSpace(req.Caption), SortOrder: *req.SortOrder})
+	if err != nil {
+		httpx.WriteError(w, r, err)
+		return
+	}
+	httpx.WriteJSON(w, http.StatusOK, newImageResponse(img, h.Files))
+}
+
+// ✅ [PUT] /accommodations/{id}/images/order - เรียงรูปใหม่ทั้ง gallery
+func (h *ImageHandler) Reorder(w http.ResponseWriter, r *http.Request) {
+	id, err := httpx.PathID(r, "id")
+	if err != nil {
+		httpx.WriteError(w, r, err)
+		return
+	}
+	var req ReorderRequest
+	if err := httpx.Decode(w, r, &req); err != nil {
+		httpx.WriteError(w, r, err)
+		return
+	}
+
+	list, err := h.Usecase.Reorder(r.Context(), id, req.ImageIDs)
+	if err != nil {
+		httpx.WriteError(w, r, err)
+		return
+	}
+	httpx.WriteJSON(w, http.StatusOK, newImageResponses(list, h.Files))
+}
+
+// ✅ [PUT] /images/{id}/cover - ตั้งเป็นรูป cover (กลายเป็น main_image ของที่พัก)
+func (h *ImageHandler) SetCover(w http.ResponseWriter, r *http.Request) {
+	id, err := httpx.PathID(r, "id")
+	if err != nil {
+		httpx.WriteError(w, r, err)
+		return
+	}
+	img, err := h.Usecase.SetCover(r.Context(), id)
+	if err != nil {
+		httpx.WriteError(w, r, err)
+		return
+	}
+	httpx.WriteJSON(w, http.StatusOK, newImageResponse(img, h.Files))
+}
+
+// ✅ [DELETE] /images/{id} - ลบรูปพร้อมไฟล์
+func (h *ImageHandler) Delete(w http.ResponseWriter, r *http.Request) {
+	id, err := httpx.PathID(r, "id")
+	if err != nil {
+		httpx.WriteError(w, r, err)
+		return
+	}
+	if err := h.Usecase.Delete(r.Context(), id); err != nil {
+		httpx.WriteError(w, r, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func parseCaptions(values []string, files int) ([]string, error) {
+	if len(values) > files {
+		return nil, apperr.Validation("Too many captions", map[string]string{"captions": "must not outnumber images"})
+	}
+	captions := make([]string, 0, len(values))
+	for i, c := range values {
+		c = strings.TrimSpace(c)
+		if utf8.RuneCountInString(c) > maxCaptionLength {
+			return nil, apperr.Validation("Caption is too long", map[string]string{
+				"captions." + strconv.Itoa(i): "must be at most " + strconv.Itoa(maxCaptionLength) + " characters",
+			})
+		}
+		captions = append(captions, c)
+	}
+	return captions, nil
+}
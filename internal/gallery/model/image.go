@@ -0,0 +1,19 @@
+package model
+
+import "time"
+
+// MaxImagesPerUpload คือจำนวนรูปสูงสุดต่อการ upload หนึ่งครั้ง
+const MaxImagesPerUpload = 10
+
+// Image คือรูปหนึ่งรูปใน gallery ของที่พัก
+// รูปที่ IsCover มีได้รูปเดียวต่อที่พัก และ object key ของรูปนั้นคือ main_image ของที่พัก
+type Image struct {
+	ID              int64
+	AccommodationID int64
+	Key             string // object key ใน storage
+	Caption         string
+	SortOrder       int
+	IsCover         bool
+	CreatedAt       time.Time
+	UpdatedAt       time.Time
+}
@@ -0,0 +1,142 @@
+package repository
+
+import (
+	"context"
+	"myapp/internal/database"
+	"myapp/internal/gallery/model"
+)
+
+type ImageRepository interface {
+	ListByAccommodation(ctx context.Context, accommodationID int64) ([]model.Image, error)
+	GetByID(ctx context.Context, id int64) (model.Image, error)
+	// LockAccommodation lock แถวของที่พัก (ต้องอยู่ใน transaction) ให้การแก้ gallery ของที่พักเดียวกันต่อคิวกัน
+	LockAccommodation(ctx context.Context, accommodationID int64) error
+	// NextSortOrder คือ sort_order ถัดจากรูปสุดท้ายของที่พัก
+	NextSortOrder(ctx context.Context, accommodationID int64) (int, error)
+	Create(ctx context.Context, img model.Image) (int64, error)
+	Update(ctx context.Context, img model.Image) error
+	SetSortOrder(ctx context.Context, id int64, sortOrder int) error
+	// SetCover ตั้งรูป id เป็น cover และคัด object key ไปที่ main_image; id เป็น nil = ไม่มี cover (main_image ว่าง)
+	SetCover(ctx context.Context, accommodationID int64, id *int64) error
+	Delete(ctx context.Context, id int64) error
+}
+
+type imageRepo struct {
+	db *database.DB
+}
+
+func NewImageRepository(db *database.DB) ImageRepository {
+	return &imageRepo{db: db}
+}
+
+const imageColumns = "image_id, accommodation_id, object_key, caption, sort_order, is_cover, created_at, updated_at"
+
+func scanImage(row interface{ Scan(...any) error }) (model.Image, error) {
+	var img model.Image
+	err := row.Scan(&img.ID, &img.AccommodationID, &img.Key, &img.Caption, &img.SortOrder, &img.IsCover, &img.CreatedAt, &img.UpdatedAt)
+	return img, err
+}
+
+func (r *imageRepo) ListByAccommodation(ctx context.Context, accommodationID int64) ([]model.Image, error) {
+	ctx, cancel := r.db.WithTimeout(ctx)
+	defer cancel()
+
+	rows, err := r.db.Conn(ctx).QueryContext(ctx,
+		"SELECT "+imageColumns+" FROM accommodation_image WHERE accommodation_id = ? ORDER BY sort_order, image_id", accommodationID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	list := []model.Image{}
+	for rows.Next() {
+		img, err := scanImage(rows)
+		if err != nil {
+			return nil, err
+		}
+		list = append(list, img)
+	}
+	return list, rows.Err()
+}
+
+func (r *imageRepo) GetByID(ctx context.Context, id int64) (model.Image, error) {
+	ctx, cancel := r.db.WithTimeout(ctx)
+	defer cancel()
+
+	img, err := scanImage(r.db.Conn(ctx).QueryRowContext(ctx, "SELECT "+imageColumns+" FROM accommodation_image WHERE image_id = ?", id))
+	return img, database.NotFound(err, "Image not found")
+}
+
+func (r *imageRepo) LockAccommodation(ctx context.Context, accommodationID int64) error {
+	ctx, cancel := r.db.WithTimeout(ctx)
+	defer cancel()
+
+	var id int64
+	err := r.db.Conn(ctx).QueryRowContext(ctx,
+		"SELECT accommodation_id FROM accommodation WHERE accommodation_id = ? FOR UPDATE", accommodationID).Scan(&id)
+	return database.NotFound(err, "Accommodation not found")
+}
+
+func (r *imageRepo) NextSortOrder(ctx context.Context, accommodationID int64) (int, error) {
+	ctx, cancel := r.db.WithTimeout(ctx)
+	defer cancel()
+
+	var next int
+	err := r.db.Conn(ctx).QueryRowContext(ctx,
+		"SELECT COALESCE(MAX(sort_order) + 1, 0) FROM accommodation_image WHERE accommodation_id = ?", accommodationID).Scan(&next)
+	return next, err
+}
+
+func (r *imageRepo) Create(ctx context.Context, img model.Image) (int64, error) {
+	ctx, cancel := r.db.WithTimeout(ctx)
+	defer cancel()
+
+	res, err := r.db.Conn(ctx).ExecContext(ctx,
+		"INSERT INTO accommodation_image (accommodation_id, object_key, caption, sort_order) VALUES (?, ?, ?, ?)",
+		img.AccommodationID, img.Key, img.Caption, img.SortOrder)
+	if err != nil {
+		return 0, err
+	}
+	return res.LastInsertId()
+}
+
+func (r *imageRepo) Update(ctx context.Context, img model.Image) error {
+	ctx, cancel := r.db.WithTimeout(ctx)
+	defer cancel()
+
+	_, err := r.db.Conn(ctx).ExecContext(ctx,
+		"UPDATE accommodation_image SET caption = ?, sort_order = ? WHERE image_id = ?", img.Caption, img.SortOrder, img.ID)
+	return err
+}
+
+func (r *imageRepo) SetSortOrder(ctx context.Context, id int64, sortOrder int) error {
+	ctx, cancel := r.db.WithTimeout(ctx)
+	defer cancel()
+
+	_, err := r.db.Conn(ctx).ExecContext(ctx, "UPDATE accommodation_image SET sort_order = ? WHERE image_id = ?", sortOrder, id)
+	return err
+}
+
+func (r *imageRepo) SetCover(ctx context.Context, accommodationID int64, id *int64) error {
+	ctx, cancel := r.db.WithTimeout(ctx)
+	defer cancel()
+
+	if _, err := r.db.Conn(ctx).ExecContext(ctx,
+		"UPDATE accommodation_image SET is_cover = (image_id <=> ?) WHERE accommodation_id = ?", id, accommodationID); err != nil {
+		return err
+	}
+	_, err := r.db.Conn(ctx).ExecContext(ctx, `
+		UPDATE accommodation SET main_image = COALESCE(
+			(SELECT object_key FROM accommodation_image WHERE image_id = ? AND accommodation_id = ?), '')
+		WHERE accommodation_id = ?`,
+		id, accommodationID, accommodationID)
+	return err
+}
+
+func (r *imageRepo) Delete(ctx context.Context, id int64) error {
+	ctx, cancel := r.db.WithTimeout(ctx)
+	defer cancel()
+
+	res, err := r.db.Conn(ctx).ExecContext(ctx, "DELETE FROM accommodation_image WHERE image_id = ?", id)
+	return database.RequireAffected(res, err, "Image not found")
+}
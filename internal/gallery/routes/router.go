@@ -0,0 +1,72 @@
+package router
+
+import (
+	"net/http"
+
+	"github.com/gorilla/mux"
+
+	accRepo "myapp/internal/accommodation/repository"
+	"myapp/internal/auth"
+	"myapp/internal/database"
+	galleryHandler "myapp/internal/gallery/handler"
+	galleryRepo "myapp/internal/gallery/repository"
+	galleryUsecase "myapp/internal/gallery/usecase"
+	"myapp/internal/httpx"
+	"myapp/internal/storage"
+)
+
+type Module struct {
+	handler            *galleryHandler.ImageHandler
+	accommodationOwner auth.OwnerFunc
+	imageOwner         auth.OwnerFunc
+	tokens             *auth.TokenManager
+}
+
+func NewModule(db *database.DB, tokens *auth.TokenManager, files storage.Storage) *Module {
+	iRepo := galleryRepo.NewImageRepository(db)
+	accommodations := accRepo.NewAccommodationRepository(db)
+	iUC := galleryUsecase.NewImageUsecase(db, iRepo, accommodations, files)
+	return &Module{
+		handler: galleryHandler.NewImageHandler(iUC, files),
+		// ✅ เจ้าของที่พักจาก path {id} ของ /accommodations/{id}/images
+		accommodationOwner: func(r *http.Request) (int64, error) {
+			id, err := httpx.PathID(r, "id")
+			if err != nil {
+				return 0, err
+			}
+			return accommodations.OwnerOf(r.Context(), id)
+		},
+		// ✅ เจ้าของที่พักของรูปจาก path {id} ของ /images/{id}
+		imageOwner: func(r *http.Request) (int64, error) {
+			id, err := httpx.PathID(r, "id")
+			if err != nil {
+				return 0, err
+			}
+			img, err := iRepo.GetByID(r.Context(), id)
+			if err != nil {
+				return 0, err
+			}
+			return accommodations.OwnerOf(r.Context(), img.AccommodationID)
+		},
+		tokens: tokens,
+	}
+}
+
+func (m *Module) Name() string { return "gallery" }
+
+func (m *Module) RegisterRoutes(r *mux.Router) {
+	iH := m.handler
+
+	// ✅ gallery ของที่พัก
+	r.HandleFunc("/accommodations/{id:[0-9]+}/images", iH.List).Methods("GET")
+
+	// ✅ upload/แก้ไข/ลบรูปได้เฉพาะเจ้าของที่พักหรือ admin
+	protected := r.NewRoute().Subrouter()
+	protected.Use(auth.Middleware(m.tokens))
+
+	protected.Handle("/accommodations/{id:[0-9]+}/images", auth.AuthorizeOwner(m.accommodationOwner, iH.Upload)).Methods("POST")
+	protected.Handle("/accommodations/{id:[0-9]+}/images/order", auth.AuthorizeOwner(m.accommodationOwner, iH.Reorder)).Methods("PUT")
+	protected.Handle("/images/{id:[0-9]+}", auth.AuthorizeOwner(m.imageOwner, iH.Update)).Methods("PUT")
+	protected.Handle("/images/{id:[0-9]+}/cover", auth.AuthorizeOwner(m.imageOwner, iH.SetCover)).Methods("PUT")
+	protected.Handle("/images/{id:[0-9]+}", auth.AuthorizeOwner(m.imageOwner, iH.Delete)).Methods("DELETE")
+}
@@ -0,0 +1,225 @@
+package usecase
+
+import (
+	"context"
+	"log/slog"
+	accRepo "myapp/internal/accommodation/repository"
+	"myapp/internal/apperr"
+	"myapp/internal/database"
+	"myapp/internal/gallery/model"
+	"myapp/internal/gallery/repository"
+	"myapp/internal/logging"
+	"myapp/internal/storage"
+	"myapp/internal/upload"
+	"slices"
+	"strconv"
+)
+
+type ImageUsecase interface {
+	List(ctx context.Context, accommodationID int64) ([]model.Image, error)
+	// Upload เพิ่มรูปต่อท้าย gallery ตามลำดับที่ส่งมา; captions ว่างหรือสั้นกว่า files ได้
+	Upload(ctx context.Context, accommodationID int64, files []upload.File, captions []string) ([]model.Image, error)
+	Update(ctx context.Context, img model.Image) (model.Image, error)
+	// Reorder เรียงรูปใหม่ทั้ง gallery; ids ต้องเป็นรูปของที่พักนี้ครบทุกรูป
+	Reorder(ctx context.Context, accommodationID int64, ids []int64) ([]model.Image, error)
+	SetCover(ctx context.Context, id int64) (model.Image, error)
+	Delete(ctx context.Context, id int64) error
+}
+
+type imageUsecase struct {
+	tx             database.Transactor
+	repo           repository.ImageRepository
+	accommodations accRepo.AccommodationRepository
+	files          storage.Storage
+}
+
+func NewImageUsecase(tx database.Transactor, r repository.ImageRepository, accommodations accRepo.AccommodationRepository, files storage.Storage) ImageUsecase {
+	return &imageUsecase{tx: tx, repo: r, accommodations: accommodations, files: files}
+}
+
+func (u *imageUsecase) List(ctx context.Context, accommodationID int64) ([]model.Image, error) {
+	if _, err := u.accommodations.GetByID(ctx, accommodationID); err != nil {
+		return nil, err
+	}
+	return u.repo.ListByAccommodation(ctx, accommodationID)
+}
+
+// Upload เขียนไฟล์ก่อนแล้วค่อยบันทึกใน transaction; ถ้าบันทึกไม่สำเร็จจะลบไฟล์ที่เขียนไปแล้วทิ้ง
+// ที่พักที่ยังไม่มี cover จะใช้รูปแรกที่ upload เป็น cover
+func (u *imageUsecase) Upload(ctx context.Context, accommodationID int64, files []upload.File, captions []string) ([]model.Image, error) {
+	if _, err := u.accommodations.GetByID(ctx, accommodationID); err != nil {
+		return nil, err
+	}
+
+	keys := make([]string, 0, len(files))
+	for _, f := range files {
+		key, err := upload.Put(ctx, u.files, "accommodations/"+strconv.FormatInt(accommodationID, 10), f)
+		if err != nil {
+			u.removeFiles(ctx, keys...)
+			return nil, err
+		}
+		keys = append(keys, key)
+	}
+
+	ids := make([]int64, 0, len(keys))
+	err := u.tx.WithinTx(ctx, func(ctx context.Context) error {
+		if err := u.repo.LockAccommodation(ctx, accommodationID); err != nil {
+			return err
+		}
+		existing, err := u.repo.ListByAccommodation(ctx, accommodationID)
+		if err != nil {
+			return err
+		}
+		next, err := u.repo.NextSortOrder(ctx, accommodationID)
+		if err != nil {
+			return err
+		}
+
+		for i, key := range keys {
+			img := model.Image{AccommodationID: accommodationID, Key: key, SortOrder: next + i}
+			if i < len(captions) {
+				img.Caption = captions[i]
+			}
+			id, err := u.repo.Create(ctx, img)
+			if err != nil {
+				return err
+			}
+			ids = append(ids, id)
+		}
+
+		if !slices.ContainsFunc(existing, func(img model.Image) bool { return img.IsCover }) {
+			return u.repo.SetCover(ctx, accommodationID, &ids[0])
+		}
+		return nil
+	})
+	if err != nil {
+		u.removeFiles(ctx, keys...)
+		return nil, err
+	}
+
+	created := make([]model.Image, 0, len(ids))
+	for _, id := range ids {
+		img, err := u.repo.GetByID(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+		created = append(created, img)
+	}
+	return created, nil
+}
+
+func (u *imageUsecase) Update(ctx context.Context, img model.Image) (model.Image, error) {
+	if _, err := u.repo.GetByID(ctx, img.ID); err != nil {
+		return model.Image{}, err
+	}
+	if err := u.repo.Update(ctx, img); err != nil {
+		return model.Image{}, err
+	}
+	return u.repo.GetByID(ctx, img.ID)
+}
+
+func (u *imageUsecase) Reorder(ctx context.Context, accommodationID int64, ids []int64) ([]model.Image, error) {
+	err := u.tx.WithinTx(ctx, func(ctx context.Context) error {
+		if err := u.repo.LockAccommodation(ctx, accommodationID); err != nil {
+			return err
+		}
+		existing, err := u.repo.ListByAccommodation(ctx, accommodationID)
+		if err != nil {
+			return err
+		}
+		if !sameImages(existing, ids) {
+			return apperr.Validation("Invalid image order", map[string]string{
+				"image_ids": "must list every image of this accommodation exactly once",
+			})
+		}
+		for i, id := range ids {
+			if err := u.repo.SetSortOrder(ctx, id, i); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return u.repo.ListByAccommodation(ctx, accommodationID)
+}
+
+func (u *imageUsecase) SetCover(ctx context.Context, id int64) (model.Image, error) {
+	img, err := u.repo.GetByID(ctx, id)
+	if err != nil {
+		return model.Image{}, err
+	}
+	err = u.tx.WithinTx(ctx, func(ctx context.Context) error {
+		if err := u.repo.LockAccommodation(ctx, img.AccommodationID); err != nil {
+			return err
+		}
+		return u.repo.SetCover(ctx, img.AccommodationID, &id)
+	})
+	if err != nil {
+		return model.Image{}, err
+	}
+	return u.repo.GetByID(ctx, id)
+}
+
+// Delete ลบรูปและไฟล์; ถ้าเป็น cover จะเลื่อนรูปแรกที่เหลือขึ้นเป็น cover แทน (ไม่เหลือรูป = main_image ว่าง)
+func (u *imageUsecase) Delete(ctx context.Context, id int64) error {
+	img, err := u.repo.GetByID(ctx, id)
+	if err != nil {
+		return err
+	}
+	err = u.tx.WithinTx(ctx, func(ctx context.Context) error {
+		if err := u.repo.LockAccommodation(ctx, img.AccommodationID); err != nil {
+			return err
+		}
+		if err := u.repo.Delete(ctx, id); err != nil {
+			return err
+		}
+		if !img.IsCover {
+			return nil
+		}
+		rest, err := u.repo.ListByAccommodation(ctx, img.AccommodationID)
+		if err != nil {
+			return err
+		}
+		var next *int64
+		if len(rest) > 0 {
+			next = &rest[0].ID
+		}
+		return u.repo.SetCover(ctx, img.AccommodationID, next)
+	})
+	if err != nil {
+		return err
+	}
+
+	// ✅ ลบไฟล์หลัง commit เท่านั้น; ถ้าลบไม่สำเร็จแค่เหลือไฟล์ค้าง ไม่กระทบข้อมูล
+	u.removeFiles(ctx, img.Key)
+	return nil
+}
+
+func (u *imageUsecase) removeFiles(ctx context.Context, keys ...string) {
+	for _, key := range keys {
+		if err := u.files.Delete(ctx, key); err != nil {
+			slog.WarnContext(ctx, "failed to remove image file", "key", key, logging.Err(err))
+		}
+	}
+}
+
+func sameImages(existing []model.Image, ids []int64) bool {
+	if len(existing) != len(ids) {
+		return false
+	}
+	seen := make(map[int64]bool, len(ids))
+	for _, id := range ids {
+		seen[id] = true
+	}
+	if len(seen) != len(ids) {
+		return false
+	}
+	for _, img := range existing {
+		if !seen[img.ID] {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,83 @@
+package geo
+
+import "math"
+
+// EarthRadiusKm คือรัศมีเฉลี่ยของโลก (ใช้ทั้งใน Go และใน SQL ให้ได้ระยะตรงกัน)
+const EarthRadiusKm = 6371.0088
+
+type Point struct {
+	Lat float64
+	Lng float64
+}
+
+// Box คือกรอบสี่เหลี่ยมตามเส้นรุ้ง/เส้นแวง
+// ถ้า MinLng > MaxLng แปลว่ากรอบคร่อมเส้น 180 องศา (ต้องเทียบแบบ lng >= MinLng OR lng <= MaxLng)
+type Box struct {
+	MinLat, MinLng float64
+	MaxLat, MaxLng float64
+}
+
+// CrossesAntimeridian บอกว่ากรอบคร่อมเส้น 180 องศาหรือไม่
+func (b Box) CrossesAntimeridian() bool {
+	return b.MinLng > b.MaxLng
+}
+
+// Contains บอกว่าจุด p อยู่ในกรอบหรือไม่
+func (b Box) Contains(p Point) bool {
+	if p.Lat < b.MinLat || p.Lat > b.MaxLat {
+		return false
+	}
+	if b.CrossesAntimeridian() {
+		return p.Lng >= b.MinLng || p.Lng <= b.MaxLng
+	}
+	return p.Lng >= b.MinLng && p.Lng <= b.MaxLng
+}
+
+// DistanceKm คือระยะทางตามผิวโลก (great-circle) ด้วยสูตร haversine
+func DistanceKm(a, b Point) float64 {
+	lat1, lat2 := radians(a.Lat), radians(b.Lat)
+	dLat := lat2 - lat1
+	dLng := radians(b.Lng - a.Lng)
+
+	h := math.Sin(dLat/2)*math.Sin(dLat/2) + math.Cos(lat1)*math.Cos(lat2)*math.Sin(dLng/2)*math.Sin(dLng/2)
+	return 2 * EarthRadiusKm * math.Asin(math.Min(1, math.Sqrt(h)))
+}
+
+// BoundingBox คืนกรอบที่ครอบวงกลมรัศมี radiusKm รอบ center ไว้ทั้งหมด
+// ใช้เป็น prefilter ที่ใช้ index ได้ก่อนคำนวณระยะจริง; ถ้าครอบขั้วโลกจะใช้เส้นแวงทั้งหมด
+func BoundingBox(center Point, radiusKm float64) Box {
+	dLat := degrees(radiusKm / EarthRadiusKm)
+	box := Box{
+		MinLat: math.Max(center.Lat-dLat, -90),
+		MaxLat: math.Min(center.Lat+dLat, 90),
+		MinLng: -180,
+		MaxLng: 180,
+	}
+	if box.MinLat == -90 || box.MaxLat == 90 {
+		return box
+	}
+
+	// ระยะ 1 องศาของเส้นแวงหดลงตาม cos(lat); ใช้สูตรของ Chamberlain ให้กรอบครอบวงกลมพอดี
+	s := math.Sin(radiusKm/EarthRadiusKm) / math.Cos(radians(center.Lat))
+	if s >= 1 {
+		return box
+	}
+	dLng := degrees(math.Asin(s))
+	box.MinLng = normalizeLng(center.Lng - dLng)
+	box.MaxLng = normalizeLng(center.Lng + dLng)
+	return box
+}
+
+func normalizeLng(lng float64) float64 {
+	switch {
+	case lng < -180:
+		return lng + 360
+	case lng > 180:
+		return lng - 360
+	}
+	return lng
+}
+
+func radians(deg float64) float64 { return deg * math.Pi / 180 }
+
+func degrees(rad float64) float64 { return rad * 180 / math.Pi }
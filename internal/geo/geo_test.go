@@ -0,0 +1,162 @@
+package geo
+
+import (
+	"math"
+	"testing"
+)
+
+func TestDistanceKm(t *testing.T) {
+	tests := []struct {
+		name string
+		a, b Point
+		want float64
+	}{
+		{"same point", Point{13.7563, 100.5018}, Point{13.7563, 100.5018}, 0},
+		{"london to paris", Point{51.5074, -0.1278}, Point{48.8566, 2.3522}, 343.5},
+		{"bangkok to chiang mai", Point{13.7563, 100.5018}, Point{18.7883, 98.9853}, 582},
+		{"new york to los angeles", Point{40.7128, -74.0060}, Point{34.0522, -118.2437}, 3936},
+		{"across the antimeridian", Point{0, 179.5}, Point{0, -179.5}, 111.2},
+		{"pole to pole", Point{90, 0}, Point{-90, 0}, math.Pi * EarthRadiusKm},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := DistanceKm(tt.a, tt.b)
+			if math.Abs(got-tt.want) > math.Max(1, tt.want*0.005) {
+				t.Errorf("DistanceKm = %.1f, want about %.1f", got, tt.want)
+			}
+			if back := DistanceKm(tt.b, tt.a); math.Abs(back-got) > 1e-9 {
+				t.Errorf("DistanceKm is not symmetric: %v vs %v", got, back)
+			}
+		})
+	}
+}
+
+func TestBoundingBox(t *testing.T) {
+	world := Box{MinLat: -90, MinLng: -180, MaxLat: 90, MaxLng: 180}
+
+	tests := []struct {
+		name     string
+		center   Point
+		radiusKm float64
+		want     *Box // nil = ไม่ตรวจค่าตรงตัว
+		crosses  bool
+		inside   []Point
+		outside  []Point
+	}{
+		{
+			name:     "zero radius collapses to the center",
+			center:   Point{13.7563, 100.5018},
+			radiusKm: 0,
+			want:     &Box{MinLat: 13.7563, MinLng: 100.5018, MaxLat: 13.7563, MaxLng: 100.5018},
+			inside:   []Point{{13.7563, 100.5018}},
+			outside:  []Point{{13.7564, 100.5018}},
+		},
+		{
+			name:     "ordinary box",
+			center:   Point{13.7563, 100.5018},
+			radiusKm: 50,
+			inside:   []Point{{13.7563, 100.5018}, {14.1, 100.5018}, {13.7563, 100.9}},
+			outside:  []Point{{14.3, 100.5018}, {13.7563, 101.0}},
+		},
+		{
+			name:     "crosses the antimeridian",
+			center:   Point{-17.7134, 178.0650},
+			radiusKm: 300,
+			crosses:  true,
+			inside:   []Point{{-17.7134, 179.9}, {-17.7134, -179.9}, {-17.7134, 177}},
+			outside:  []Point{{-17.7134, 0}, {-17.7134, -170}, {-17.7134, 170}},
+		},
+		{
+			name:     "north pole clamps to all longitudes",
+			center:   Point{89.5, 10},
+			radiusKm: 100,
+			want:     &Box{MinLat: 89.5 - degrees(100/EarthRadiusKm), MinLng: -180, MaxLat: 90, MaxLng: 180},
+			inside:   []Point{{90, 0}, {89.5, -170}},
+		},
+		{
+			name:     "south pole clamps to all longitudes",
+			center:   Point{-89.9, -45},
+			radiusKm: 50,
+			want:     &Box{MinLat: -90, MinLng: -180, MaxLat: -89.9 + degrees(50/EarthRadiusKm), MaxLng: 180},
+		},
+		{
+			name:     "radius larger than the earth covers everything",
+			center:   Point{0, 0},
+			radiusKm: 25000,
+			want:     &world,
+			inside:   []Point{{90, 180}, {-90, -180}, {0, 179.99}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := BoundingBox(tt.center, tt.radiusKm)
+			if tt.want != nil && !boxNear(got, *tt.want) {
+				t.Errorf("BoundingBox = %+v, want %+v", got, *tt.want)
+			}
+			if got.CrossesAntimeridian() != tt.crosses {
+				t.Errorf("CrossesAntimeridian = %v, want %v (box %+v)", got.CrossesAntimeridian(), tt.crosses, got)
+			}
+			if got.MinLat < -90 || got.MaxLat > 90 || got.MinLng < -180 || got.MaxLng > 180 {
+				t.Errorf("box out of range: %+v", got)
+			}
+			for _, p := range tt.inside {
+				if !got.Contains(p) {
+					t.Errorf("box %+v should contain %+v", got, p)
+				}
+			}
+			for _, p := range tt.outside {
+				if got.Contains(p) {
+					t.Errorf("box %+v should not contain %+v", got, p)
+				}
+			}
+		})
+	}
+}
+
+// กรอบต้องครอบทุกจุดบนขอบวงกลม ไม่อย่างนั้น prefilter จะตัดผลลัพธ์ที่ถูกต้องทิ้ง
+func TestBoundingBoxCoversCircle(t *testing.T) {
+	centers := []Point{{13.7563, 100.5018}, {-17.7134, 178.0650}, {64.1466, -21.9426}, {-33.8688, 151.2093}}
+	for _, c := range centers {
+		for _, r := range []float64{1, 25, 500} {
+			box := BoundingBox(c, r)
+			for bearing := 0.0; bearing < 360; bearing += 5 {
+				p := destination(c, bearing, r*0.999)
+				if !box.Contains(p) {
+					t.Errorf("center %+v radius %v: box %+v misses %+v", c, r, box, p)
+				}
+			}
+		}
+	}
+}
+
+func TestBoxCrossesAntimeridian(t *testing.T) {
+	tests := []struct {
+		box  Box
+		want bool
+	}{
+		{Box{MinLat: 0, MinLng: 170, MaxLat: 10, MaxLng: -170}, true},
+		{Box{MinLat: 0, MinLng: -170, MaxLat: 10, MaxLng: 170}, false},
+		{Box{MinLat: 0, MinLng: 10, MaxLat: 10, MaxLng: 10}, false},
+	}
+	for _, tt := range tests {
+		if got := tt.box.CrossesAntimeridian(); got != tt.want {
+			t.Errorf("%+v.CrossesAntimeridian() = %v, want %v", tt.box, got, tt.want)
+		}
+	}
+}
+
+func boxNear(a, b Box) bool {
+	const eps = 1e-9
+	return math.Abs(a.MinLat-b.MinLat) < eps && math.Abs(a.MaxLat-b.MaxLat) < eps &&
+		math.Abs(a.MinLng-b.MinLng) < eps && math.Abs(a.MaxLng-b.MaxLng) < eps
+}
+
+// destination คือจุดที่อยู่ห่างจาก p เป็นระยะ km ตามทิศ bearing (องศาจากทิศเหนือ)
+func destination(p Point, bearing, km float64) Point {
+	lat1, lng1, brg, d := radians(p.Lat), radians(p.Lng), radians(bearing), km/EarthRadiusKm
+	lat2 := math.Asin(math.Sin(lat1)*math.Cos(d) + math.Cos(lat1)*math.Sin(d)*math.Cos(brg))
+	lng2 := lng1 + math.Atan2(math.Sin(brg)*math.Sin(d)*math.Cos(lat1), math.Cos(d)-math.Sin(lat1)*math.Sin(lat2))
+	return Point{Lat: degrees(lat2), Lng: normalizeLng(degrees(lng2))}
+}
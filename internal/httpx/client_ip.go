@@ -0,0 +1,15 @@
+package httpx
+
+import (
+	"net"
+	"net/http"
+)
+
+// ClientIP คืน IP ของ connection (ไม่เชื่อ X-Forwarded-For เพราะ client ปลอมได้)
+func ClientIP(r *http.Request) string {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+	return host
+}
@@ -0,0 +1,72 @@
+package httpx
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"myapp/internal/apperr"
+	"myapp/internal/validate"
+)
+
+// MaxBodyBytes คือขนาด JSON body สูงสุดที่รับ (ไม่รวม upload แบบ multipart)
+const MaxBodyBytes = 1 << 20
+
+// Decode อ่าน JSON body แบบเข้มงวดลงใน dst แล้วตรวจ tag `validate` ของ dst
+// ปฏิเสธ field ที่ไม่รู้จัก, body ที่มีมากกว่าหนึ่ง object และ body ที่ใหญ่เกิน MaxBodyBytes
+func Decode(w http.ResponseWriter, r *http.Request, dst any) error {
+	r.Body = http.MaxBytesReader(w, r.Body, MaxBodyBytes)
+
+	dec := json.NewDecoder(r.Body)
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(dst); err != nil {
+		return decodeError(err)
+	}
+	if err := dec.Decode(&struct{}{}); !errors.Is(err, io.EOF) {
+		return apperr.Invalid("Request body must contain a single JSON object")
+	}
+
+	return Validate(dst)
+}
+
+// Validate ตรวจ tag `validate` ของ v แล้วคืน validation error พร้อมรายละเอียดราย field
+func Validate(v any) error {
+	if fields := validate.Struct(v); fields != nil {
+		return apperr.Validation("Validation failed", fields)
+	}
+	return nil
+}
+
+func decodeError(err error) error {
+	var (
+		syntaxErr   *json.SyntaxError
+		typeErr     *json.UnmarshalTypeError
+		maxBytesErr *http.MaxBytesError
+	)
+
+	switch {
+	case errors.Is(err, io.EOF):
+		return apperr.Invalid("Request body is required")
+	case errors.Is(err, io.ErrUnexpectedEOF):
+		return apperr.Invalid("Request body contains malformed JSON")
+	case errors.As(err, &syntaxErr):
+		return apperr.Invalid(fmt.Sprintf("Request body contains malformed JSON at position %d", syntaxErr.Offset))
+	case errors.As(err, &typeErr):
+		name := typeErr.Field
+		if name == "" {
+			return apperr.Invalid("Request body must be a JSON object")
+		}
+		return apperr.Validation("Validation failed", map[string]string{
+			name: "must be a " + typeErr.Type.String(),
+		})
+	case strings.HasPrefix(err.Error(), "json: unknown field "):
+		name := strings.Trim(strings.TrimPrefix(err.Error(), "json: unknown field "), `"`)
+		return apperr.Validation("Validation failed", map[string]string{name: "is not allowed"})
+	case errors.As(err, &maxBytesErr):
+		return apperr.TooLarge(fmt.Sprintf("Request body must not be larger than %d bytes", maxBytesErr.Limit))
+	}
+	return apperr.Invalid("Request body is invalid")
+}
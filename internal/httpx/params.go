@@ -0,0 +1,19 @@
+package httpx
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gorilla/mux"
+
+	"myapp/internal/apperr"
+)
+
+// PathID อ่าน path variable ที่เป็น ID (จำนวนเต็มบวก) คืน validation error ถ้ารูปแบบผิด
+func PathID(r *http.Request, name string) (int64, error) {
+	id, err := strconv.ParseInt(mux.Vars(r)[name], 10, 64)
+	if err != nil || id <= 0 {
+		return 0, apperr.Validation("Invalid "+name, map[string]string{name: "must be a positive integer"})
+	}
+	return id, nil
+}
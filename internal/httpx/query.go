@@ -0,0 +1,102 @@
+package httpx
+
+import (
+	"math"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"myapp/internal/apperr"
+	"myapp/internal/database"
+)
+
+// ListOptions อ่าน ?limit=&offset=&cursor=&sort= ของ list endpoint
+// sort จะถูกตรวจกับ whitelist ใน repository; ที่นี่ตรวจแค่รูปแบบตัวเลขและห้ามใช้ cursor คู่กับ offset
+func ListOptions(r *http.Request) (database.ListOptions, error) {
+	q := r.URL.Query()
+	fields := map[string]string{}
+
+	opts := database.ListOptions{
+		Cursor: strings.TrimSpace(q.Get("cursor")),
+		Sort:   strings.TrimSpace(q.Get("sort")),
+	}
+	if v := q.Get("limit"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 1 || n > database.MaxPageLimit {
+			fields["limit"] = "must be between 1 and " + strconv.Itoa(database.MaxPageLimit)
+		}
+		opts.Limit = n
+	}
+	if v := q.Get("offset"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 0 {
+			fields["offset"] = "must be a non-negative integer"
+		}
+		opts.Offset = n
+	}
+	if opts.Cursor != "" && opts.Offset > 0 {
+		fields["cursor"] = "cannot be combined with offset"
+	}
+
+	if len(fields) > 0 {
+		return opts, apperr.Validation("Invalid pagination", fields)
+	}
+	return opts, nil
+}
+
+// QueryID อ่าน query parameter ที่เป็น ID; คืน nil เมื่อไม่ได้ส่งมา
+func QueryID(r *http.Request, name string) (*int64, error) {
+	v := r.URL.Query().Get(name)
+	if v == "" {
+		return nil, nil
+	}
+	id, err := strconv.ParseInt(v, 10, 64)
+	if err != nil || id <= 0 {
+		return nil, apperr.Validation("Invalid "+name, map[string]string{name: "must be a positive integer"})
+	}
+	return &id, nil
+}
+
+// QueryOneOf อ่าน query parameter ที่ต้องเป็นหนึ่งในค่าที่กำหนด; คืน "" เมื่อไม่ได้ส่งมา
+func QueryOneOf(r *http.Request, name string, allowed ...string) (string, error) {
+	v := strings.TrimSpace(r.URL.Query().Get(name))
+	if v == "" {
+		return "", nil
+	}
+	for _, a := range allowed {
+		if v == a {
+			return v, nil
+		}
+	}
+	return "", apperr.Validation("Invalid "+name, map[string]string{name: "must be one of " + strings.Join(allowed, ", ")})
+}
+
+// QueryFloat อ่าน query parameter ที่เป็นตัวเลขในช่วง [min, max]; คืน nil เมื่อไม่ได้ส่งมา
+func QueryFloat(r *http.Request, name string, min, max float64) (*float64, error) {
+	v := r.URL.Query().Get(name)
+	if v == "" {
+		return nil, nil
+	}
+	f, err := strconv.ParseFloat(v, 64)
+	if err != nil || math.IsNaN(f) || f < min || f > max {
+		return nil, apperr.Validation("Invalid "+name, map[string]string{
+			name: "must be a number between " + strconv.FormatFloat(min, 'g', -1, 64) + " and " + strconv.FormatFloat(max, 'g', -1, 64),
+		})
+	}
+	return &f, nil
+}
+
+// QueryInt อ่าน query parameter ที่เป็นจำนวนเต็มในช่วง [min, max]; คืน fallback เมื่อไม่ได้ส่งมา
+func QueryInt(r *http.Request, name string, fallback, min, max int) (int, error) {
+	v := r.URL.Query().Get(name)
+	if v == "" {
+		return fallback, nil
+	}
+	n, err := strconv.Atoi(v)
+	if err != nil || n < min || n > max {
+		return 0, apperr.Validation("Invalid "+name, map[string]string{
+			name: "must be an integer between " + strconv.Itoa(min) + " and " + strconv.Itoa(max),
+		})
+	}
+	return n, nil
+}
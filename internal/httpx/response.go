@@ -0,0 +1,48 @@
+package httpx
+
+import (
+	"encoding/json"
+	"log/slog"
+	"math"
+	"net/http"
+	"strconv"
+
+	"myapp/internal/apperr"
+)
+
+// ErrorBody คือรูปแบบ JSON ของ error ทุก response
+type ErrorBody struct {
+	Code    string            `json:"code"`
+	Message string            `json:"message"`
+	Fields  map[string]string `json:"fields,omitempty"`
+}
+
+// WriteJSON เขียน v เป็น JSON พร้อม status
+func WriteJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	if err := json.NewEncoder(w).Encode(v); err != nil {
+		slog.Error("failed to encode response", "error", err)
+	}
+}
+
+// WriteError แปลง err เป็น status + ErrorBody
+// error ที่ไม่ใช่ apperr จะไม่ถูกส่งข้อความให้ client (กันข้อความของ MySQL หลุด) แต่จะถูก log ไว้แทน
+func WriteError(w http.ResponseWriter, r *http.Request, err error) {
+	status := Status(err)
+	body := ErrorBody{Code: "internal", Message: "Internal server error"}
+
+	if e, ok := apperr.As(err); ok {
+		body = ErrorBody{Code: e.CodeOrKind(), Message: e.Message, Fields: e.Fields}
+		if e.RetryAfter > 0 {
+			w.Header().Set("Retry-After", strconv.Itoa(int(math.Ceil(e.RetryAfter.Seconds()))))
+		}
+	} else if status == http.StatusGatewayTimeout {
+		body = ErrorBody{Code: "timeout", Message: "The request took too long, please try again"}
+	}
+
+	if status >= http.StatusInternalServerError {
+		slog.ErrorContext(r.Context(), "request failed", "method", r.Method, "path", r.URL.Path, "status", status, "error", err)
+	}
+	WriteJSON(w, status, body)
+}
@@ -0,0 +1,33 @@
+package httpx
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"myapp/internal/apperr"
+)
+
+var kindStatus = map[apperr.Kind]int{
+	apperr.KindNotFound:     http.StatusNotFound,
+	apperr.KindConflict:     http.StatusConflict,
+	apperr.KindValidation:   http.StatusBadRequest,
+	apperr.KindUnauthorized: http.StatusUnauthorized,
+	apperr.KindForbidden:    http.StatusForbidden,
+	apperr.KindRateLimited:  http.StatusTooManyRequests,
+	apperr.KindUnavailable:  http.StatusServiceUnavailable,
+	apperr.KindTooLarge:     http.StatusRequestEntityTooLarge,
+}
+
+// Status คือ HTTP status ของ err: ตาม Kind ของ apperr, 504 เมื่อ query หมดเวลา, นอกนั้น 500
+func Status(err error) int {
+	if e, ok := apperr.As(err); ok {
+		if status, ok := kindStatus[e.Kind]; ok {
+			return status
+		}
+	}
+	if errors.Is(err, context.DeadlineExceeded) {
+		return http.StatusGatewayTimeout
+	}
+	return http.StatusInternalServerError
+}
@@ -0,0 +1,81 @@
+package logging
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"strings"
+
+	"myapp/internal/config"
+)
+
+// redactedKeys คือชื่อ field (ตัวพิมพ์เล็ก) ที่ค่าจะถูกแทนด้วย [REDACTED] เสมอ ไม่ว่าจะอยู่ใน group ใด
+var redactedKeys = []string{"password", "secret", "token", "authorization", "cookie", "otp", "dsn"}
+
+const redacted = "[REDACTED]"
+
+// New สร้าง logger ตาม LOG_LEVEL / LOG_FORMAT ที่ใส่ request_id จาก context และซ่อนค่าที่เป็นความลับ
+func New(cfg config.LogConfig, w io.Writer) *slog.Logger {
+	opts := &slog.HandlerOptions{
+		Level:       cfg.Level,
+		ReplaceAttr: redact,
+	}
+
+	var h slog.Handler
+	if cfg.Format == "json" {
+		h = slog.NewJSONHandler(w, opts)
+	} else {
+		h = slog.NewTextHandler(w, opts)
+	}
+	return slog.New(contextHandler{h})
+}
+
+// Setup ตั้ง logger เป็น default ของทั้ง slog และ log (log.Printf เดิมจะออกผ่าน handler เดียวกัน)
+func Setup(cfg config.LogConfig, w io.Writer) *slog.Logger {
+	logger := New(cfg, w)
+	slog.SetDefault(logger)
+	return logger
+}
+
+// Err คือ attribute มาตรฐานสำหรับ error
+func Err(err error) slog.Attr {
+	return slog.Any("error", err)
+}
+
+func redact(groups []string, a slog.Attr) slog.Attr {
+	if Sensitive(a.Key) {
+		return slog.String(a.Key, redacted)
+	}
+	return a
+}
+
+// Sensitive บอกว่าชื่อ field นี้ต้องถูกซ่อนค่าเมื่อ log
+func Sensitive(key string) bool {
+	key = strings.ToLower(key)
+	for _, k := range redactedKeys {
+		if strings.Contains(key, k) {
+			return true
+		}
+	}
+	return false
+}
+
+// contextHandler เติม request_id จาก context ให้ทุก record ที่ log ด้วย *Context(ctx, ...)
+type contextHandler struct {
+	slog.Handler
+}
+
+func (h contextHandler) Handle(ctx context.Context, r slog.Record) error {
+	if id := RequestID(ctx); id != "" {
+		r.AddAttrs(slog.String("request_id", id))
+	}
+	return h.Handler.Handle(ctx, r)
+}
+
+func (h contextHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return contextHandler{h.Handler.WithAttrs(attrs)}
+}
+
+func (h contextHandler) WithGroup(name string) slog.Handler {
+	return contextHandler{h.Handler.WithGroup(name)}
+}
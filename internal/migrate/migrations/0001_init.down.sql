@@ -0,0 +1,6 @@
+DROP TABLE IF EXISTS notification;
+DROP TABLE IF EXISTS district;
+DROP TABLE IF EXISTS accommodation;
+DROP TABLE IF EXISTS otp_metadata;
+DROP TABLE IF EXISTS otps;
+DROP TABLE IF EXISTS users;
@@ -0,0 +1,69 @@
+CREATE TABLE IF NOT EXISTS users (
+    user_id      BIGINT       NOT NULL AUTO_INCREMENT,
+    first_name   VARCHAR(100) NOT NULL,
+    lastname     VARCHAR(100) NOT NULL DEFAULT '',
+    password     VARCHAR(255) NOT NULL,
+    phone_number BIGINT       NULL,
+    email        VARCHAR(255) NOT NULL,
+    photo        VARCHAR(512) NULL,
+    created_at   DATETIME     NULL,
+    updated_at   DATETIME     NULL,
+    role         VARCHAR(20)  NOT NULL DEFAULT 'traveler',
+    PRIMARY KEY (user_id),
+    UNIQUE KEY uq_users_email (email)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
+
+CREATE TABLE IF NOT EXISTS otps (
+    id          BIGINT       NOT NULL AUTO_INCREMENT,
+    email       VARCHAR(255) NOT NULL,
+    code        VARCHAR(10)  NOT NULL,
+    action      VARCHAR(50)  NOT NULL,
+    expires_at  DATETIME     NOT NULL,
+    verified    TINYINT(1)   NOT NULL DEFAULT 0,
+    verified_at DATETIME     NULL,
+    created_at  DATETIME     NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (id),
+    KEY idx_otps_email_action (email, action),
+    KEY idx_otps_expires_at (expires_at)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
+
+CREATE TABLE IF NOT EXISTS otp_metadata (
+    id          BIGINT       NOT NULL AUTO_INCREMENT,
+    email       VARCHAR(255) NOT NULL,
+    action      VARCHAR(50)  NOT NULL,
+    field_key   VARCHAR(100) NOT NULL,
+    field_value TEXT         NOT NULL,
+    PRIMARY KEY (id),
+    KEY idx_otp_metadata_email_action (email, action)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
+
+CREATE TABLE IF NOT EXISTS accommodation (
+    accommodation_id   BIGINT       NOT NULL AUTO_INCREMENT,
+    name               VARCHAR(255) NOT NULL,
+    main_image         VARCHAR(512) NOT NULL DEFAULT '',
+    village_id         BIGINT       NOT NULL,
+    about              TEXT         NOT NULL,
+    popular_facilities TEXT         NOT NULL,
+    latitude           DOUBLE       NOT NULL DEFAULT 0,
+    longitude          DOUBLE       NOT NULL DEFAULT 0,
+    createdAt          DATETIME     NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updatedAt          DATETIME     NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    PRIMARY KEY (accommodation_id),
+    KEY idx_accommodation_village_id (village_id)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
+
+CREATE TABLE IF NOT EXISTS district (
+    district_id BIGINT       NOT NULL AUTO_INCREMENT,
+    name        VARCHAR(255) NOT NULL,
+    province_id BIGINT       NOT NULL,
+    PRIMARY KEY (district_id),
+    KEY idx_district_province_id (province_id)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
+
+CREATE TABLE IF NOT EXISTS notification (
+    notification_id     INT         NOT NULL AUTO_INCREMENT,
+    status_notification VARCHAR(50) NOT NULL,
+    order_id            INT         NULL,
+    PRIMARY KEY (notification_id),
+    KEY idx_notification_order_id (order_id)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
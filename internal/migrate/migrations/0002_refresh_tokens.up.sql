@@ -0,0 +1,15 @@
+CREATE TABLE IF NOT EXISTS refresh_tokens (
+    id          BIGINT      NOT NULL AUTO_INCREMENT,
+    user_id     BIGINT      NOT NULL,
+    token_hash  CHAR(64)    NOT NULL,
+    family_id   VARCHAR(64) NOT NULL,
+    expires_at  DATETIME    NOT NULL,
+    revoked_at  DATETIME    NULL,
+    replaced_by BIGINT      NULL,
+    created_at  DATETIME    NOT NULL,
+    PRIMARY KEY (id),
+    UNIQUE KEY uq_refresh_tokens_hash (token_hash),
+    KEY idx_refresh_tokens_family (family_id),
+    KEY idx_refresh_tokens_user (user_id),
+    CONSTRAINT fk_refresh_tokens_user FOREIGN KEY (user_id) REFERENCES users (user_id) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
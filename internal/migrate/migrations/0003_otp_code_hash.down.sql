@@ -0,0 +1,4 @@
+ALTER TABLE otps
+    DROP COLUMN code_salt,
+    DROP COLUMN code_hash,
+    ADD COLUMN code VARCHAR(10) NOT NULL DEFAULT '' AFTER email;
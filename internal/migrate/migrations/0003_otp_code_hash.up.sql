@@ -0,0 +1,6 @@
+-- OTP codes are stored only as a salted SHA-256 hash; outstanding plain-text codes are discarded.
+DELETE FROM otps WHERE verified = 0;
+ALTER TABLE otps
+    DROP COLUMN code,
+    ADD COLUMN code_hash CHAR(64) NOT NULL DEFAULT '' AFTER email,
+    ADD COLUMN code_salt CHAR(32) NOT NULL DEFAULT '' AFTER code_hash;
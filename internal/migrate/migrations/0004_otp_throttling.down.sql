@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS otp_attempts;
+ALTER TABLE otps DROP COLUMN attempts;
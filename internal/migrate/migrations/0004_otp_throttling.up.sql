@@ -0,0 +1,10 @@
+ALTER TABLE otps ADD COLUMN attempts INT NOT NULL DEFAULT 0 AFTER verified_at;
+
+CREATE TABLE IF NOT EXISTS otp_attempts (
+    bucket            VARCHAR(320) NOT NULL,
+    hits              INT          NOT NULL DEFAULT 0,
+    window_started_at DATETIME     NOT NULL,
+    last_hit_at       DATETIME     NOT NULL,
+    locked_until      DATETIME     NULL,
+    PRIMARY KEY (bucket)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
@@ -0,0 +1 @@
+ALTER TABLE accommodation DROP KEY idx_accommodation_lat_lng;
@@ -0,0 +1,2 @@
+-- Composite index for the bounding-box prefilter of nearby/viewport searches.
+ALTER TABLE accommodation ADD KEY idx_accommodation_lat_lng (latitude, longitude);
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS village;
+DROP TABLE IF EXISTS province;
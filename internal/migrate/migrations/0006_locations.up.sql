@@ -0,0 +1,13 @@
+CREATE TABLE IF NOT EXISTS province (
+    province_id BIGINT       NOT NULL AUTO_INCREMENT,
+    name        VARCHAR(255) NOT NULL,
+    PRIMARY KEY (province_id)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
+
+CREATE TABLE IF NOT EXISTS village (
+    village_id  BIGINT       NOT NULL AUTO_INCREMENT,
+    name        VARCHAR(255) NOT NULL,
+    district_id BIGINT       NOT NULL,
+    PRIMARY KEY (village_id),
+    KEY idx_village_district_id (district_id)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS booking;
+ALTER TABLE accommodation DROP COLUMN price_per_night;
@@ -0,0 +1,21 @@
+ALTER TABLE accommodation ADD COLUMN price_per_night DECIMAL(12,2) NOT NULL DEFAULT 0 AFTER popular_facilities;
+
+-- order_id is INT to match notification.order_id.
+CREATE TABLE IF NOT EXISTS booking (
+    order_id         INT           NOT NULL AUTO_INCREMENT,
+    user_id          BIGINT        NOT NULL,
+    accommodation_id BIGINT        NOT NULL,
+    check_in         DATE          NOT NULL,
+    check_out        DATE          NOT NULL,
+    guests           INT           NOT NULL,
+    nightly_price    DECIMAL(12,2) NOT NULL,
+    total_price      DECIMAL(12,2) NOT NULL,
+    status           VARCHAR(20)   NOT NULL DEFAULT 'pending',
+    created_at       DATETIME      NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at       DATETIME      NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    PRIMARY KEY (order_id),
+    KEY idx_booking_unit_dates (accommodation_id, check_in, check_out),
+    KEY idx_booking_user (user_id),
+    CONSTRAINT fk_booking_user FOREIGN KEY (user_id) REFERENCES users (user_id) ON DELETE CASCADE,
+    CONSTRAINT fk_booking_accommodation FOREIGN KEY (accommodation_id) REFERENCES accommodation (accommodation_id)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
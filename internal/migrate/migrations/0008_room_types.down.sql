@@ -0,0 +1,5 @@
+ALTER TABLE booking
+    DROP KEY idx_booking_room_type,
+    DROP COLUMN room_type_id;
+DROP TABLE IF EXISTS room_calendar;
+DROP TABLE IF EXISTS room_type;
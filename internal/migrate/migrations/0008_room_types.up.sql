@@ -0,0 +1,30 @@
+CREATE TABLE IF NOT EXISTS room_type (
+    room_type_id      BIGINT        NOT NULL AUTO_INCREMENT,
+    accommodation_id  BIGINT        NOT NULL,
+    name              VARCHAR(255)  NOT NULL,
+    capacity          INT           NOT NULL,
+    bed_configuration VARCHAR(255)  NOT NULL DEFAULT '',
+    base_price        DECIMAL(12,2) NOT NULL,
+    quantity          INT           NOT NULL,
+    min_stay          INT           NOT NULL DEFAULT 1,
+    created_at        DATETIME      NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at        DATETIME      NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    PRIMARY KEY (room_type_id),
+    KEY idx_room_type_accommodation (accommodation_id),
+    CONSTRAINT fk_room_type_accommodation FOREIGN KEY (accommodation_id) REFERENCES accommodation (accommodation_id) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
+
+-- Per-date overrides; a missing row means base price, open and the room type's min stay.
+CREATE TABLE IF NOT EXISTS room_calendar (
+    room_type_id BIGINT        NOT NULL,
+    date         DATE          NOT NULL,
+    price        DECIMAL(12,2) NULL,
+    closed       TINYINT(1)    NOT NULL DEFAULT 0,
+    min_stay     INT           NULL,
+    PRIMARY KEY (room_type_id, date),
+    CONSTRAINT fk_room_calendar_room_type FOREIGN KEY (room_type_id) REFERENCES room_type (room_type_id) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
+
+ALTER TABLE booking
+    ADD COLUMN room_type_id BIGINT NULL AFTER accommodation_id,
+    ADD KEY idx_booking_room_type (room_type_id);
@@ -0,0 +1,5 @@
+DROP TABLE IF EXISTS review_photo;
+DROP TABLE IF EXISTS review;
+ALTER TABLE accommodation
+    DROP COLUMN rating_count,
+    DROP COLUMN rating_sum;
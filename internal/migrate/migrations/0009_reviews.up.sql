@@ -0,0 +1,31 @@
+-- Running totals of visible reviews; the average is rating_sum / rating_count.
+ALTER TABLE accommodation
+    ADD COLUMN rating_sum   BIGINT NOT NULL DEFAULT 0 AFTER longitude,
+    ADD COLUMN rating_count INT    NOT NULL DEFAULT 0 AFTER rating_sum;
+
+CREATE TABLE IF NOT EXISTS review (
+    review_id        BIGINT     NOT NULL AUTO_INCREMENT,
+    accommodation_id BIGINT     NOT NULL,
+    user_id          BIGINT     NOT NULL,
+    order_id         INT        NOT NULL,
+    rating           TINYINT    NOT NULL,
+    comment          TEXT       NOT NULL,
+    host_reply       TEXT       NULL,
+    replied_at       DATETIME   NULL,
+    hidden           TINYINT(1) NOT NULL DEFAULT 0,
+    created_at       DATETIME   NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at       DATETIME   NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    PRIMARY KEY (review_id),
+    UNIQUE KEY uq_review_order (order_id),
+    KEY idx_review_accommodation (accommodation_id, hidden),
+    CONSTRAINT fk_review_accommodation FOREIGN KEY (accommodation_id) REFERENCES accommodation (accommodation_id) ON DELETE CASCADE,
+    CONSTRAINT fk_review_user FOREIGN KEY (user_id) REFERENCES users (user_id) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
+
+CREATE TABLE IF NOT EXISTS review_photo (
+    review_id BIGINT       NOT NULL,
+    position  INT          NOT NULL,
+    url       VARCHAR(512) NOT NULL,
+    PRIMARY KEY (review_id, position),
+    CONSTRAINT fk_review_photo_review FOREIGN KEY (review_id) REFERENCES review (review_id) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
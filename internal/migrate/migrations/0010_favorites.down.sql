@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS wishlist_item;
+DROP TABLE IF EXISTS wishlist;
+DROP TABLE IF EXISTS favorite;
@@ -0,0 +1,33 @@
+CREATE TABLE IF NOT EXISTS favorite (
+    user_id          BIGINT   NOT NULL,
+    accommodation_id BIGINT   NOT NULL,
+    created_at       DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (user_id, accommodation_id),
+    KEY idx_favorite_accommodation (accommodation_id),
+    CONSTRAINT fk_favorite_user FOREIGN KEY (user_id) REFERENCES users (user_id) ON DELETE CASCADE,
+    CONSTRAINT fk_favorite_accommodation FOREIGN KEY (accommodation_id) REFERENCES accommodation (accommodation_id)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
+
+-- share_token เป็น NULL จนกว่าเจ้าของจะเปิดแชร์; ลบ token ทิ้งเพื่อยกเลิกลิงก์เดิม
+CREATE TABLE IF NOT EXISTS wishlist (
+    wishlist_id BIGINT       NOT NULL AUTO_INCREMENT,
+    user_id     BIGINT       NOT NULL,
+    name        VARCHAR(100) NOT NULL,
+    share_token VARCHAR(64)  NULL,
+    created_at  DATETIME     NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at  DATETIME     NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    PRIMARY KEY (wishlist_id),
+    UNIQUE KEY uq_wishlist_share_token (share_token),
+    KEY idx_wishlist_user (user_id),
+    CONSTRAINT fk_wishlist_user FOREIGN KEY (user_id) REFERENCES users (user_id) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
+
+CREATE TABLE IF NOT EXISTS wishlist_item (
+    wishlist_id      BIGINT   NOT NULL,
+    accommodation_id BIGINT   NOT NULL,
+    created_at       DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (wishlist_id, accommodation_id),
+    KEY idx_wishlist_item_accommodation (accommodation_id),
+    CONSTRAINT fk_wishlist_item_wishlist FOREIGN KEY (wishlist_id) REFERENCES wishlist (wishlist_id) ON DELETE CASCADE,
+    CONSTRAINT fk_wishlist_item_accommodation FOREIGN KEY (accommodation_id) REFERENCES accommodation (accommodation_id)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
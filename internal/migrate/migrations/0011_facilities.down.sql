@@ -0,0 +1,3 @@
+-- popular_facilities เดิมไม่ถูกแก้ตอน up จึงไม่ต้องคืนค่า
+DROP TABLE IF EXISTS accommodation_facility;
+DROP TABLE IF EXISTS facility;
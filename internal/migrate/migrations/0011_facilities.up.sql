@@ -0,0 +1,117 @@
+CREATE TABLE IF NOT EXISTS facility (
+    facility_id BIGINT       NOT NULL AUTO_INCREMENT,
+    code        VARCHAR(50)  NOT NULL,
+    name        VARCHAR(100) NOT NULL,
+    category    VARCHAR(50)  NOT NULL DEFAULT 'other',
+    icon        VARCHAR(50)  NOT NULL DEFAULT 'generic',
+    created_at  DATETIME     NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at  DATETIME     NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    PRIMARY KEY (facility_id),
+    UNIQUE KEY uq_facility_code (code),
+    KEY idx_facility_category (category)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
+
+CREATE TABLE IF NOT EXISTS accommodation_facility (
+    accommodation_id BIGINT NOT NULL,
+    facility_id      BIGINT NOT NULL,
+    PRIMARY KEY (accommodation_id, facility_id),
+    KEY idx_accommodation_facility_facility (facility_id, accommodation_id),
+    CONSTRAINT fk_accommodation_facility_accommodation FOREIGN KEY (accommodation_id) REFERENCES accommodation (accommodation_id) ON DELETE CASCADE,
+    CONSTRAINT fk_accommodation_facility_facility FOREIGN KEY (facility_id) REFERENCES facility (facility_id)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
+
+INSERT INTO facility (code, name, category, icon) VALUES
+    ('wifi', 'Wi-Fi', 'connectivity', 'wifi'),
+    ('parking', 'Parking', 'transport', 'parking'),
+    ('airport_shuttle', 'Airport shuttle', 'transport', 'shuttle'),
+    ('pool', 'Swimming pool', 'leisure', 'pool'),
+    ('fitness', 'Fitness centre', 'leisure', 'fitness'),
+    ('spa', 'Spa', 'leisure', 'spa'),
+    ('garden', 'Garden', 'leisure', 'garden'),
+    ('beachfront', 'Beachfront', 'leisure', 'beach'),
+    ('restaurant', 'Restaurant', 'food', 'restaurant'),
+    ('bar', 'Bar', 'food', 'bar'),
+    ('breakfast', 'Breakfast', 'food', 'breakfast'),
+    ('kitchen', 'Kitchen', 'room', 'kitchen'),
+    ('air_conditioning', 'Air conditioning', 'room', 'air_conditioning'),
+    ('tv', 'TV', 'room', 'tv'),
+    ('washing_machine', 'Washing machine', 'room', 'washing_machine'),
+    ('family_rooms', 'Family rooms', 'room', 'family'),
+    ('non_smoking', 'Non-smoking rooms', 'room', 'non_smoking'),
+    ('front_desk_24h', '24-hour front desk', 'service', 'front_desk'),
+    ('room_service', 'Room service', 'service', 'room_service'),
+    ('pet_friendly', 'Pets allowed', 'service', 'pet');
+
+-- ชื่อที่พบบ่อยใน popular_facilities เดิม (อังกฤษ/ไทย) -> code ใน catalogue; ตารางชั่วคราว ลบทิ้งท้ายไฟล์
+CREATE TABLE facility_alias (
+    alias VARCHAR(100) NOT NULL,
+    code  VARCHAR(50)  NOT NULL,
+    PRIMARY KEY (alias)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
+
+INSERT INTO facility_alias (alias, code) VALUES
+    ('wifi', 'wifi'), ('wi-fi', 'wifi'), ('free wifi', 'wifi'), ('free wi-fi', 'wifi'), ('internet', 'wifi'),
+    ('ไวไฟ', 'wifi'), ('wifi ฟรี', 'wifi'), ('ฟรี wifi', 'wifi'), ('อินเทอร์เน็ต', 'wifi'), ('อินเทอร์เน็ตไร้สาย', 'wifi'),
+    ('parking', 'parking'), ('free parking', 'parking'), ('car park', 'parking'), ('ที่จอดรถ', 'parking'), ('ที่จอดรถฟรี', 'parking'),
+    ('airport shuttle', 'airport_shuttle'), ('รถรับส่งสนามบิน', 'airport_shuttle'),
+    ('pool', 'pool'), ('swimming pool', 'pool'), ('outdoor pool', 'pool'), ('สระว่ายน้ำ', 'pool'),
+    ('fitness', 'fitness'), ('gym', 'fitness'), ('fitness centre', 'fitness'), ('fitness center', 'fitness'), ('ฟิตเนส', 'fitness'),
+    ('spa', 'spa'), ('สปา', 'spa'),
+    ('garden', 'garden'), ('สวน', 'garden'),
+    ('beachfront', 'beachfront'), ('beach', 'beachfront'), ('ติดชายหาด', 'beachfront'),
+    ('restaurant', 'restaurant'), ('ร้านอาหาร', 'restaurant'), ('ห้องอาหาร', 'restaurant'),
+    ('bar', 'bar'), ('บาร์', 'bar'),
+    ('breakfast', 'breakfast'), ('อาหารเช้า', 'breakfast'),
+    ('kitchen', 'kitchen'), ('ห้องครัว', 'kitchen'), ('ครัว', 'kitchen'),
+    ('air conditioning', 'air_conditioning'), ('aircon', 'air_conditioning'), ('ac', 'air_conditioning'), ('แอร์', 'air_conditioning'), ('เครื่องปรับอากาศ', 'air_conditioning'),
+    ('tv', 'tv'), ('ทีวี', 'tv'), ('โทรทัศน์', 'tv'),
+    ('washing machine', 'washing_machine'), ('laundry', 'washing_machine'), ('เครื่องซักผ้า', 'washing_machine'),
+    ('family rooms', 'family_rooms'), ('ห้องพักสำหรับครอบครัว', 'family_rooms'),
+    ('non-smoking rooms', 'non_smoking'), ('non-smoking', 'non_smoking'), ('ห้องปลอดบุหรี่', 'non_smoking'),
+    ('24-hour front desk', 'front_desk_24h'), ('แผนกต้อนรับ 24 ชั่วโมง', 'front_desk_24h'),
+    ('room service', 'room_service'), ('รูมเซอร์วิส', 'room_service'),
+    ('pets allowed', 'pet_friendly'), ('pet friendly', 'pet_friendly'), ('เลี้ยงสัตว์ได้', 'pet_friendly');
+
+CREATE TABLE facility_import (
+    accommodation_id BIGINT       NOT NULL,
+    name             VARCHAR(100) NOT NULL,
+    code             VARCHAR(50)  NULL
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
+
+-- แยก popular_facilities ตาม comma ทีละรายการ
+INSERT INTO facility_import (accommodation_id, name)
+WITH RECURSIVE parts (accommodation_id, item, rest) AS (
+    SELECT accommodation_id,
+           TRIM(SUBSTRING_INDEX(popular_facilities, ',', 1)),
+           IF(LOCATE(',', popular_facilities) > 0, SUBSTRING(popular_facilities, LOCATE(',', popular_facilities) + 1), '')
+    FROM accommodation
+    WHERE TRIM(popular_facilities) <> ''
+    UNION ALL
+    SELECT accommodation_id,
+           TRIM(SUBSTRING_INDEX(rest, ',', 1)),
+           IF(LOCATE(',', rest) > 0, SUBSTRING(rest, LOCATE(',', rest) + 1), '')
+    FROM parts
+    WHERE rest <> ''
+)
+SELECT DISTINCT accommodation_id, LEFT(item, 100) FROM parts WHERE item <> '';
+
+UPDATE facility_import i JOIN facility_alias a ON a.alias = LOWER(i.name) SET i.code = a.code;
+
+-- ชื่อที่ไม่รู้จักกลายเป็น facility ใหม่หมวด other; ชื่อที่ไม่มีตัวอักษรอังกฤษใช้ hash ของชื่อเป็น code
+UPDATE facility_import
+SET code = LEFT(TRIM(BOTH '_' FROM REGEXP_REPLACE(LOWER(name), '[^a-z0-9]+', '_')), 50)
+WHERE code IS NULL;
+
+UPDATE facility_import
+SET code = CONCAT('custom_', LEFT(SHA1(LOWER(name)), 12))
+WHERE code = '';
+
+INSERT IGNORE INTO facility (code, name)
+SELECT code, MIN(name) FROM facility_import GROUP BY code;
+
+INSERT IGNORE INTO accommodation_facility (accommodation_id, facility_id)
+SELECT i.accommodation_id, f.facility_id
+FROM facility_import i JOIN facility f ON f.code = i.code;
+
+DROP TABLE facility_import;
+DROP TABLE facility_alias;
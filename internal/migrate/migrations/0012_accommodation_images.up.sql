@@ -0,0 +1,17 @@
+CREATE TABLE IF NOT EXISTS accommodation_image (
+    image_id         BIGINT       NOT NULL AUTO_INCREMENT,
+    accommodation_id BIGINT       NOT NULL,
+    path             VARCHAR(512) NOT NULL,
+    caption          VARCHAR(255) NOT NULL DEFAULT '',
+    sort_order       INT          NOT NULL DEFAULT 0,
+    is_cover         TINYINT(1)   NOT NULL DEFAULT 0,
+    created_at       DATETIME     NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at       DATETIME     NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    PRIMARY KEY (image_id),
+    KEY idx_accommodation_image_order (accommodation_id, sort_order),
+    CONSTRAINT fk_accommodation_image_accommodation FOREIGN KEY (accommodation_id) REFERENCES accommodation (accommodation_id) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
+
+-- main_image เดิมกลายเป็นรูป cover ของ gallery
+INSERT INTO accommodation_image (accommodation_id, path, is_cover)
+SELECT accommodation_id, main_image, 1 FROM accommodation WHERE main_image <> '';
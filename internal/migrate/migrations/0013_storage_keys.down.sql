@@ -0,0 +1,7 @@
+-- key กลับเป็น path ในเครื่อง; URL http(s) ภายนอกไม่เคยเป็น key จึงคงเดิม
+UPDATE users SET photo = CONCAT('uploads/', photo) WHERE photo <> '' AND photo NOT LIKE 'http://%' AND photo NOT LIKE 'https://%';
+UPDATE accommodation SET main_image = CONCAT('uploads/', main_image) WHERE main_image <> '' AND main_image NOT LIKE 'http://%' AND main_image NOT LIKE 'https://%';
+UPDATE accommodation_image SET object_key = CONCAT('uploads/', object_key) WHERE object_key NOT LIKE 'http://%' AND object_key NOT LIKE 'https://%';
+
+ALTER TABLE accommodation_image
+    CHANGE COLUMN object_key path VARCHAR(512) NOT NULL;
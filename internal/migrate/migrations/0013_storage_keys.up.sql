@@ -0,0 +1,7 @@
+-- ไฟล์ที่อัปโหลดอ้างอิงด้วย object key ของ storage; path เดิมเป็น "uploads/<key>"
+ALTER TABLE accommodation_image
+    CHANGE COLUMN path object_key VARCHAR(512) NOT NULL;
+
+UPDATE accommodation_image SET object_key = SUBSTRING(object_key, 9) WHERE object_key LIKE 'uploads/%';
+UPDATE accommodation SET main_image = SUBSTRING(main_image, 9) WHERE main_image LIKE 'uploads/%';
+UPDATE users SET photo = SUBSTRING(photo, 9) WHERE photo LIKE 'uploads/%';
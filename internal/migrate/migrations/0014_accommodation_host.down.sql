@@ -0,0 +1,4 @@
+ALTER TABLE accommodation DROP FOREIGN KEY fk_accommodation_host;
+ALTER TABLE accommodation
+    DROP KEY idx_accommodation_host,
+    DROP COLUMN host_id;
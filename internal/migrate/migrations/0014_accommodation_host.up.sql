@@ -0,0 +1,5 @@
+-- host เจ้าของที่พัก; ที่พักที่สร้างก่อนมีระบบเจ้าของเป็น NULL (admin จัดการเท่านั้น)
+ALTER TABLE accommodation
+    ADD COLUMN host_id BIGINT NULL AFTER accommodation_id,
+    ADD KEY idx_accommodation_host (host_id),
+    ADD CONSTRAINT fk_accommodation_host FOREIGN KEY (host_id) REFERENCES users (user_id) ON DELETE SET NULL;
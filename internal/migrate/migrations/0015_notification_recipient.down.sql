@@ -0,0 +1,4 @@
+ALTER TABLE notification DROP FOREIGN KEY fk_notification_user;
+ALTER TABLE notification
+    DROP KEY idx_notification_user,
+    DROP COLUMN user_id;
@@ -0,0 +1,7 @@
+-- ผู้รับการแจ้งเตือน; แจ้งเตือนเดิมของการจองเป็นของผู้จอง ส่วนที่ไม่ผูกกับการจองเป็น NULL (admin เห็นเท่านั้น)
+ALTER TABLE notification
+    ADD COLUMN user_id BIGINT NULL AFTER notification_id,
+    ADD KEY idx_notification_user (user_id, notification_id),
+    ADD CONSTRAINT fk_notification_user FOREIGN KEY (user_id) REFERENCES users (user_id) ON DELETE CASCADE;
+
+UPDATE notification n JOIN booking b ON b.order_id = n.order_id SET n.user_id = b.user_id;
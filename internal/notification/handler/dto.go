@@ -0,0 +1,36 @@
+package handler
+
+import "myapp/internal/notification/model"
+
+// NotificationRequest คือ body ของ POST /notifications
+type NotificationRequest struct {
+	UserID             *int64 `json:"user_id" validate:"gte=1"`
+	StatusNotification string `json:"status_notification" validate:"required,oneof=unread read"`
+	OrderID            *int   `json:"order_id" validate:"gte=1"`
+}
+
+// UpdateNotificationRequest คือ body ของ PUT /notifications (ระบุ notification_id ใน body)
+type UpdateNotificationRequest struct {
+	NotificationID int `json:"notification_id" validate:"required,gte=1"`
+	NotificationRequest
+}
+
+func (req NotificationRequest) toModel() model.Notification {
+	return model.Notification{UserID: req.UserID, StatusNotification: req.StatusNotification, OrderID: req.OrderID}
+}
+
+type NotificationResponse struct {
+	NotificationID     int    `json:"notification_id"`
+	UserID             *int64 `json:"user_id,omitempty"`
+	StatusNotification string `json:"status_notification"`
+	OrderID            *int   `json:"order_id,omitempty"`
+}
+
+func newNotificationResponse(n model.Notification) NotificationResponse {
+	return NotificationResponse{
+		NotificationID:     n.NotificationID,
+		UserID:             n.UserID,
+		StatusNotification: n.StatusNotification,
+		OrderID:            n.OrderID,
+	}
+}
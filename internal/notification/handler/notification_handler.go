@@ -1,15 +1,13 @@
 package handler
 
 import (
-	"encoding/json"
-	"log"
+	"myapp/internal/auth"
+	"myapp/internal/httpx"
 	"net/http"
-	"strconv"
 
+	"myapp/internal/database"
 	"myapp/internal/notification/model"
 	"myapp/internal/notification/usecase"
-
-	"github.com/gorilla/mux"
 )
 
 type NotificationHandler struct {
@@ -20,35 +18,69 @@ func NewNotificationHandler(u usecase.NotificationUseCase) *NotificationHandler
 	return &NotificationHandler{u}
 }
 
+// GetAll รองรับ ?status_notification=&order_id=&limit=&offset=&cursor=&sort= (ผลลัพธ์จำกัดตามผู้รับเสมอ ยกเว้น admin)
 func (h *NotificationHandler) GetAll(w http.ResponseWriter, r *http.Request) {
-	data, err := h.Usecase.GetAll()
+	actor, err := actorFrom(r)
+	if err != nil {
+		httpx.WriteError(w, r, err)
+		return
+	}
+	opts, err := httpx.ListOptions(r)
+	if err != nil {
+		httpx.WriteError(w, r, err)
+		return
+	}
+	var f model.NotificationFilter
+	if f.Status, err = httpx.QueryOneOf(r, "status_notification", model.StatusUnread, model.StatusRead); err != nil {
+		httpx.WriteError(w, r, err)
+		return
+	}
+	orderID, err := httpx.QueryID(r, "order_id")
+	if err != nil {
+		httpx.WriteError(w, r, err)
+		return
+	}
+	if orderID != nil {
+		id := int(*orderID)
+		f.OrderID = &id
+	}
+
+	page, err := h.Usecase.List(r.Context(), actor, f, opts)
 	if err != nil {
-		http.Error(w, "Error fetching notifications", http.StatusInternalServerError)
+		httpx.WriteError(w, r, err)
 		return
 	}
-	json.NewEncoder(w).Encode(data)
+	httpx.WriteJSON(w, http.StatusOK, database.MapPage(page, newNotificationResponse))
 }
 
 func (h *NotificationHandler) GetByID(w http.ResponseWriter, r *http.Request) {
-	id, _ := strconv.Atoi(mux.Vars(r)["id"])
-	n, err := h.Usecase.GetByID(id)
+	actor, err := actorFrom(r)
 	if err != nil {
-		http.Error(w, "Notification not found", http.StatusNotFound)
+		httpx.WriteError(w, r, err)
 		return
 	}
-	json.NewEncoder(w).Encode(n)
+	id, err := httpx.PathID(r, "id")
+	if err != nil {
+		httpx.WriteError(w, r, err)
+		return
+	}
+	n, err := h.Usecase.GetByID(r.Context(), actor, int(id))
+	if err != nil {
+		httpx.WriteError(w, r, err)
+		return
+	}
+	httpx.WriteJSON(w, http.StatusOK, newNotificationResponse(*n))
 }
 
 func (h *NotificationHandler) Create(w http.ResponseWriter, r *http.Request) {
-	var n model.Notification
-	if err := json.NewDecoder(r.Body).Decode(&n); err != nil {
-		http.Error(w, "Invalid JSON", http.StatusBadRequest)
+	var req NotificationRequest
+	if err := httpx.Decode(w, r, &req); err != nil {
+		httpx.WriteError(w, r, err)
 		return
 	}
 
-	if err := h.Usecase.Create(n); err != nil {
-		log.Println("❌ Create failed:", err) // ✅ เพิ่ม log error จริงตรงนี้
-		http.Error(w, "Create failed", http.StatusBadRequest)
+	if err := h.Usecase.Create(r.Context(), req.toModel()); err != nil {
+		httpx.WriteError(w, r, err)
 		return
 	}
 
@@ -56,20 +88,37 @@ func (h *NotificationHandler) Create(w http.ResponseWriter, r *http.Request) {
 }
 
 func (h *NotificationHandler) Update(w http.ResponseWriter, r *http.Request) {
-	var n model.Notification
-	json.NewDecoder(r.Body).Decode(&n)
-	if err := h.Usecase.Update(n); err != nil {
-		http.Error(w, "Update failed", http.StatusBadRequest)
+	var req UpdateNotificationRequest
+	if err := httpx.Decode(w, r, &req); err != nil {
+		httpx.WriteError(w, r, err)
+		return
+	}
+	n := req.toModel()
+	n.NotificationID = req.NotificationID
+	if err := h.Usecase.Update(r.Context(), n); err != nil {
+		httpx.WriteError(w, r, err)
 		return
 	}
 	w.WriteHeader(http.StatusOK)
 }
 
 func (h *NotificationHandler) Delete(w http.ResponseWriter, r *http.Request) {
-	id, _ := strconv.Atoi(mux.Vars(r)["id"])
-	if err := h.Usecase.Delete(id); err != nil {
-		http.Error(w, "Delete failed", http.StatusInternalServerError)
+	id, err := httpx.PathID(r, "id")
+	if err != nil {
+		httpx.WriteError(w, r, err)
+		return
+	}
+	if err := h.Usecase.Delete(r.Context(), int(id)); err != nil {
+		httpx.WriteError(w, r, err)
 		return
 	}
 	w.WriteHeader(http.StatusOK)
 }
+
+func actorFrom(r *http.Request) (model.Actor, error) {
+	p, ok := auth.PrincipalFrom(r.Context())
+	if !ok {
+		return model.Actor{}, auth.ErrMissingToken
+	}
+	return model.Actor{UserID: p.UserID, Admin: p.Role == auth.RoleAdmin}, nil
+}
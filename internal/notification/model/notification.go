@@ -1,7 +1,27 @@
 package model
 
+// ค่าของ status_notification
+const (
+	StatusUnread = "unread"
+	StatusRead   = "read"
+)
+
 type Notification struct {
 	NotificationID     int    `json:"notification_id"`
+	UserID             *int64 `json:"user_id,omitempty"` // ผู้รับ; nil = ไม่มีผู้รับ (admin เห็นเท่านั้น)
 	StatusNotification string `json:"status_notification"`
 	OrderID            *int   `json:"order_id,omitempty"`
 }
+
+// NotificationFilter คือเงื่อนไขของ list (ค่าว่าง/nil = ไม่กรอง)
+type NotificationFilter struct {
+	UserID  *int64
+	Status  string
+	OrderID *int
+}
+
+// Actor คือผู้ที่เรียก usecase; Admin เห็นทุกรายการ คนอื่นเห็นเฉพาะที่ตนเป็นผู้รับ
+type Actor struct {
+	UserID int64
+	Admin  bool
+}
@@ -1,66 +1,92 @@
 package repository
 
 import (
+	"context"
 	"database/sql"
+	"myapp/internal/database"
 	"myapp/internal/notification/model"
 )
 
 type NotificationRepository interface {
-	GetAll() ([]model.Notification, error)
-	GetByID(id int) (*model.Notification, error)
-	Create(n model.Notification) error
-	Update(n model.Notification) error
-	Delete(id int) error
+	List(ctx context.Context, f model.NotificationFilter, opts database.ListOptions) (database.Page[model.Notification], error)
+	GetByID(ctx context.Context, id int) (*model.Notification, error)
+	Create(ctx context.Context, n model.Notification) error
+	Update(ctx context.Context, n model.Notification) error
+	Delete(ctx context.Context, id int) error
 }
 
 type notificationRepo struct {
-	db *sql.DB
+	db *database.DB
 }
 
-func NewNotificationRepository(db *sql.DB) NotificationRepository {
+func NewNotificationRepository(db *database.DB) NotificationRepository {
 	return &notificationRepo{db}
 }
 
-func (r *notificationRepo) GetAll() ([]model.Notification, error) {
-	rows, err := r.db.Query(`SELECT notification_id, status_notification, order_id FROM notification`)
-	if err != nil {
-		return nil, err
-	}
-	defer rows.Close()
-
-	var notifications []model.Notification
-	for rows.Next() {
+// notificationList คือ sort key ที่ client ใช้ได้ใน ?sort= (order_id เป็น NULL ได้จึงไม่เปิดให้ sort)
+var notificationList = database.ListSpec[model.Notification]{
+	IDColumn: "notification_id",
+	ID:       func(n model.Notification) any { return n.NotificationID },
+	Sorts: map[string]database.SortKey[model.Notification]{
+		"id":                  {Column: "notification_id", Value: func(n model.Notification) any { return n.NotificationID }},
+		"status_notification": {Column: "status_notification", Value: func(n model.Notification) any { return n.StatusNotification }},
+	},
+	DefaultSort: "-id",
+	Scan: func(rows *sql.Rows) (model.Notification, error) {
 		var n model.Notification
-		if err := rows.Scan(&n.NotificationID, &n.StatusNotification, &n.OrderID); err != nil {
-			return nil, err
-		}
-		notifications = append(notifications, n)
+		err := rows.Scan(&n.NotificationID, &n.UserID, &n.StatusNotification, &n.OrderID)
+		return n, err
+	},
+}
+
+func (r *notificationRepo) List(ctx context.Context, f model.NotificationFilter, opts database.ListOptions) (database.Page[model.Notification], error) {
+	q := database.Select("notification", "notification_id", "user_id", "status_notification", "order_id")
+	if f.UserID != nil {
+		q.Where("user_id = ?", *f.UserID)
+	}
+	if f.Status != "" {
+		q.Where("status_notification = ?", f.Status)
 	}
-	return notifications, nil
+	if f.OrderID != nil {
+		q.Where("order_id = ?", *f.OrderID)
+	}
+	return database.List(ctx, r.db, q, notificationList, opts)
 }
 
-func (r *notificationRepo) GetByID(id int) (*model.Notification, error) {
+func (r *notificationRepo) GetByID(ctx context.Context, id int) (*model.Notification, error) {
+	ctx, cancel := r.db.WithTimeout(ctx)
+	defer cancel()
+
 	var n model.Notification
-	err := r.db.QueryRow(`SELECT notification_id, status_notification, order_id FROM notification WHERE notification_id = ?`, id).
-		Scan(&n.NotificationID, &n.StatusNotification, &n.OrderID)
+	err := r.db.Conn(ctx).QueryRowContext(ctx, `SELECT notification_id, user_id, status_notification, order_id FROM notification WHERE notification_id = ?`, id).
+		Scan(&n.NotificationID, &n.UserID, &n.StatusNotification, &n.OrderID)
 	if err != nil {
-		return nil, err
+		return nil, database.NotFound(err, "Notification not found")
 	}
 	return &n, nil
 }
 
-func (r *notificationRepo) Create(n model.Notification) error {
-	_, err := r.db.Exec(`INSERT INTO notification (status_notification, order_id) VALUES (?, ?)`, n.StatusNotification, n.OrderID)
+func (r *notificationRepo) Create(ctx context.Context, n model.Notification) error {
+	ctx, cancel := r.db.WithTimeout(ctx)
+	defer cancel()
+
+	_, err := r.db.Conn(ctx).ExecContext(ctx, `INSERT INTO notification (user_id, status_notification, order_id) VALUES (?, ?, ?)`, n.UserID, n.StatusNotification, n.OrderID)
 	return err
 }
 
-func (r *notificationRepo) Update(n model.Notification) error {
-	_, err := r.db.Exec(`UPDATE notification SET status_notification = ?, order_id = ? WHERE notification_id = ?`,
-		n.StatusNotification, n.OrderID, n.NotificationID)
+func (r *notificationRepo) Update(ctx context.Context, n model.Notification) error {
+	ctx, cancel := r.db.WithTimeout(ctx)
+	defer cancel()
+
+	_, err := r.db.Conn(ctx).ExecContext(ctx, `UPDATE notification SET user_id = ?, status_notification = ?, order_id = ? WHERE notification_id = ?`,
+		n.UserID, n.StatusNotification, n.OrderID, n.NotificationID)
 	return err
 }
 
-func (r *notificationRepo) Delete(id int) error {
-	_, err := r.db.Exec(`DELETE FROM notification WHERE notification_id = ?`, id)
-	return err
+func (r *notificationRepo) Delete(ctx context.Context, id int) error {
+	ctx, cancel := r.db.WithTimeout(ctx)
+	defer cancel()
+
+	res, err := r.db.Conn(ctx).ExecContext(ctx, `DELETE FROM notification WHERE notification_id = ?`, id)
+	return database.RequireAffected(res, err, "Notification not found")
 }
@@ -1,22 +1,41 @@
 package routes
 
 import (
-	"database/sql"
 	"github.com/gorilla/mux"
+	"myapp/internal/auth"
+	"myapp/internal/database"
 	"myapp/internal/notification/handler"
 	"myapp/internal/notification/repository"
 	"myapp/internal/notification/usecase"
 )
 
-func RegisterNotificationRoutes(r *mux.Router, db *sql.DB) {
+type Module struct {
+	handler *handler.NotificationHandler
+	tokens  *auth.TokenManager
+}
+
+func NewModule(db *database.DB, tokens *auth.TokenManager) *Module {
 	repo := repository.NewNotificationRepository(db)
 	uc := usecase.NewNotificationUseCase(repo)
-	h := handler.NewNotificationHandler(uc)
+	return &Module{handler: handler.NewNotificationHandler(uc), tokens: tokens}
+}
+
+func (m *Module) Name() string { return "notification" }
+
+func (m *Module) RegisterRoutes(r *mux.Router) {
+	h := m.handler
+
+	// ✅ ทุก route ของ notifications ต้อง login ก่อน
+	protected := r.PathPrefix("/notifications").Subrouter()
+	protected.Use(auth.Middleware(m.tokens))
+
+	// ✅ อ่านได้เฉพาะแจ้งเตือนของตัวเอง (ตรวจใน usecase; admin อ่านได้ทุกรายการ), เขียนได้เฉพาะ admin
+	adminOnly := auth.Roles(auth.RoleAdmin)
 
 	// ✅ CRUD สำหรับ /notifications
-	r.HandleFunc("/notifications", h.GetAll).Methods("GET")
-	r.HandleFunc("/notifications/{id:[0-9]+}", h.GetByID).Methods("GET")
-	r.HandleFunc("/notifications", h.Create).Methods("POST")
-	r.HandleFunc("/notifications", h.Update).Methods("PUT")
-	r.HandleFunc("/notifications/{id:[0-9]+}", h.Delete).Methods("DELETE")
+	protected.HandleFunc("", h.GetAll).Methods("GET")
+	protected.HandleFunc("/{id:[0-9]+}", h.GetByID).Methods("GET")
+	protected.Handle("", auth.Authorize(adminOnly, h.Create)).Methods("POST")
+	protected.Handle("", auth.Authorize(adminOnly, h.Update)).Methods("PUT")
+	protected.Handle("/{id:[0-9]+}", auth.Authorize(adminOnly, h.Delete)).Methods("DELETE")
 }
@@ -1,16 +1,19 @@
 package usecase
 
 import (
+	"context"
+	"myapp/internal/apperr"
+	"myapp/internal/database"
 	"myapp/internal/notification/model"
 	"myapp/internal/notification/repository"
 )
 
 type NotificationUseCase interface {
-	GetAll() ([]model.Notification, error)
-	GetByID(id int) (*model.Notification, error)
-	Create(n model.Notification) error
-	Update(n model.Notification) error
-	Delete(id int) error
+	List(ctx context.Context, actor model.Actor, f model.NotificationFilter, opts database.ListOptions) (database.Page[model.Notification], error)
+	GetByID(ctx context.Context, actor model.Actor, id int) (*model.Notification, error)
+	Create(ctx context.Context, n model.Notification) error
+	Update(ctx context.Context, n model.Notification) error
+	Delete(ctx context.Context, id int) error
 }
 
 type notificationUsecase struct {
@@ -21,22 +24,34 @@ func NewNotificationUseCase(repo repository.NotificationRepository) Notification
 	return &notificationUsecase{repo}
 }
 
-func (u *notificationUsecase) GetAll() ([]model.Notification, error) {
-	return u.repo.GetAll()
+// List: ผู้ที่ไม่ใช่ admin เห็นเฉพาะแจ้งเตือนที่ตนเป็นผู้รับ
+func (u *notificationUsecase) List(ctx context.Context, actor model.Actor, f model.NotificationFilter, opts database.ListOptions) (database.Page[model.Notification], error) {
+	if !actor.Admin {
+		f.UserID = &actor.UserID
+	}
+	return u.repo.List(ctx, f, opts)
 }
 
-func (u *notificationUsecase) GetByID(id int) (*model.Notification, error) {
-	return u.repo.GetByID(id)
+// GetByID ตอบ not found (ไม่ใช่ forbidden) เมื่อไม่ใช่ผู้รับ เพื่อไม่ให้รู้ว่ามี id นี้อยู่
+func (u *notificationUsecase) GetByID(ctx context.Context, actor model.Actor, id int) (*model.Notification, error) {
+	n, err := u.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if !actor.Admin && (n.UserID == nil || *n.UserID != actor.UserID) {
+		return nil, apperr.NotFound("Notification not found")
+	}
+	return n, nil
 }
 
-func (u *notificationUsecase) Create(n model.Notification) error {
-	return u.repo.Create(n)
+func (u *notificationUsecase) Create(ctx context.Context, n model.Notification) error {
+	return u.repo.Create(ctx, n)
 }
 
-func (u *notificationUsecase) Update(n model.Notification) error {
-	return u.repo.Update(n)
+func (u *notificationUsecase) Update(ctx context.Context, n model.Notification) error {
+	return u.repo.Update(ctx, n)
 }
 
-func (u *notificationUsecase) Delete(id int) error {
-	return u.repo.Delete(id)
+func (u *notificationUsecase) Delete(ctx context.Context, id int) error {
+	return u.repo.Delete(ctx, id)
 }
@@ -0,0 +1,86 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"myapp/internal/apperr"
+	"myapp/internal/database"
+	"myapp/internal/notification/model"
+	"myapp/internal/notification/repository"
+)
+
+type fakeRepo struct {
+	repository.NotificationRepository
+	byID   map[int]model.Notification
+	listed model.NotificationFilter
+}
+
+func (r *fakeRepo) GetByID(ctx context.Context, id int) (*model.Notification, error) {
+	n, ok := r.byID[id]
+	if !ok {
+		return nil, apperr.NotFound("Notification not found")
+	}
+	return &n, nil
+}
+
+func (r *fakeRepo) List(ctx context.Context, f model.NotificationFilter, opts database.ListOptions) (database.Page[model.Notification], error) {
+	r.listed = f
+	return database.Page[model.Notification]{}, nil
+}
+
+func TestNotificationScope(t *testing.T) {
+	guest, host := int64(3), int64(2)
+	repo := &fakeRepo{byID: map[int]model.Notification{
+		1: {NotificationID: 1, UserID: &guest},
+		2: {NotificationID: 2, UserID: &host},
+		3: {NotificationID: 3},
+	}}
+	u := NewNotificationUseCase(repo)
+	admin := model.Actor{UserID: 1, Admin: true}
+	traveler := model.Actor{UserID: guest}
+
+	tests := []struct {
+		name  string
+		actor model.Actor
+		id    int
+		want  bool
+	}{
+		{"recipient reads own", traveler, 1, true},
+		{"other recipient is hidden", traveler, 2, false},
+		{"no recipient is hidden", traveler, 3, false},
+		{"admin reads any", admin, 2, true},
+		{"admin reads unaddressed", admin, 3, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := u.GetByID(context.Background(), tt.actor, tt.id)
+			if tt.want && err != nil {
+				t.Errorf("GetByID error = %v, want nil", err)
+			}
+			if !tt.want && !errors.Is(err, apperr.ErrNotFound) {
+				t.Errorf("GetByID error = %v, want not found", err)
+			}
+		})
+	}
+
+	t.Run("list is limited to the recipient", func(t *testing.T) {
+		other := int64(99)
+		if _, err := u.List(context.Background(), traveler, model.NotificationFilter{UserID: &other}, database.ListOptions{}); err != nil {
+			t.Fatal(err)
+		}
+		if repo.listed.UserID == nil || *repo.listed.UserID != guest {
+			t.Errorf("filter user_id = %v, want %d", repo.listed.UserID, guest)
+		}
+	})
+
+	t.Run("admin list is not limited", func(t *testing.T) {
+		if _, err := u.List(context.Background(), admin, model.NotificationFilter{}, database.ListOptions{}); err != nil {
+			t.Fatal(err)
+		}
+		if repo.listed.UserID != nil {
+			t.Errorf("filter user_id = %v, want none", *repo.listed.UserID)
+		}
+	})
+}
@@ -0,0 +1,27 @@
+package handler
+
+import "myapp/internal/province/model"
+
+// ProvinceRequest คือ body ของ POST /provinces
+type ProvinceRequest struct {
+	Name string `json:"name" validate:"required,max=255"`
+}
+
+// UpdateProvinceRequest คือ body ของ PUT /provinces (ระบุ id ใน body)
+type UpdateProvinceRequest struct {
+	ID int64 `json:"id" validate:"required,gte=1"`
+	ProvinceRequest
+}
+
+func (req ProvinceRequest) toModel() model.Province {
+	return model.Province{Name: req.Name}
+}
+
+type ProvinceResponse struct {
+	ID   int64  `json:"id"`
+	Name string `json:"name"`
+}
+
+func newProvinceResponse(p model.Province) ProvinceResponse {
+	return ProvinceResponse{ID: p.ID, Name: p.Name}
+}
@@ -0,0 +1,100 @@
+package handler
+
+import (
+	"myapp/internal/httpx"
+	"net/http"
+
+	"myapp/internal/database"
+	"myapp/internal/province/usecase"
+)
+
+type ProvinceHandler struct {
+	Usecase usecase.ProvinceUsecase
+}
+
+func NewProvinceHandler(u usecase.ProvinceUsecase) *ProvinceHandler {
+	return &ProvinceHandler{Usecase: u}
+}
+
+// GetAll รองรับ ?limit=&offset=&cursor=&sort=
+func (h *ProvinceHandler) GetAll(w http.ResponseWriter, r *http.Request) {
+	opts, err := httpx.ListOptions(r)
+	if err != nil {
+		httpx.WriteError(w, r, err)
+		return
+	}
+
+	page, err := h.Usecase.List(r.Context(), opts)
+	if err != nil {
+		httpx.WriteError(w, r, err)
+		return
+	}
+	httpx.WriteJSON(w, http.StatusOK, database.MapPage(page, newProvinceResponse))
+}
+
+func (h *ProvinceHandler) GetByID(w http.ResponseWriter, r *http.Request) {
+	id, err := httpx.PathID(r, "id")
+	if err != nil {
+		httpx.WriteError(w, r, err)
+		return
+	}
+	data, err := h.Usecase.GetByID(r.Context(), id)
+	if err != nil {
+		httpx.WriteError(w, r, err)
+		return
+	}
+	httpx.WriteJSON(w, http.StatusOK, newProvinceResponse(data))
+}
+
+func (h *ProvinceHandler) Create(w http.ResponseWriter, r *http.Request) {
+	var req ProvinceRequest
+	if err := httpx.Decode(w, r, &req); err != nil {
+		httpx.WriteError(w, r, err)
+		return
+	}
+	if err := h.Usecase.Create(r.Context(), req.toModel()); err != nil {
+		httpx.WriteError(w, r, err)
+		return
+	}
+	w.WriteHeader(http.StatusCreated)
+}
+
+func (h *ProvinceHandler) Update(w http.ResponseWriter, r *http.Request) {
+	var req UpdateProvinceRequest
+	if err := httpx.Decode(w, r, &req); err != nil {
+		httpx.WriteError(w, r, err)
+		return
+	}
+	p := req.toModel()
+	p.ID = req.ID
+	if err := h.Usecase.Update(r.Context(), p); err != nil {
+		httpx.WriteError(w, r, err)
+	}
+}
+
+func (h *ProvinceHandler) Delete(w http.ResponseWriter, r *http.Request) {
+	id, err := httpx.PathID(r, "id")
+	if err != nil {
+		httpx.WriteError(w, r, err)
+		return
+	}
+	if err := h.Usecase.Delete(r.Context(), id); err != nil {
+		httpx.WriteError(w, r, err)
+	}
+}
+
+// Tree คือ [GET] /locations/tree?province_id= คืน province → district → village แบบซ้อนกัน
+func (h *ProvinceHandler) Tree(w http.ResponseWriter, r *http.Request) {
+	provinceID, err := httpx.QueryID(r, "province_id")
+	if err != nil {
+		httpx.WriteError(w, r, err)
+		return
+	}
+
+	tree, err := h.Usecase.Tree(r.Context(), provinceID)
+	if err != nil {
+		httpx.WriteError(w, r, err)
+		return
+	}
+	httpx.WriteJSON(w, http.StatusOK, tree)
+}
@@ -0,0 +1,24 @@
+package model
+
+type Province struct {
+	ID   int64  `json:"id"`
+	Name string `json:"name"`
+}
+
+// ProvinceNode คือโหนดของ GET /locations/tree: province → district → village
+type ProvinceNode struct {
+	ID        int64          `json:"id"`
+	Name      string         `json:"name"`
+	Districts []DistrictNode `json:"districts"`
+}
+
+type DistrictNode struct {
+	ID       int64         `json:"id"`
+	Name     string        `json:"name"`
+	Villages []VillageNode `json:"villages"`
+}
+
+type VillageNode struct {
+	ID   int64  `json:"id"`
+	Name string `json:"name"`
+}
@@ -0,0 +1,140 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"myapp/internal/database"
+	"myapp/internal/province/model"
+)
+
+type ProvinceRepository interface {
+	List(ctx context.Context, opts database.ListOptions) (database.Page[model.Province], error)
+	GetByID(ctx context.Context, id int64) (model.Province, error)
+	Exists(ctx context.Context, id int64) (bool, error)
+	Create(ctx context.Context, p model.Province) error
+	Update(ctx context.Context, p model.Province) error
+	Delete(ctx context.Context, id int64) error
+	Tree(ctx context.Context, provinceID *int64) ([]model.ProvinceNode, error)
+}
+
+type provinceRepo struct {
+	db *database.DB
+}
+
+func NewProvinceRepository(db *database.DB) ProvinceRepository {
+	return &provinceRepo{db: db}
+}
+
+// provinceList คือ sort key ที่ client ใช้ได้ใน ?sort= (ใส่ "-" นำหน้าเพื่อเรียงมากไปน้อย)
+var provinceList = database.ListSpec[model.Province]{
+	IDColumn: "province_id",
+	ID:       func(p model.Province) any { return p.ID },
+	Sorts: map[string]database.SortKey[model.Province]{
+		"id":   {Column: "province_id", Value: func(p model.Province) any { return p.ID }},
+		"name": {Column: "name", Value: func(p model.Province) any { return p.Name }},
+	},
+	DefaultSort: "id",
+	Scan: func(rows *sql.Rows) (model.Province, error) {
+		var p model.Province
+		err := rows.Scan(&p.ID, &p.Name)
+		return p, err
+	},
+}
+
+func (r *provinceRepo) List(ctx context.Context, opts database.ListOptions) (database.Page[model.Province], error) {
+	return database.List(ctx, r.db, database.Select("province", "province_id", "name"), provinceList, opts)
+}
+
+func (r *provinceRepo) GetByID(ctx context.Context, id int64) (model.Province, error) {
+	ctx, cancel := r.db.WithTimeout(ctx)
+	defer cancel()
+
+	var p model.Province
+	err := r.db.Conn(ctx).QueryRowContext(ctx, "SELECT province_id, name FROM province WHERE province_id=?", id).
+		Scan(&p.ID, &p.Name)
+	return p, database.NotFound(err, "Province not found")
+}
+
+func (r *provinceRepo) Exists(ctx context.Context, id int64) (bool, error) {
+	ctx, cancel := r.db.WithTimeout(ctx)
+	defer cancel()
+
+	var exists bool
+	err := r.db.Conn(ctx).QueryRowContext(ctx, "SELECT EXISTS(SELECT 1 FROM province WHERE province_id=?)", id).Scan(&exists)
+	return exists, err
+}
+
+func (r *provinceRepo) Create(ctx context.Context, p model.Province) error {
+	ctx, cancel := r.db.WithTimeout(ctx)
+	defer cancel()
+
+	_, err := r.db.Conn(ctx).ExecContext(ctx, "INSERT INTO province (name) VALUES (?)", p.Name)
+	return err
+}
+
+func (r *provinceRepo) Update(ctx context.Context, p model.Province) error {
+	ctx, cancel := r.db.WithTimeout(ctx)
+	defer cancel()
+
+	_, err := r.db.Conn(ctx).ExecContext(ctx, "UPDATE province SET name=? WHERE province_id=?", p.Name, p.ID)
+	return err
+}
+
+func (r *provinceRepo) Delete(ctx context.Context, id int64) error {
+	ctx, cancel := r.db.WithTimeout(ctx)
+	defer cancel()
+
+	res, err := r.db.Conn(ctx).ExecContext(ctx, "DELETE FROM province WHERE province_id=?", id)
+	return database.RequireAffected(res, err, "Province not found")
+}
+
+// Tree ดึง province → district → village ใน query เดียว (LEFT JOIN เพื่อให้ province/district ที่ยังไม่มีลูกแสดงด้วย)
+// แถวเรียงตาม id ของแต่ละระดับ จึงประกอบเป็นต้นไม้ได้ในรอบเดียว
+func (r *provinceRepo) Tree(ctx context.Context, provinceID *int64) ([]model.ProvinceNode, error) {
+	ctx, cancel := r.db.WithTimeout(ctx)
+	defer cancel()
+
+	q := database.Select("province p LEFT JOIN district d ON d.province_id = p.province_id LEFT JOIN village v ON v.district_id = d.district_id",
+		"p.province_id", "p.name", "d.district_id", "d.name", "v.village_id", "v.name")
+	if provinceID != nil {
+		q.Where("p.province_id = ?", *provinceID)
+	}
+	q.OrderBy("p.province_id", false).OrderBy("d.district_id", false).OrderBy("v.village_id", false)
+
+	query, args := q.SQL()
+	rows, err := r.db.Conn(ctx).QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	tree := []model.ProvinceNode{}
+	for rows.Next() {
+		var (
+			pID          int64
+			pName        string
+			dID, vID     sql.NullInt64
+			dName, vName sql.NullString
+		)
+		if err := rows.Scan(&pID, &pName, &dID, &dName, &vID, &vName); err != nil {
+			return nil, err
+		}
+
+		if n := len(tree); n == 0 || tree[n-1].ID != pID {
+			tree = append(tree, model.ProvinceNode{ID: pID, Name: pName, Districts: []model.DistrictNode{}})
+		}
+		p := &tree[len(tree)-1]
+		if !dID.Valid {
+			continue
+		}
+
+		if n := len(p.Districts); n == 0 || p.Districts[n-1].ID != dID.Int64 {
+			p.Districts = append(p.Districts, model.DistrictNode{ID: dID.Int64, Name: dName.String, Villages: []model.VillageNode{}})
+		}
+		d := &p.Districts[len(p.Districts)-1]
+		if vID.Valid {
+			d.Villages = append(d.Villages, model.VillageNode{ID: vID.Int64, Name: vName.String})
+		}
+	}
+	return tree, rows.Err()
+}
@@ -0,0 +1,44 @@
+package router
+
+import (
+	"github.com/gorilla/mux"
+
+	"myapp/internal/auth"
+	"myapp/internal/database"
+	provinceHandler "myapp/internal/province/handler"
+	provinceRepo "myapp/internal/province/repository"
+	provinceUsecase "myapp/internal/province/usecase"
+)
+
+type Module struct {
+	handler *provinceHandler.ProvinceHandler
+	tokens  *auth.TokenManager
+}
+
+func NewModule(db *database.DB, tokens *auth.TokenManager) *Module {
+	pRepo := provinceRepo.NewProvinceRepository(db)
+	pUC := provinceUsecase.NewProvinceUsecase(pRepo)
+	return &Module{
+		handler: provinceHandler.NewProvinceHandler(pUC),
+		tokens:  tokens,
+	}
+}
+
+func (m *Module) Name() string { return "province" }
+
+func (m *Module) RegisterRoutes(r *mux.Router) {
+	pH := m.handler
+
+	// ✅ Province routes + ต้นไม้ของที่ตั้งทั้งหมด
+	r.HandleFunc("/provinces", pH.GetAll).Methods("GET")
+	r.HandleFunc("/provinces/{id}", pH.GetByID).Methods("GET")
+	r.HandleFunc("/locations/tree", pH.Tree).Methods("GET")
+
+	protected := r.NewRoute().Subrouter()
+	protected.Use(auth.Middleware(m.tokens))
+	adminOnly := auth.Roles(auth.RoleAdmin)
+
+	protected.Handle("/provinces", auth.Authorize(adminOnly, pH.Create)).Methods("POST")
+	protected.Handle("/provinces", auth.Authorize(adminOnly, pH.Update)).Methods("PUT")
+	protected.Handle("/provinces/{id}", auth.Authorize(adminOnly, pH.Delete)).Methods("DELETE")
+}
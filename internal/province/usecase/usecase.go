@@ -0,0 +1,49 @@
+package usecase
+
+import (
+	"context"
+	"myapp/internal/database"
+	"myapp/internal/province/model"
+	"myapp/internal/province/repository"
+)
+
+type ProvinceUsecase interface {
+	List(ctx context.Context, opts database.ListOptions) (database.Page[model.Province], error)
+	GetByID(ctx context.Context, id int64) (model.Province, error)
+	Create(ctx context.Context, m model.Province) error
+	Update(ctx context.Context, m model.Province) error
+	Delete(ctx context.Context, id int64) error
+	Tree(ctx context.Context, provinceID *int64) ([]model.ProvinceNode, error)
+}
+
+type provinceUsecase struct {
+	repo repository.ProvinceRepository
+}
+
+func NewProvinceUsecase(r repository.ProvinceRepository) ProvinceUsecase {
+	return &provinceUsecase{repo: r}
+}
+
+func (u *provinceUsecase) List(ctx context.Context, opts database.ListOptions) (database.Page[model.Province], error) {
+	return u.repo.List(ctx, opts)
+}
+
+func (u *provinceUsecase) GetByID(ctx context.Context, id int64) (model.Province, error) {
+	return u.repo.GetByID(ctx, id)
+}
+
+func (u *provinceUsecase) Create(ctx context.Context, p model.Province) error {
+	return u.repo.Create(ctx, p)
+}
+
+func (u *provinceUsecase) Update(ctx context.Context, p model.Province) error {
+	return u.repo.Update(ctx, p)
+}
+
+func (u *provinceUsecase) Delete(ctx context.Context, id int64) error {
+	return u.repo.Delete(ctx, id)
+}
+
+func (u *provinceUsecase) Tree(ctx context.Context, provinceID *int64) ([]model.ProvinceNode, error) {
+	return u.repo.Tree(ctx, provinceID)
+}
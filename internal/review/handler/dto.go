@@ -0,0 +1,83 @@
+package handler
+
+import (
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"myapp/internal/apperr"
+	"myapp/internal/review/model"
+)
+
+// CreateReviewRequest คือ body ของ POST /reviews; photos คือ URL ของรูปที่อัปโหลดไว้แล้ว
+type CreateReviewRequest struct {
+	OrderID int      `json:"order_id" validate:"required,gte=1"`
+	Rating  int      `json:"rating" validate:"required,gte=1,lte=5"`
+	Comment string   `json:"comment" validate:"required,max=5000"`
+	Photos  []string `json:"photos" validate:"max=10"`
+}
+
+func (req CreateReviewRequest) toModel(userID int64) (model.Review, error) {
+	for i, p := range req.Photos {
+		u, err := url.Parse(p)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" || len(p) > 512 {
+			return model.Review{}, apperr.Validation("Validation failed", map[string]string{
+				"photos." + strconv.Itoa(i): "must be an http(s) URL of at most 512 characters",
+			})
+		}
+	}
+	return model.Review{
+		UserID:  userID,
+		OrderID: req.OrderID,
+		Rating:  req.Rating,
+		Comment: strings.TrimSpace(req.Comment),
+		Photos:  req.Photos,
+	}, nil
+}
+
+// ReplyRequest คือ body ของ POST /reviews/{id}/reply
+type ReplyRequest struct {
+	Reply string `json:"reply" validate:"required,max=5000"`
+}
+
+// VisibilityRequest คือ body ของ PUT /reviews/{id}/visibility
+type VisibilityRequest struct {
+	Hidden *bool `json:"hidden" validate:"required"`
+}
+
+type ReviewResponse struct {
+	ID              int64      `json:"id"`
+	AccommodationID int64      `json:"accommodation_id"`
+	UserID          int64      `json:"user_id"`
+	OrderID         int        `json:"order_id"`
+	Rating          int        `json:"rating"`
+	Comment         string     `json:"comment"`
+	Photos          []string   `json:"photos"`
+	HostReply       *string    `json:"host_reply,omitempty"`
+	RepliedAt       *time.Time `json:"replied_at,omitempty"`
+	Hidden          bool       `json:"hidden,omitempty"`
+	CreatedAt       time.Time  `json:"created_at"`
+	UpdatedAt       time.Time  `json:"updated_at"`
+}
+
+func newReviewResponse(rv model.Review) ReviewResponse {
+	photos := rv.Photos
+	if photos == nil {
+		photos = []string{}
+	}
+	return ReviewResponse{
+		ID:              rv.ID,
+		AccommodationID: rv.AccommodationID,
+		UserID:          rv.UserID,
+		OrderID:         rv.OrderID,
+		Rating:          rv.Rating,
+		Comment:         rv.Comment,
+		Photos:          photos,
+		HostReply:       rv.HostReply,
+		RepliedAt:       rv.RepliedAt,
+		Hidden:          rv.Hidden,
+		CreatedAt:       rv.CreatedAt,
+		UpdatedAt:       rv.UpdatedAt,
+	}
+}
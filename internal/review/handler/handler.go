@@ -0,0 +1,123 @@
+package handler
+
+import (
+	"myapp/internal/auth"
+	"myapp/internal/httpx"
+	"net/http"
+	"strings"
+
+	"myapp/internal/database"
+	"myapp/internal/review/model"
+	"myapp/internal/review/usecase"
+)
+
+type ReviewHandler struct {
+	Usecase usecase.ReviewUsecase
+}
+
+func NewReviewHandler(u usecase.ReviewUsecase) *ReviewHandler {
+	return &ReviewHandler{Usecase: u}
+}
+
+// ListByAccommodation รองรับ ?limit=&offset=&cursor=&sort= (sort: id, rating, created_at)
+func (h *ReviewHandler) ListByAccommodation(w http.ResponseWriter, r *http.Request) {
+	id, err := httpx.PathID(r, "id")
+	if err != nil {
+		httpx.WriteError(w, r, err)
+		return
+	}
+	opts, err := httpx.ListOptions(r)
+	if err != nil {
+		httpx.WriteError(w, r, err)
+		return
+	}
+
+	page, err := h.Usecase.List(r.Context(), model.ReviewFilter{AccommodationID: id}, opts)
+	if err != nil {
+		httpx.WriteError(w, r, err)
+		return
+	}
+	httpx.WriteJSON(w, http.StatusOK, database.MapPage(page, newReviewResponse))
+}
+
+func (h *ReviewHandler) GetByID(w http.ResponseWriter, r *http.Request) {
+	id, err := httpx.PathID(r, "id")
+	if err != nil {
+		httpx.WriteError(w, r, err)
+		return
+	}
+	rv, err := h.Usecase.GetByID(r.Context(), id)
+	if err != nil {
+		httpx.WriteError(w, r, err)
+		return
+	}
+	httpx.WriteJSON(w, http.StatusOK, newReviewResponse(rv))
+}
+
+// ✅ [POST] /reviews - รีวิวการเข้าพักที่ completed แล้ว
+func (h *ReviewHandler) Create(w http.ResponseWriter, r *http.Request) {
+	p, ok := auth.PrincipalFrom(r.Context())
+	if !ok {
+		httpx.WriteError(w, r, auth.ErrMissingToken)
+		return
+	}
+	var req CreateReviewRequest
+	if err := httpx.Decode(w, r, &req); err != nil {
+		httpx.WriteError(w, r, err)
+		return
+	}
+	rv, err := req.toModel(p.UserID)
+	if err != nil {
+		httpx.WriteError(w, r, err)
+		return
+	}
+
+	created, err := h.Usecase.Create(r.Context(), rv)
+	if err != nil {
+		httpx.WriteError(w, r, err)
+		return
+	}
+	httpx.WriteJSON(w, http.StatusCreated, newReviewResponse(created))
+}
+
+// ✅ [POST] /reviews/{id}/reply - host ตอบรีวิว (ได้ครั้งเดียว)
+func (h *ReviewHandler) Reply(w http.ResponseWriter, r *http.Request) {
+	id, err := httpx.PathID(r, "id")
+	if err != nil {
+		httpx.WriteError(w, r, err)
+		return
+	}
+	var req ReplyRequest
+	if err := httpx.Decode(w, r, &req); err != nil {
+		httpx.WriteError(w, r, err)
+		return
+	}
+
+	rv, err := h.Usecase.Reply(r.Context(), id, strings.TrimSpace(req.Reply))
+	if err != nil {
+		httpx.WriteError(w, r, err)
+		return
+	}
+	httpx.WriteJSON(w, http.StatusOK, newReviewResponse(rv))
+}
+
+// ✅ [PUT] /reviews/{id}/visibility - admin ซ่อน/แสดงรีวิว
+func (h *ReviewHandler) SetVisibility(w http.ResponseWriter, r *http.Request) {
+	id, err := httpx.PathID(r, "id")
+	if err != nil {
+		httpx.WriteError(w, r, err)
+		return
+	}
+	var req VisibilityRequest
+	if err := httpx.Decode(w, r, &req); err != nil {
+		httpx.WriteError(w, r, err)
+		return
+	}
+
+	rv, err := h.Usecase.SetHidden(r.Context(), id, *req.Hidden)
+	if err != nil {
+		httpx.WriteError(w, r, err)
+		return
+	}
+	httpx.WriteJSON(w, http.StatusOK, newReviewResponse(rv))
+}
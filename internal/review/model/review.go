@@ -0,0 +1,24 @@
+package model
+
+import "time"
+
+type Review struct {
+	ID              int64
+	AccommodationID int64
+	UserID          int64
+	OrderID         int
+	Rating          int
+	Comment         string
+	Photos          []string
+	HostReply       *string
+	RepliedAt       *time.Time
+	Hidden          bool
+	CreatedAt       time.Time
+	UpdatedAt       time.Time
+}
+
+// ReviewFilter คือเงื่อนไขของ list; รีวิวที่ถูกซ่อนจะไม่แสดงเว้นแต่ IncludeHidden
+type ReviewFilter struct {
+	AccommodationID int64
+	IncludeHidden   bool
+}
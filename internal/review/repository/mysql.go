@@ -0,0 +1,229 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"myapp/internal/database"
+	"myapp/internal/review/model"
+	"strings"
+	"time"
+)
+
+type ReviewRepository interface {
+	List(ctx context.Context, f model.ReviewFilter, opts database.ListOptions) (database.Page[model.Review], error)
+	GetByID(ctx context.Context, id int64) (model.Review, error)
+	// Lock อ่านรีวิวพร้อม row lock (ต้องอยู่ใน transaction)
+	Lock(ctx context.Context, id int64) (model.Review, error)
+	// Photos คืนรูปของรีวิวหลายรายการในครั้งเดียว (key คือ review_id)
+	Photos(ctx context.Context, reviewIDs []int64) (map[int64][]string, error)
+	Create(ctx context.Context, rv model.Review) (int64, error)
+	// SetReply/SetHidden ควรเรียกหลัง Lock รีวิวนั้นใน transaction เดียวกัน
+	SetReply(ctx context.Context, id int64, reply string) error
+	SetHidden(ctx context.Context, id int64, hidden bool) error
+	// AdjustRating บวกค่าเข้ายอดสะสมของที่พัก (ติดลบเมื่อซ่อนรีวิว); ไม่ต้องคำนวณใหม่จากรีวิวทั้งหมด
+	AdjustRating(ctx context.Context, accommodationID int64, sum int64, count int) error
+	// SubtractUserReviews lock รีวิวของผู้ใช้แล้วหักรีวิวที่แสดงอยู่ออกจากยอดสะสม (ต้องอยู่ใน transaction เดียวกับการลบผู้ใช้)
+	SubtractUserReviews(ctx context.Context, userID int64) error
+}
+
+type reviewRepo struct {
+	db *database.DB
+}
+
+func NewReviewRepository(db *database.DB) ReviewRepository {
+	return &reviewRepo{db: db}
+}
+
+const reviewColumns = "review_id, accommodation_id, user_id, order_id, rating, comment, host_reply, replied_at, hidden, created_at, updated_at"
+
+func scanReview(row interface{ Scan(...any) error }) (model.Review, error) {
+	var rv model.Review
+	err := row.Scan(&rv.ID, &rv.AccommodationID, &rv.UserID, &rv.OrderID, &rv.Rating, &rv.Comment,
+		&rv.HostReply, &rv.RepliedAt, &rv.Hidden, &rv.CreatedAt, &rv.UpdatedAt)
+	return rv, err
+}
+
+// reviewList คือ sort key ที่ client ใช้ได้ใน ?sort= (ใส่ "-" นำหน้าเพื่อเรียงมากไปน้อย)
+var reviewList = database.ListSpec[model.Review]{
+	IDColumn: "review_id",
+	ID:       func(rv model.Review) any { return rv.ID },
+	Sorts: map[string]database.SortKey[model.Review]{
+		"id":         {Column: "review_id", Value: func(rv model.Review) any { return rv.ID }},
+		"rating":     {Column: "rating", Value: func(rv model.Review) any { return rv.Rating }},
+		"created_at": {Column: "created_at", Value: func(rv model.Review) any { return rv.CreatedAt.Format(time.DateTime) }},
+	},
+	DefaultSort: "-id",
+	Scan:        func(rows *sql.Rows) (model.Review, error) { return scanReview(rows) },
+}
+
+func (r *reviewRepo) List(ctx context.Context, f model.ReviewFilter, opts database.ListOptions) (database.Page[model.Review], error) {
+	q := database.Select("review",
+		"review_id", "accommodation_id", "user_id", "order_id", "rating", "comment", "host_reply", "replied_at", "hidden", "created_at", "updated_at").
+		Where("accommodation_id = ?", f.AccommodationID)
+	if !f.IncludeHidden {
+		q.Where("hidden = 0")
+	}
+	page, err := database.List(ctx, r.db, q, reviewList, opts)
+	if err != nil {
+		return page, err
+	}
+
+	ids := make([]int64, 0, len(page.Items))
+	for _, rv := range page.Items {
+		ids = append(ids, rv.ID)
+	}
+	photos, err := r.Photos(ctx, ids)
+	if err != nil {
+		return page, err
+	}
+	for i := range page.Items {
+		page.Items[i].Photos = photos[page.Items[i].ID]
+	}
+	return page, nil
+}
+
+func (r *reviewRepo) GetByID(ctx context.Context, id int64) (model.Review, error) {
+	return r.get(ctx, "SELECT "+reviewColumns+" FROM review WHERE review_id = ?", id)
+}
+
+func (r *reviewRepo) Lock(ctx context.Context, id int64) (model.Review, error) {
+	return r.get(ctx, "SELECT "+reviewColumns+" FROM review WHERE review_id = ? FOR UPDATE", id)
+}
+
+func (r *reviewRepo) get(ctx context.Context, query string, id int64) (model.Review, error) {
+	ctx, cancel := r.db.WithTimeout(ctx)
+	defer cancel()
+
+	rv, err := scanReview(r.db.Conn(ctx).QueryRowContext(ctx, query, id))
+	if err != nil {
+		return rv, database.NotFound(err, "Review not found")
+	}
+	photos, err := r.Photos(ctx, []int64{id})
+	rv.Photos = photos[id]
+	return rv, err
+}
+
+func (r *reviewRepo) Photos(ctx context.Context, reviewIDs []int64) (map[int64][]string, error) {
+	photos := map[int64][]string{}
+	if len(reviewIDs) == 0 {
+		return photos, nil
+	}
+
+	ctx, cancel := r.db.WithTimeout(ctx)
+	defer cancel()
+
+	args := make([]any, 0, len(reviewIDs))
+	for _, id := range reviewIDs {
+		args = append(args, id)
+	}
+	rows, err := r.db.Conn(ctx).QueryContext(ctx,
+		"SELECT review_id, url FROM review_photo WHERE review_id IN (?"+strings.Repeat(", ?", len(args)-1)+") ORDER BY review_id, position",
+		args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var id int64
+		var url string
+		if err := rows.Scan(&id, &url); err != nil {
+			return nil, err
+		}
+		photos[id] = append(photos[id], url)
+	}
+	return photos, rows.Err()
+}
+
+// Create เพิ่มรีวิวพร้อมรูป; ควรเรียกใน transaction เพื่อให้รีวิวกับรูปเข้าไปพร้อมกัน
+func (r *reviewRepo) Create(ctx context.Context, rv model.Review) (int64, error) {
+	ctx, cancel := r.db.WithTimeout(ctx)
+	defer cancel()
+
+	res, err := r.db.Conn(ctx).ExecContext(ctx, `
+		INSERT INTO review (accommodation_id, user_id, order_id, rating, comment)
+		VALUES (?, ?, ?, ?, ?)`,
+		rv.AccommodationID, rv.UserID, rv.OrderID, rv.Rating, rv.Comment)
+	if err != nil {
+		return 0, err
+	}
+	id, err := res.LastInsertId()
+	if err != nil {
+		return 0, err
+	}
+
+	for i, url := range rv.Photos {
+		if _, err := r.db.Conn(ctx).ExecContext(ctx,
+			"INSERT INTO review_photo (review_id, position, url) VALUES (?, ?, ?)", id, i, url); err != nil {
+			return 0, err
+		}
+	}
+	return id, nil
+}
+
+func (r *reviewRepo) SetReply(ctx context.Context, id int64, reply string) error {
+	ctx, cancel := r.db.WithTimeout(ctx)
+	defer cancel()
+
+	_, err := r.db.Conn(ctx).ExecContext(ctx,
+		"UPDATE review SET host_reply = ?, replied_at = NOW() WHERE review_id = ?", reply, id)
+	return err
+}
+
+func (r *reviewRepo) SetHidden(ctx context.Context, id int64, hidden bool) error {
+	ctx, cancel := r.db.WithTimeout(ctx)
+	defer cancel()
+
+	_, err := r.db.Conn(ctx).ExecContext(ctx, "UPDATE review SET hidden = ? WHERE review_id = ?", hidden, id)
+	return err
+}
+
+func (r *reviewRepo) AdjustRating(ctx context.Context, accommodationID int64, sum int64, count int) error {
+	ctx, cancel := r.db.WithTimeout(ctx)
+	defer cancel()
+
+	_, err := r.db.Conn(ctx).ExecContext(ctx,
+		"UPDATE accommodation SET rating_sum = rating_sum + ?, rating_count = rating_count + ? WHERE accommodation_id = ?",
+		sum, count, accommodationID)
+	return err
+}
+
+func (r *reviewRepo) SubtractUserReviews(ctx context.Context, userID int64) error {
+	ctx, cancel := r.db.WithTimeout(ctx)
+	defer cancel()
+
+	// ✅ FOR UPDATE กันการซ่อน/แสดงรีวิวพร้อมกันทำให้ยอดเพี้ยน
+	rows, err := r.db.Conn(ctx).QueryContext(ctx, `
+		SELECT accommodation_id, SUM(rating), COUNT(*)
+		FROM review WHERE user_id = ? AND hidden = 0
+		GROUP BY accommodation_id
+		FOR UPDATE`, userID)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	type totals struct {
+		accommodationID, sum int64
+		count                int
+	}
+	var ratings []totals
+	for rows.Next() {
+		var t totals
+		if err := rows.Scan(&t.accommodationID, &t.sum, &t.count); err != nil {
+			return err
+		}
+		ratings = append(ratings, t)
+	}
+	if err := rows.Err(); err != nil {
+		return err
+	}
+	rows.Close() // ต้องปิดก่อนรัน statement ถัดไปบน connection เดียวกัน
+
+	for _, t := range ratings {
+		if err := r.AdjustRating(ctx, t.accommodationID, -t.sum, -t.count); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,36 @@
+package repository
+
+import (
+	"context"
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+
+	"myapp/internal/database"
+)
+
+func TestSubtractUserReviews(t *testing.T) {
+	sqlDB, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer sqlDB.Close()
+	repo := NewReviewRepository(database.New(sqlDB, 0))
+
+	mock.ExpectQuery(`SELECT accommodation_id, SUM\(rating\), COUNT\(\*\)\s+FROM review WHERE user_id = \? AND hidden = 0\s+GROUP BY accommodation_id\s+FOR UPDATE`).
+		WithArgs(5).
+		WillReturnRows(sqlmock.NewRows([]string{"accommodation_id", "sum", "count"}).
+			AddRow(10, 9, 2).
+			AddRow(11, 4, 1))
+	adjust := regexp.QuoteMeta("UPDATE accommodation SET rating_sum = rating_sum + ?, rating_count = rating_count + ? WHERE accommodation_id = ?")
+	mock.ExpectExec(adjust).WithArgs(-9, -2, 10).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(adjust).WithArgs(-4, -1, 11).WillReturnResult(sqlmock.NewResult(0, 1))
+
+	if err := repo.SubtractUserReviews(context.Background(), 5); err != nil {
+		t.Fatalf("SubtractUserReviews error = %v", err)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
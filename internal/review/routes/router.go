@@ -0,0 +1,62 @@
+package router
+
+import (
+	"net/http"
+
+	"github.com/gorilla/mux"
+
+	accRepo "myapp/internal/accommodation/repository"
+	"myapp/internal/auth"
+	bookingRepo "myapp/internal/booking/repository"
+	"myapp/internal/database"
+	"myapp/internal/httpx"
+	reviewHandler "myapp/internal/review/handler"
+	reviewRepo "myapp/internal/review/repository"
+	reviewUsecase "myapp/internal/review/usecase"
+)
+
+type Module struct {
+	handler     *reviewHandler.ReviewHandler
+	reviewOwner auth.OwnerFunc
+	tokens      *auth.TokenManager
+}
+
+func NewModule(db *database.DB, tokens *auth.TokenManager) *Module {
+	rRepo := reviewRepo.NewReviewRepository(db)
+	rUC := reviewUsecase.NewReviewUsecase(db, rRepo, bookingRepo.NewBookingRepository(db))
+	accommodations := accRepo.NewAccommodationRepository(db)
+	return &Module{
+		handler: reviewHandler.NewReviewHandler(rUC),
+		// ✅ เจ้าของที่พักที่ถูกรีวิวจาก path {id} ของ /reviews/{id}
+		reviewOwner: func(r *http.Request) (int64, error) {
+			id, err := httpx.PathID(r, "id")
+			if err != nil {
+				return 0, err
+			}
+			rv, err := rRepo.GetByID(r.Context(), id)
+			if err != nil {
+				return 0, err
+			}
+			return accommodations.OwnerOf(r.Context(), rv.AccommodationID)
+		},
+		tokens: tokens,
+	}
+}
+
+func (m *Module) Name() string { return "review" }
+
+func (m *Module) RegisterRoutes(r *mux.Router) {
+	rH := m.handler
+
+	// ✅ รีวิวที่แสดงอยู่ของที่พัก (ไม่รวมรีวิวที่ถูกซ่อน)
+	r.HandleFunc("/accommodations/{id:[0-9]+}/reviews", rH.ListByAccommodation).Methods("GET")
+	r.HandleFunc("/reviews/{id:[0-9]+}", rH.GetByID).Methods("GET")
+
+	// ✅ เขียนรีวิวต้อง login; ตอบรีวิวเฉพาะเจ้าของที่พัก/admin และซ่อนรีวิวเฉพาะ admin
+	protected := r.PathPrefix("/reviews").Subrouter()
+	protected.Use(auth.Middleware(m.tokens))
+
+	protected.HandleFunc("", rH.Create).Methods("POST")
+	protected.Handle("/{id:[0-9]+}/reply", auth.AuthorizeOwner(m.reviewOwner, rH.Reply)).Methods("POST")
+	protected.Handle("/{id:[0-9]+}/visibility", auth.Authorize(auth.Roles(auth.RoleAdmin), rH.SetVisibility)).Methods("PUT")
+}
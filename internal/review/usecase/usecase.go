@@ -0,0 +1,128 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"myapp/internal/apperr"
+	bookingModel "myapp/internal/booking/model"
+	bookingRepo "myapp/internal/booking/repository"
+	"myapp/internal/database"
+	"myapp/internal/review/model"
+	"myapp/internal/review/repository"
+)
+
+var (
+	ErrStayNotCompleted = apperr.Conflict("stay_not_completed", "Only completed stays can be reviewed")
+	ErrAlreadyReviewed  = apperr.Conflict("already_reviewed", "This booking has already been reviewed")
+	ErrAlreadyReplied   = apperr.Conflict("already_replied", "The host has already replied to this review")
+)
+
+type ReviewUsecase interface {
+	List(ctx context.Context, f model.ReviewFilter, opts database.ListOptions) (database.Page[model.Review], error)
+	GetByID(ctx context.Context, id int64) (model.Review, error)
+	Create(ctx context.Context, rv model.Review) (model.Review, error)
+	Reply(ctx context.Context, id int64, reply string) (model.Review, error)
+	SetHidden(ctx context.Context, id int64, hidden bool) (model.Review, error)
+}
+
+type reviewUsecase struct {
+	tx       database.Transactor
+	repo     repository.ReviewRepository
+	bookings bookingRepo.BookingRepository
+}
+
+func NewReviewUsecase(tx database.Transactor, r repository.ReviewRepository, bookings bookingRepo.BookingRepository) ReviewUsecase {
+	return &reviewUsecase{tx: tx, repo: r, bookings: bookings}
+}
+
+func (u *reviewUsecase) List(ctx context.Context, f model.ReviewFilter, opts database.ListOptions) (database.Page[model.Review], error) {
+	return u.repo.List(ctx, f, opts)
+}
+
+// GetByID: รีวิวที่ถูกซ่อนตอบเป็น not found
+func (u *reviewUsecase) GetByID(ctx context.Context, id int64) (model.Review, error) {
+	rv, err := u.repo.GetByID(ctx, id)
+	if err != nil {
+		return rv, err
+	}
+	if rv.Hidden {
+		return model.Review{}, apperr.NotFound("Review not found")
+	}
+	return rv, nil
+}
+
+// Create รีวิวการเข้าพักที่ completed แล้วของผู้ใช้เอง (หนึ่งรีวิวต่อหนึ่ง order)
+// เพิ่มยอดสะสมคะแนนของที่พักใน transaction เดียวกัน
+func (u *reviewUsecase) Create(ctx context.Context, rv model.Review) (model.Review, error) {
+	var id int64
+	err := u.tx.WithinTx(ctx, func(ctx context.Context) error {
+		b, err := u.bookings.Lock(ctx, rv.OrderID)
+		if errors.Is(err, apperr.ErrNotFound) || (err == nil && b.UserID != rv.UserID) {
+			return apperr.Validation("Booking does not exist", map[string]string{"order_id": "does not exist"})
+		}
+		if err != nil {
+			return err
+		}
+		if b.Status != bookingModel.StatusCompleted {
+			return ErrStayNotCompleted
+		}
+
+		rv.AccommodationID = b.AccommodationID
+		id, err = u.repo.Create(ctx, rv)
+		if database.IsDuplicate(err) {
+			return ErrAlreadyReviewed.Wrap(err)
+		}
+		if err != nil {
+			return err
+		}
+		return u.repo.AdjustRating(ctx, rv.AccommodationID, int64(rv.Rating), 1)
+	})
+	if err != nil {
+		return model.Review{}, err
+	}
+	return u.repo.GetByID(ctx, id)
+}
+
+// Reply ให้ host ตอบรีวิวได้ครั้งเดียว
+func (u *reviewUsecase) Reply(ctx context.Context, id int64, reply string) (model.Review, error) {
+	err := u.tx.WithinTx(ctx, func(ctx context.Context) error {
+		rv, err := u.repo.Lock(ctx, id)
+		if err != nil {
+			return err
+		}
+		if rv.HostReply != nil {
+			return ErrAlreadyReplied
+		}
+		return u.repo.SetReply(ctx, id, reply)
+	})
+	if err != nil {
+		return model.Review{}, err
+	}
+	return u.repo.GetByID(ctx, id)
+}
+
+// SetHidden ซ่อน/แสดงรีวิว (admin) และหัก/คืนคะแนนในยอดสะสมของที่พัก
+// ตั้งค่าเดิมซ้ำไม่เปลี่ยนยอดสะสม
+func (u *reviewUsecase) SetHidden(ctx context.Context, id int64, hidden bool) (model.Review, error) {
+	err := u.tx.WithinTx(ctx, func(ctx context.Context) error {
+		rv, err := u.repo.Lock(ctx, id)
+		if err != nil {
+			return err
+		}
+		if rv.Hidden == hidden {
+			return nil
+		}
+		if err := u.repo.SetHidden(ctx, id, hidden); err != nil {
+			return err
+		}
+		sum, count := int64(rv.Rating), 1
+		if hidden {
+			sum, count = -sum, -count
+		}
+		return u.repo.AdjustRating(ctx, rv.AccommodationID, sum, count)
+	})
+	if err != nil {
+		return model.Review{}, err
+	}
+	return u.repo.GetByID(ctx, id)
+}
@@ -0,0 +1,125 @@
+package handler
+
+import (
+	"math"
+	"strconv"
+	"time"
+
+	"myapp/internal/apperr"
+	"myapp/internal/room/model"
+)
+
+// RoomTypeRequest คือ body ของ POST /accommodations/{id}/room-types และ PUT /room-types/{id}
+type RoomTypeRequest struct {
+	Name             string   `json:"name" validate:"required,max=255"`
+	Capacity         int      `json:"capacity" validate:"required,gte=1,lte=50"`
+	BedConfiguration string   `json:"bed_configuration" validate:"max=255"`
+	BasePrice        *float64 `json:"base_price" validate:"required,gte=0,lte=10000000"`
+	Quantity         int      `json:"quantity" validate:"required,gte=1,lte=1000"`
+	MinStay          int      `json:"min_stay" validate:"gte=0,lte=90"`
+}
+
+func (req RoomTypeRequest) toModel() model.RoomType {
+	minStay := req.MinStay
+	if minStay == 0 {
+		minStay = 1
+	}
+	return model.RoomType{
+		Name:             req.Name,
+		Capacity:         req.Capacity,
+		BedConfiguration: req.BedConfiguration,
+		BasePrice:        math.Round(*req.BasePrice*100) / 100,
+		Quantity:         req.Quantity,
+		MinStay:          minStay,
+	}
+}
+
+// CalendarRequest คือ body ของ PUT /room-types/{id}/calendar
+// ใช้กับทุกวันในช่วง [from, to); ไม่ส่ง price/min_stay และ closed=false = ล้าง override ของช่วงนั้น
+type CalendarRequest struct {
+	From    string   `json:"from" validate:"required"`
+	To      string   `json:"to" validate:"required"`
+	Price   *float64 `json:"price" validate:"gte=0,lte=10000000"`
+	Closed  bool     `json:"closed"`
+	MinStay *int     `json:"min_stay" validate:"gte=1,lte=90"`
+}
+
+type RoomTypeResponse struct {
+	ID               int64   `json:"id"`
+	AccommodationID  int64   `json:"accommodation_id"`
+	Name             string  `json:"name"`
+	Capacity         int     `json:"capacity"`
+	BedConfiguration string  `json:"bed_configuration"`
+	BasePrice        float64 `json:"base_price"`
+	Quantity         int     `json:"quantity"`
+	MinStay          int     `json:"min_stay"`
+}
+
+func newRoomTypeResponse(rt model.RoomType) RoomTypeResponse {
+	return RoomTypeResponse{
+		ID:               rt.ID,
+		AccommodationID:  rt.AccommodationID,
+		Name:             rt.Name,
+		Capacity:         rt.Capacity,
+		BedConfiguration: rt.BedConfiguration,
+		BasePrice:        rt.BasePrice,
+		Quantity:         rt.Quantity,
+		MinStay:          rt.MinStay,
+	}
+}
+
+type NightResponse struct {
+	Date      string  `json:"date"`
+	Price     float64 `json:"price"`
+	Available int     `json:"available"`
+	Closed    bool    `json:"closed,omitempty"`
+}
+
+type AvailabilityResponse struct {
+	RoomType   RoomTypeResponse `json:"room_type"`
+	Bookable   bool             `json:"bookable"`
+	Reason     string           `json:"reason,omitempty"`
+	MinStay    int              `json:"min_stay"`
+	TotalPrice float64          `json:"total_price"`
+	Nights     []NightResponse  `json:"nights"`
+}
+
+func newAvailabilityResponse(av model.Availability) AvailabilityResponse {
+	nights := make([]NightResponse, 0, len(av.Nights))
+	for _, n := range av.Nights {
+		nights = append(nights, NightResponse{Date: n.Date.Format(time.DateOnly), Price: n.Price, Available: n.Available, Closed: n.Closed})
+	}
+	return AvailabilityResponse{
+		RoomType:   newRoomTypeResponse(av.RoomType),
+		Bookable:   av.Bookable,
+		Reason:     av.Reason,
+		MinStay:    av.MinStay,
+		TotalPrice: av.TotalPrice,
+		Nights:     nights,
+	}
+}
+
+// parseRange อ่านช่วงวัน [from, to) ในรูป YYYY-MM-DD และจำกัดความยาวไม่เกิน MaxRangeNights
+func parseRange(rawFrom, rawTo string) (time.Time, time.Time, error) {
+	fields := map[string]string{}
+	from, err := time.Parse(time.DateOnly, rawFrom)
+	if err != nil {
+		fields["from"] = "must be a date in YYYY-MM-DD format"
+	}
+	to, err := time.Parse(time.DateOnly, rawTo)
+	if err != nil {
+		fields["to"] = "must be a date in YYYY-MM-DD format"
+	}
+	if len(fields) == 0 {
+		switch nights := int(to.Sub(from).Hours() / 24); {
+		case nights < 1:
+			fields["to"] = "must be after from"
+		case nights > model.MaxRangeNights:
+			fields["to"] = "range must not exceed " + strconv.Itoa(model.MaxRangeNights) + " nights"
+		}
+	}
+	if len(fields) > 0 {
+		return time.Time{}, time.Time{}, apperr.Validation("Invalid date range", fields)
+	}
+	return from, to, nil
+}
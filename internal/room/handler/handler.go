@@ -0,0 +1,165 @@
+package handler
+
+import (
+	"math"
+	"myapp/internal/httpx"
+	"net/http"
+
+	"myapp/internal/room/model"
+	"myapp/internal/room/usecase"
+)
+
+type RoomHandler struct {
+	Usecase usecase.RoomUsecase
+}
+
+func NewRoomHandler(u usecase.RoomUsecase) *RoomHandler {
+	return &RoomHandler{Usecase: u}
+}
+
+// ✅ [GET] /accommodations/{id}/room-types
+func (h *RoomHandler) ListByAccommodation(w http.ResponseWriter, r *http.Request) {
+	id, err := httpx.PathID(r, "id")
+	if err != nil {
+		httpx.WriteError(w, r, err)
+		return
+	}
+	list, err := h.Usecase.ListByAccommodation(r.Context(), id)
+	if err != nil {
+		httpx.WriteError(w, r, err)
+		return
+	}
+
+	resp := make([]RoomTypeResponse, 0, len(list))
+	for _, rt := range list {
+		resp = append(resp, newRoomTypeResponse(rt))
+	}
+	httpx.WriteJSON(w, http.StatusOK, resp)
+}
+
+func (h *RoomHandler) GetByID(w http.ResponseWriter, r *http.Request) {
+	id, err := httpx.PathID(r, "id")
+	if err != nil {
+		httpx.WriteError(w, r, err)
+		return
+	}
+	rt, err := h.Usecase.GetByID(r.Context(), id)
+	if err != nil {
+		httpx.WriteError(w, r, err)
+		return
+	}
+	httpx.WriteJSON(w, http.StatusOK, newRoomTypeResponse(rt))
+}
+
+// ✅ [POST] /accommodations/{id}/room-types
+func (h *RoomHandler) Create(w http.ResponseWriter, r *http.Request) {
+	accommodationID, err := httpx.PathID(r, "id")
+	if err != nil {
+		httpx.WriteError(w, r, err)
+		return
+	}
+	var req RoomTypeRequest
+	if err := httpx.Decode(w, r, &req); err != nil {
+		httpx.WriteError(w, r, err)
+		return
+	}
+
+	rt := req.toModel()
+	rt.AccommodationID = accommodationID
+	created, err := h.Usecase.Create(r.Context(), rt)
+	if err != nil {
+		httpx.WriteError(w, r, err)
+		return
+	}
+	httpx.WriteJSON(w, http.StatusCreated, newRoomTypeResponse(created))
+}
+
+// ✅ [PUT] /room-types/{id}
+func (h *RoomHandler) Update(w http.ResponseWriter, r *http.Request) {
+	id, err := httpx.PathID(r, "id")
+	if err != nil {
+		httpx.WriteError(w, r, err)
+		return
+	}
+	var req RoomTypeRequest
+	if err := httpx.Decode(w, r, &req); err != nil {
+		httpx.WriteError(w, r, err)
+		return
+	}
+
+	rt := req.toModel()
+	rt.ID = id
+	if err := h.Usecase.Update(r.Context(), rt); err != nil {
+		httpx.WriteError(w, r, err)
+	}
+}
+
+func (h *RoomHandler) Delete(w http.ResponseWriter, r *http.Request) {
+	id, err := httpx.PathID(r, "id")
+	if err != nil {
+		httpx.WriteError(w, r, err)
+		return
+	}
+	if err := h.Usecase.Delete(r.Context(), id); err != nil {
+		httpx.WriteError(w, r, err)
+	}
+}
+
+// ✅ [PUT] /room-types/{id}/calendar - ตั้งราคา/blackout/min stay รายวัน
+func (h *RoomHandler) SetCalendar(w http.ResponseWriter, r *http.Request) {
+	id, err := httpx.PathID(r, "id")
+	if err != nil {
+		httpx.WriteError(w, r, err)
+		return
+	}
+	var req CalendarRequest
+	if err := httpx.Decode(w, r, &req); err != nil {
+		httpx.WriteError(w, r, err)
+		return
+	}
+	from, to, err := parseRange(req.From, req.To)
+	if err != nil {
+		httpx.WriteError(w, r, err)
+		return
+	}
+
+	day := model.CalendarDay{Closed: req.Closed, MinStay: req.MinStay}
+	if req.Price != nil {
+		p := math.Round(*req.Price*100) / 100
+		day.Price = &p
+	}
+	if err := h.Usecase.SetCalendar(r.Context(), id, from, to, day); err != nil {
+		httpx.WriteError(w, r, err)
+	}
+}
+
+// ✅ [GET] /accommodations/{id}/availability?from=&to=&guests=
+func (h *RoomHandler) Availability(w http.ResponseWriter, r *http.Request) {
+	id, err := httpx.PathID(r, "id")
+	if err != nil {
+		httpx.WriteError(w, r, err)
+		return
+	}
+	from, to, err := parseRange(r.URL.Query().Get("from"), r.URL.Query().Get("to"))
+	if err != nil {
+		httpx.WriteError(w, r, err)
+		return
+	}
+	guests, err := httpx.QueryInt(r, "guests", 1, 1, 50)
+	if err != nil {
+		httpx.WriteError(w, r, err)
+		return
+	}
+
+	list, err := h.Usecase.Availability(r.Context(), id, from, to, guests)
+	if err != nil {
+		httpx.WriteError(w, r, err)
+		return
+	}
+
+	resp := make([]AvailabilityResponse, 0, len(list))
+	for _, av := range list {
+		resp = append(resp, newAvailabilityResponse(av))
+	}
+	httpx.WriteJSON(w, http.StatusOK, resp)
+}
@@ -0,0 +1,123 @@
+package model
+
+import (
+	"math"
+	"time"
+)
+
+// MaxRangeNights คือช่วงวันสูงสุดที่ตรวจ availability ได้ในครั้งเดียว
+const MaxRangeNights = 90
+
+// RoomType คือประเภทห้องของที่พัก; Quantity คือจำนวนห้องประเภทนี้ที่ขายได้ต่อคืน
+type RoomType struct {
+	ID               int64
+	AccommodationID  int64
+	Name             string
+	Capacity         int
+	BedConfiguration string
+	BasePrice        float64
+	Quantity         int
+	MinStay          int
+	CreatedAt        time.Time
+	UpdatedAt        time.Time
+}
+
+// CalendarDay คือค่าที่ override ของวันหนึ่ง; nil = ใช้ค่าของ RoomType
+type CalendarDay struct {
+	RoomTypeID int64
+	Date       time.Time
+	Price      *float64
+	Closed     bool // blackout date
+	MinStay    *int
+}
+
+// Stay คือการจองที่ยัง active ซึ่งกินห้องอยู่; RoomTypeID nil = จองทั้งที่พัก (ปิดทุกห้อง)
+type Stay struct {
+	RoomTypeID *int64
+	CheckIn    time.Time
+	CheckOut   time.Time
+}
+
+type Night struct {
+	Date      time.Time
+	Price     float64
+	Available int
+	Closed    bool
+}
+
+// Availability คือผลการตรวจห้องประเภทหนึ่งในช่วง [from, to)
+type Availability struct {
+	RoomType   RoomType
+	Nights     []Night
+	MinStay    int
+	Bookable   bool
+	Reason     string // เหตุผลเมื่อ Bookable = false
+	TotalPrice float64
+}
+
+const (
+	ReasonClosed   = "closed"
+	ReasonSoldOut  = "sold_out"
+	ReasonMinStay  = "min_stay"
+	ReasonCapacity = "capacity"
+)
+
+// Compute คำนวณราคาและจำนวนห้องว่างรายคืน
+// min stay ใช้ค่าของวันเข้าพัก (override ของ from ถ้ามี) ตามธรรมเนียมของ calendar ที่พัก
+func Compute(rt RoomType, days []CalendarDay, stays []Stay, from, to time.Time, guests int) Availability {
+	overrides := make(map[string]CalendarDay, len(days))
+	for _, d := range days {
+		if d.RoomTypeID == rt.ID {
+			overrides[d.Date.Format(time.DateOnly)] = d
+		}
+	}
+
+	av := Availability{RoomType: rt, MinStay: rt.MinStay, Bookable: true}
+	if d, ok := overrides[from.Format(time.DateOnly)]; ok && d.MinStay != nil {
+		av.MinStay = *d.MinStay
+	}
+
+	var total float64
+	for day := from; day.Before(to); day = day.AddDate(0, 0, 1) {
+		n := Night{Date: day, Price: rt.BasePrice, Available: rt.Quantity}
+		if d, ok := overrides[day.Format(time.DateOnly)]; ok {
+			if d.Price != nil {
+				n.Price = *d.Price
+			}
+			n.Closed = d.Closed
+		}
+		for _, s := range stays {
+			if day.Before(s.CheckIn) || !day.Before(s.CheckOut) {
+				continue
+			}
+			if s.RoomTypeID == nil {
+				n.Available = 0
+			} else if *s.RoomTypeID == rt.ID {
+				n.Available--
+			}
+		}
+		if n.Closed || n.Available < 0 {
+			n.Available = 0
+		}
+
+		switch {
+		case !av.Bookable:
+		case n.Closed:
+			av.Bookable, av.Reason = false, ReasonClosed
+		case n.Available == 0:
+			av.Bookable, av.Reason = false, ReasonSoldOut
+		}
+		total += n.Price
+		av.Nights = append(av.Nights, n)
+	}
+
+	switch {
+	case !av.Bookable:
+	case len(av.Nights) < av.MinStay:
+		av.Bookable, av.Reason = false, ReasonMinStay
+	case guests > rt.Capacity:
+		av.Bookable, av.Reason = false, ReasonCapacity
+	}
+	av.TotalPrice = math.Round(total*100) / 100
+	return av
+}
@@ -0,0 +1,188 @@
+package model
+
+import (
+	"slices"
+	"testing"
+	"time"
+)
+
+func date(s string) time.Time {
+	d, err := time.Parse(time.DateOnly, s)
+	if err != nil {
+		panic(err)
+	}
+	return d
+}
+
+func ptr[T any](v T) *T { return &v }
+
+func TestCompute(t *testing.T) {
+	rt := RoomType{ID: 1, Capacity: 2, BasePrice: 1000, Quantity: 2, MinStay: 1}
+	other := int64(9)
+
+	tests := []struct {
+		name          string
+		rt            RoomType
+		days          []CalendarDay
+		stays         []Stay
+		from, to      string
+		guests        int
+		wantBookable  bool
+		wantReason    string
+		wantMinStay   int
+		wantTotal     float64
+		wantPrices    []float64
+		wantAvailable []int
+	}{
+		{
+			name: "base price and full quantity", rt: rt,
+			from: "2026-11-01", to: "2026-11-03", guests: 2,
+			wantBookable: true, wantMinStay: 1, wantTotal: 2000,
+			wantPrices: []float64{1000, 1000}, wantAvailable: []int{2, 2},
+		},
+		{
+			name: "price override on one night", rt: rt,
+			days: []CalendarDay{{RoomTypeID: 1, Date: date("2026-11-02"), Price: ptr(1499.995)}},
+			from: "2026-11-01", to: "2026-11-03", guests: 1,
+			wantBookable: true, wantMinStay: 1, wantTotal: 2500,
+			wantPrices: []float64{1000, 1499.995}, wantAvailable: []int{2, 2},
+		},
+		{
+			name: "overrides of other room types are ignored", rt: rt,
+			days: []CalendarDay{{RoomTypeID: other, Date: date("2026-11-01"), Price: ptr(1.0), Closed: true, MinStay: ptr(5)}},
+			from: "2026-11-01", to: "2026-11-02", guests: 1,
+			wantBookable: true, wantMinStay: 1, wantTotal: 1000,
+			wantPrices: []float64{1000}, wantAvailable: []int{2},
+		},
+		{
+			name: "blackout night closes the stay", rt: rt,
+			days: []CalendarDay{{RoomTypeID: 1, Date: date("2026-11-02"), Closed: true}},
+			from: "2026-11-01", to: "2026-11-04", guests: 1,
+			wantBookable: false, wantReason: ReasonClosed, wantMinStay: 1, wantTotal: 3000,
+			wantPrices: []float64{1000, 1000, 1000}, wantAvailable: []int{2, 0, 2},
+		},
+		{
+			name: "bookings of this type reduce availability", rt: rt,
+			stays: []Stay{
+				{RoomTypeID: ptr(int64(1)), CheckIn: date("2026-10-30"), CheckOut: date("2026-11-02")},
+				{RoomTypeID: ptr(int64(1)), CheckIn: date("2026-11-02"), CheckOut: date("2026-11-03")},
+			},
+			from: "2026-11-01", to: "2026-11-04", guests: 1,
+			wantBookable: true, wantMinStay: 1, wantTotal: 3000,
+			wantPrices: []float64{1000, 1000, 1000}, wantAvailable: []int{1, 1, 2},
+		},
+		{
+			name: "bookings of other types do not count", rt: rt,
+			stays: []Stay{{RoomTypeID: &other, CheckIn: date("2026-11-01"), CheckOut: date("2026-11-02")}},
+			from:  "2026-11-01", to: "2026-11-02", guests: 1,
+			wantBookable: true, wantMinStay: 1, wantTotal: 1000,
+			wantPrices: []float64{1000}, wantAvailable: []int{2},
+		},
+		{
+			name: "check-out day is free again", rt: rt,
+			stays: []Stay{
+				{RoomTypeID: ptr(int64(1)), CheckIn: date("2026-10-25"), CheckOut: date("2026-11-01")},
+				{RoomTypeID: ptr(int64(1)), CheckIn: date("2026-10-25"), CheckOut: date("2026-11-01")},
+			},
+			from: "2026-11-01", to: "2026-11-02", guests: 1,
+			wantBookable: true, wantMinStay: 1, wantTotal: 1000,
+			wantPrices: []float64{1000}, wantAvailable: []int{2},
+		},
+		{
+			name: "sold out when every room is taken", rt: rt,
+			stays: []Stay{
+				{RoomTypeID: ptr(int64(1)), CheckIn: date("2026-11-02"), CheckOut: date("2026-11-03")},
+				{RoomTypeID: ptr(int64(1)), CheckIn: date("2026-11-02"), CheckOut: date("2026-11-03")},
+				{RoomTypeID: ptr(int64(1)), CheckIn: date("2026-11-02"), CheckOut: date("2026-11-03")},
+			},
+			from: "2026-11-01", to: "2026-11-03", guests: 1,
+			wantBookable: false, wantReason: ReasonSoldOut, wantMinStay: 1, wantTotal: 2000,
+			wantPrices: []float64{1000, 1000}, wantAvailable: []int{2, 0},
+		},
+		{
+			name: "whole-house stay zeroes every room type", rt: rt,
+			stays: []Stay{{CheckIn: date("2026-11-01"), CheckOut: date("2026-11-02")}},
+			from:  "2026-11-01", to: "2026-11-03", guests: 1,
+			wantBookable: false, wantReason: ReasonSoldOut, wantMinStay: 1, wantTotal: 2000,
+			wantPrices: []float64{1000, 1000}, wantAvailable: []int{0, 2},
+		},
+		{
+			name: "first closed night wins over later sold out", rt: rt,
+			days:  []CalendarDay{{RoomTypeID: 1, Date: date("2026-11-01"), Closed: true}},
+			stays: []Stay{{CheckIn: date("2026-11-02"), CheckOut: date("2026-11-03")}},
+			from:  "2026-11-01", to: "2026-11-03", guests: 1,
+			wantBookable: false, wantReason: ReasonClosed, wantMinStay: 1, wantTotal: 2000,
+			wantPrices: []float64{1000, 1000}, wantAvailable: []int{0, 0},
+		},
+		{
+			name: "room type min stay", rt: RoomType{ID: 1, Capacity: 2, BasePrice: 1000, Quantity: 1, MinStay: 3},
+			from: "2026-11-01", to: "2026-11-03", guests: 1,
+			wantBookable: false, wantReason: ReasonMinStay, wantMinStay: 3, wantTotal: 2000,
+			wantPrices: []float64{1000, 1000}, wantAvailable: []int{1, 1},
+		},
+		{
+			name: "min stay override on the first night applies", rt: rt,
+			days: []CalendarDay{{RoomTypeID: 1, Date: date("2026-11-01"), MinStay: ptr(3)}},
+			from: "2026-11-01", to: "2026-11-03", guests: 1,
+			wantBookable: false, wantReason: ReasonMinStay, wantMinStay: 3, wantTotal: 2000,
+			wantPrices: []float64{1000, 1000}, wantAvailable: []int{2, 2},
+		},
+		{
+			name: "min stay override on a later night is ignored", rt: rt,
+			days: []CalendarDay{{RoomTypeID: 1, Date: date("2026-11-02"), MinStay: ptr(5)}},
+			from: "2026-11-01", to: "2026-11-03", guests: 1,
+			wantBookable: true, wantMinStay: 1, wantTotal: 2000,
+			wantPrices: []float64{1000, 1000}, wantAvailable: []int{2, 2},
+		},
+		{
+			name: "too many guests", rt: rt,
+			from: "2026-11-01", to: "2026-11-02", guests: 3,
+			wantBookable: false, wantReason: ReasonCapacity, wantMinStay: 1, wantTotal: 1000,
+			wantPrices: []float64{1000}, wantAvailable: []int{2},
+		},
+		{
+			name: "sold out is reported before capacity", rt: rt,
+			stays: []Stay{{CheckIn: date("2026-11-01"), CheckOut: date("2026-11-02")}},
+			from:  "2026-11-01", to: "2026-11-02", guests: 3,
+			wantBookable: false, wantReason: ReasonSoldOut, wantMinStay: 1, wantTotal: 1000,
+			wantPrices: []float64{1000}, wantAvailable: []int{0},
+		},
+		{
+			name: "empty range", rt: rt,
+			from: "2026-11-01", to: "2026-11-01", guests: 1,
+			wantBookable: false, wantReason: ReasonMinStay, wantMinStay: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			av := Compute(tt.rt, tt.days, tt.stays, date(tt.from), date(tt.to), tt.guests)
+
+			if av.Bookable != tt.wantBookable || av.Reason != tt.wantReason {
+				t.Errorf("bookable = %v (%q), want %v (%q)", av.Bookable, av.Reason, tt.wantBookable, tt.wantReason)
+			}
+			if av.MinStay != tt.wantMinStay {
+				t.Errorf("min stay = %d, want %d", av.MinStay, tt.wantMinStay)
+			}
+			if av.TotalPrice != tt.wantTotal {
+				t.Errorf("total = %v, want %v", av.TotalPrice, tt.wantTotal)
+			}
+
+			var prices []float64
+			var available []int
+			for i, n := range av.Nights {
+				if want := date(tt.from).AddDate(0, 0, i); !n.Date.Equal(want) {
+					t.Errorf("night %d date = %s, want %s", i, n.Date.Format(time.DateOnly), want.Format(time.DateOnly))
+				}
+				prices = append(prices, n.Price)
+				available = append(available, n.Available)
+			}
+			if !slices.Equal(prices, tt.wantPrices) {
+				t.Errorf("prices = %v, want %v", prices, tt.wantPrices)
+			}
+			if !slices.Equal(available, tt.wantAvailable) {
+				t.Errorf("available = %v, want %v", available, tt.wantAvailable)
+			}
+		})
+	}
+}
@@ -0,0 +1,191 @@
+package repository
+
+import (
+	"context"
+	bookingModel "myapp/internal/booking/model"
+	"myapp/internal/database"
+	"myapp/internal/room/model"
+	"strings"
+	"time"
+)
+
+type RoomRepository interface {
+	ListByAccommodation(ctx context.Context, accommodationID int64) ([]model.RoomType, error)
+	GetByID(ctx context.Context, id int64) (model.RoomType, error)
+	Create(ctx context.Context, rt model.RoomType) (int64, error)
+	Update(ctx context.Context, rt model.RoomType) error
+	Delete(ctx context.Context, id int64) error
+	Calendar(ctx context.Context, roomTypeIDs []int64, from, to time.Time) ([]model.CalendarDay, error)
+	SetCalendar(ctx context.Context, roomTypeID int64, from, to time.Time, day model.CalendarDay) error
+	ClearCalendar(ctx context.Context, roomTypeID int64, from, to time.Time) error
+	// Stays คือการจองที่ยัง active ของที่พักซึ่งทับช่วง [from, to)
+	Stays(ctx context.Context, accommodationID int64, from, to time.Time) ([]model.Stay, error)
+}
+
+type roomRepo struct {
+	db *database.DB
+}
+
+func NewRoomRepository(db *database.DB) RoomRepository {
+	return &roomRepo{db: db}
+}
+
+const roomTypeColumns = "room_type_id, accommodation_id, name, capacity, bed_configuration, base_price, quantity, min_stay, created_at, updated_at"
+
+func scanRoomType(row interface{ Scan(...any) error }) (model.RoomType, error) {
+	var rt model.RoomType
+	err := row.Scan(&rt.ID, &rt.AccommodationID, &rt.Name, &rt.Capacity, &rt.BedConfiguration,
+		&rt.BasePrice, &rt.Quantity, &rt.MinStay, &rt.CreatedAt, &rt.UpdatedAt)
+	return rt, err
+}
+
+func (r *roomRepo) ListByAccommodation(ctx context.Context, accommodationID int64) ([]model.RoomType, error) {
+	ctx, cancel := r.db.WithTimeout(ctx)
+	defer cancel()
+
+	rows, err := r.db.Conn(ctx).QueryContext(ctx,
+		"SELECT "+roomTypeColumns+" FROM room_type WHERE accommodation_id = ? ORDER BY base_price, room_type_id", accommodationID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	list := []model.RoomType{}
+	for rows.Next() {
+		rt, err := scanRoomType(rows)
+		if err != nil {
+			return nil, err
+		}
+		list = append(list, rt)
+	}
+	return list, rows.Err()
+}
+
+func (r *roomRepo) GetByID(ctx context.Context, id int64) (model.RoomType, error) {
+	ctx, cancel := r.db.WithTimeout(ctx)
+	defer cancel()
+
+	rt, err := scanRoomType(r.db.Conn(ctx).QueryRowContext(ctx, "SELECT "+roomTypeColumns+" FROM room_type WHERE room_type_id = ?", id))
+	return rt, database.NotFound(err, "Room type not found")
+}
+
+func (r *roomRepo) Create(ctx context.Context, rt model.RoomType) (int64, error) {
+	ctx, cancel := r.db.WithTimeout(ctx)
+	defer cancel()
+
+	res, err := r.db.Conn(ctx).ExecContext(ctx, `
+		INSERT INTO room_type (accommodation_id, name, capacity, bed_configuration, base_price, quantity, min_stay)
+		VALUES (?, ?, ?, ?, ?, ?, ?)`,
+		rt.AccommodationID, rt.Name, rt.Capacity, rt.BedConfiguration, rt.BasePrice, rt.Quantity, rt.MinStay)
+	if err != nil {
+		return 0, err
+	}
+	return res.LastInsertId()
+}
+
+func (r *roomRepo) Update(ctx context.Context, rt model.RoomType) error {
+	ctx, cancel := r.db.WithTimeout(ctx)
+	defer cancel()
+
+	_, err := r.db.Conn(ctx).ExecContext(ctx, `
+		UPDATE room_type
+		SET name=?, capacity=?, bed_configuration=?, base_price=?, quantity=?, min_stay=?
+		WHERE room_type_id=?`,
+		rt.Name, rt.Capacity, rt.BedConfiguration, rt.BasePrice, rt.Quantity, rt.MinStay, rt.ID)
+	return err
+}
+
+func (r *roomRepo) Delete(ctx context.Context, id int64) error {
+	ctx, cancel := r.db.WithTimeout(ctx)
+	defer cancel()
+
+	res, err := r.db.Conn(ctx).ExecContext(ctx, "DELETE FROM room_type WHERE room_type_id=?", id)
+	return database.RequireAffected(res, err, "Room type not found")
+}
+
+func (r *roomRepo) Calendar(ctx context.Context, roomTypeIDs []int64, from, to time.Time) ([]model.CalendarDay, error) {
+	ctx, cancel := r.db.WithTimeout(ctx)
+	defer cancel()
+
+	ids := make([]any, 0, len(roomTypeIDs))
+	for _, id := range roomTypeIDs {
+		ids = append(ids, id)
+	}
+	q := database.Select("room_calendar", "room_type_id", "date", "price", "closed", "min_stay").
+		WhereIn("room_type_id", ids...).
+		Where("date >= ? AND date < ?", from.Format(time.DateOnly), to.Format(time.DateOnly))
+
+	query, args := q.SQL()
+	rows, err := r.db.Conn(ctx).QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var days []model.CalendarDay
+	for rows.Next() {
+		var d model.CalendarDay
+		if err := rows.Scan(&d.RoomTypeID, &d.Date, &d.Price, &d.Closed, &d.MinStay); err != nil {
+			return nil, err
+		}
+		days = append(days, d)
+	}
+	return days, rows.Err()
+}
+
+// SetCalendar เขียน override เดียวกันให้ทุกวันในช่วง [from, to) ด้วย statement เดียว
+func (r *roomRepo) SetCalendar(ctx context.Context, roomTypeID int64, from, to time.Time, day model.CalendarDay) error {
+	ctx, cancel := r.db.WithTimeout(ctx)
+	defer cancel()
+
+	var (
+		values []string
+		args   []any
+	)
+	for d := from; d.Before(to); d = d.AddDate(0, 0, 1) {
+		values = append(values, "(?, ?, ?, ?, ?)")
+		args = append(args, roomTypeID, d.Format(time.DateOnly), day.Price, day.Closed, day.MinStay)
+	}
+	if len(values) == 0 {
+		return nil
+	}
+
+	_, err := r.db.Conn(ctx).ExecContext(ctx, `
+		INSERT INTO room_calendar (room_type_id, date, price, closed, min_stay)
+		VALUES `+strings.Join(values, ", ")+`
+		ON DUPLICATE KEY UPDATE price = VALUES(price), closed = VALUES(closed), min_stay = VALUES(min_stay)`, args...)
+	return err
+}
+
+func (r *roomRepo) ClearCalendar(ctx context.Context, roomTypeID int64, from, to time.Time) error {
+	ctx, cancel := r.db.WithTimeout(ctx)
+	defer cancel()
+
+	_, err := r.db.Conn(ctx).ExecContext(ctx, "DELETE FROM room_calendar WHERE room_type_id = ? AND date >= ? AND date < ?",
+		roomTypeID, from.Format(time.DateOnly), to.Format(time.DateOnly))
+	return err
+}
+
+func (r *roomRepo) Stays(ctx context.Context, accommodationID int64, from, to time.Time) ([]model.Stay, error) {
+	ctx, cancel := r.db.WithTimeout(ctx)
+	defer cancel()
+
+	rows, err := r.db.Conn(ctx).QueryContext(ctx, `
+		SELECT room_type_id, check_in, check_out FROM booking
+		WHERE accommodation_id = ? AND status IN (?, ?) AND check_in < ? AND check_out > ?`,
+		accommodationID, bookingModel.StatusPending, bookingModel.StatusConfirmed, to.Format(time.DateOnly), from.Format(time.DateOnly))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var stays []model.Stay
+	for rows.Next() {
+		var s model.Stay
+		if err := rows.Scan(&s.RoomTypeID, &s.CheckIn, &s.CheckOut); err != nil {
+			return nil, err
+		}
+		stays = append(stays, s)
+	}
+	return stays, rows.Err()
+}
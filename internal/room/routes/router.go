@@ -0,0 +1,72 @@
+package router
+
+import (
+	"net/http"
+
+	"github.com/gorilla/mux"
+
+	accRepo "myapp/internal/accommodation/repository"
+	"myapp/internal/auth"
+	"myapp/internal/database"
+	"myapp/internal/httpx"
+	roomHandler "myapp/internal/room/handler"
+	roomRepo "myapp/internal/room/repository"
+	roomUsecase "myapp/internal/room/usecase"
+)
+
+type Module struct {
+	handler            *roomHandler.RoomHandler
+	accommodationOwner auth.OwnerFunc
+	roomTypeOwner      auth.OwnerFunc
+	tokens             *auth.TokenManager
+}
+
+func NewModule(db *database.DB, tokens *auth.TokenManager) *Module {
+	rRepo := roomRepo.NewRoomRepository(db)
+	accommodations := accRepo.NewAccommodationRepository(db)
+	rUC := roomUsecase.NewRoomUsecase(rRepo, accommodations)
+	return &Module{
+		handler: roomHandler.NewRoomHandler(rUC),
+		// ✅ เจ้าของที่พักจาก path {id} ของ /accommodations/{id}/room-types
+		accommodationOwner: func(r *http.Request) (int64, error) {
+			id, err := httpx.PathID(r, "id")
+			if err != nil {
+				return 0, err
+			}
+			return accommodations.OwnerOf(r.Context(), id)
+		},
+		// ✅ เจ้าของที่พักของประเภทห้องจาก path {id} ของ /room-types/{id}
+		roomTypeOwner: func(r *http.Request) (int64, error) {
+			id, err := httpx.PathID(r, "id")
+			if err != nil {
+				return 0, err
+			}
+			rt, err := rRepo.GetByID(r.Context(), id)
+			if err != nil {
+				return 0, err
+			}
+			return accommodations.OwnerOf(r.Context(), rt.AccommodationID)
+		},
+		tokens: tokens,
+	}
+}
+
+func (m *Module) Name() string { return "room" }
+
+func (m *Module) RegisterRoutes(r *mux.Router) {
+	rH := m.handler
+
+	// ✅ ประเภทห้องและ availability ของที่พัก
+	r.HandleFunc("/accommodations/{id:[0-9]+}/room-types", rH.ListByAccommodation).Methods("GET")
+	r.HandleFunc("/accommodations/{id:[0-9]+}/availability", rH.Availability).Methods("GET")
+	r.HandleFunc("/room-types/{id:[0-9]+}", rH.GetByID).Methods("GET")
+
+	// ✅ เพิ่ม/แก้ไข/ลบ และตั้ง calendar ได้เฉพาะเจ้าของที่พักหรือ admin
+	protected := r.NewRoute().Subrouter()
+	protected.Use(auth.Middleware(m.tokens))
+
+	protected.Handle("/accommodations/{id:[0-9]+}/room-types", auth.AuthorizeOwner(m.accommodationOwner, rH.Create)).Methods("POST")
+	protected.Handle("/room-types/{id:[0-9]+}", auth.AuthorizeOwner(m.roomTypeOwner, rH.Update)).Methods("PUT")
+	protected.Handle("/room-types/{id:[0-9]+}", auth.AuthorizeOwner(m.roomTypeOwner, rH.Delete)).Methods("DELETE")
+	protected.Handle("/room-types/{id:[0-9]+}/calendar", auth.AuthorizeOwner(m.roomTypeOwner, rH.SetCalendar)).Methods("PUT")
+}
@@ -0,0 +1,102 @@
+package usecase
+
+import (
+	"context"
+	accRepo "myapp/internal/accommodation/repository"
+	"myapp/internal/room/model"
+	"myapp/internal/room/repository"
+	"time"
+)
+
+type RoomUsecase interface {
+	ListByAccommodation(ctx context.Context, accommodationID int64) ([]model.RoomType, error)
+	GetByID(ctx context.Context, id int64) (model.RoomType, error)
+	Create(ctx context.Context, rt model.RoomType) (model.RoomType, error)
+	Update(ctx context.Context, rt model.RoomType) error
+	Delete(ctx context.Context, id int64) error
+	// SetCalendar เขียน override ของช่วง [from, to); day ที่ไม่มี override ใดเลยคือการล้างช่วงนั้นกลับเป็นค่าปกติ
+	SetCalendar(ctx context.Context, roomTypeID int64, from, to time.Time, day model.CalendarDay) error
+	Availability(ctx context.Context, accommodationID int64, from, to time.Time, guests int) ([]model.Availability, error)
+}
+
+type roomUsecase struct {
+	repo           repository.RoomRepository
+	accommodations accRepo.AccommodationRepository
+}
+
+func NewRoomUsecase(r repository.RoomRepository, accommodations accRepo.AccommodationRepository) RoomUsecase {
+	return &roomUsecase{repo: r, accommodations: accommodations}
+}
+
+func (u *roomUsecase) ListByAccommodation(ctx context.Context, accommodationID int64) ([]model.RoomType, error) {
+	if _, err := u.accommodations.GetByID(ctx, accommodationID); err != nil {
+		return nil, err
+	}
+	return u.repo.ListByAccommodation(ctx, accommodationID)
+}
+
+func (u *roomUsecase) GetByID(ctx context.Context, id int64) (model.RoomType, error) {
+	return u.repo.GetByID(ctx, id)
+}
+
+func (u *roomUsecase) Create(ctx context.Context, rt model.RoomType) (model.RoomType, error) {
+	if _, err := u.accommodations.GetByID(ctx, rt.AccommodationID); err != nil {
+		return model.RoomType{}, err
+	}
+	id, err := u.repo.Create(ctx, rt)
+	if err != nil {
+		return model.RoomType{}, err
+	}
+	return u.repo.GetByID(ctx, id)
+}
+
+func (u *roomUsecase) Update(ctx context.Context, rt model.RoomType) error {
+	if _, err := u.repo.GetByID(ctx, rt.ID); err != nil {
+		return err
+	}
+	return u.repo.Update(ctx, rt)
+}
+
+func (u *roomUsecase) Delete(ctx context.Context, id int64) error {
+	return u.repo.Delete(ctx, id)
+}
+
+func (u *roomUsecase) SetCalendar(ctx context.Context, roomTypeID int64, from, to time.Time, day model.CalendarDay) error {
+	if _, err := u.repo.GetByID(ctx, roomTypeID); err != nil {
+		return err
+	}
+	if day.Price == nil && !day.Closed && day.MinStay == nil {
+		return u.repo.ClearCalendar(ctx, roomTypeID, from, to)
+	}
+	return u.repo.SetCalendar(ctx, roomTypeID, from, to, day)
+}
+
+// Availability คืนทุกประเภทห้องของที่พักพร้อมราคา/ห้องว่างรายคืน ในช่วง [from, to)
+func (u *roomUsecase) Availability(ctx context.Context, accommodationID int64, from, to time.Time, guests int) ([]model.Availability, error) {
+	if _, err := u.accommodations.GetByID(ctx, accommodationID); err != nil {
+		return nil, err
+	}
+	types, err := u.repo.ListByAccommodation(ctx, accommodationID)
+	if err != nil || len(types) == 0 {
+		return []model.Availability{}, err
+	}
+
+	ids := make([]int64, 0, len(types))
+	for _, rt := range types {
+		ids = append(ids, rt.ID)
+	}
+	days, err := u.repo.Calendar(ctx, ids, from, to)
+	if err != nil {
+		return nil, err
+	}
+	stays, err := u.repo.Stays(ctx, accommodationID, from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	list := make([]model.Availability, 0, len(types))
+	for _, rt := range types {
+		list = append(list, model.Compute(rt, days, stays, from, to, guests))
+	}
+	return list, nil
+}
@@ -0,0 +1,232 @@
+package scheduler
+
+import (
+	"context"
+	"errors"
+	"expvar"
+	"fmt"
+	"log/slog"
+	"math/rand/v2"
+	"runtime/debug"
+	"sync"
+	"time"
+)
+
+// Job คืองานที่รันซ้ำทุก Interval (บวก jitter สุ่ม 0..Jitter เพื่อไม่ให้หลาย instance รันพร้อมกัน)
+// Run คืนจำนวนรายการที่ประมวลผล (เช่นจำนวนแถวที่ลบ) เพื่อใช้ใน log และ metrics
+type Job struct {
+	Name     string
+	Interval time.Duration
+	Jitter   time.Duration
+	Timeout  time.Duration
+	Run      func(ctx context.Context) (int64, error)
+}
+
+// Status คือผลการรันล่าสุดของ job
+type Status struct {
+	Name         string        `json:"name"`
+	Running      bool          `json:"running"`
+	Runs         int64         `json:"runs"`
+	Failures     int64         `json:"failures"`
+	Skipped      int64         `json:"skipped"`
+	Processed    int64         `json:"processed"`
+	LastStarted  time.Time     `json:"last_started,omitempty"`
+	LastFinished time.Time     `json:"last_finished,omitempty"`
+	LastDuration time.Duration `json:"last_duration"`
+	LastCount    int64         `json:"last_count"`
+	LastError    string        `json:"last_error,omitempty"`
+}
+
+// metrics ถูก publish ที่ /debug/vars ภายใต้ key "scheduler"
+var metrics = expvar.NewMap("scheduler")
+
+// Scheduler รัน job แต่ละตัวใน goroutine ของตัวเอง; job เดียวกันจะไม่รันซ้อนกัน
+type Scheduler struct {
+	mu      sync.Mutex
+	jobs    []*entry
+	started bool
+	cancel  context.CancelFunc
+	wg      sync.WaitGroup
+}
+
+type entry struct {
+	job Job
+
+	mu      sync.Mutex
+	running bool
+	status  Status
+}
+
+func New() *Scheduler {
+	return &Scheduler{}
+}
+
+// Add ลงทะเบียน job; ต้องเรียกก่อน Start
+func (s *Scheduler) Add(jobs ...Job) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.started {
+		return errors.New("scheduler: cannot add jobs after start")
+	}
+	for _, j := range jobs {
+		if j.Name == "" || j.Run == nil || j.Interval <= 0 {
+			return fmt.Errorf("scheduler: job %q needs a name, a run func and a positive interval", j.Name)
+		}
+		for _, e := range s.jobs {
+			if e.job.Name == j.Name {
+				return fmt.Errorf("scheduler: duplicate job %q", j.Name)
+			}
+		}
+		s.jobs = append(s.jobs, &entry{job: j, status: Status{Name: j.Name}})
+	}
+	return nil
+}
+
+// Start เริ่มรันทุก job จนกว่าจะเรียก Stop หรือ ctx ถูกยกเลิก
+func (s *Scheduler) Start(ctx context.Context) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.started {
+		return
+	}
+	s.started = true
+
+	ctx, s.cancel = context.WithCancel(ctx)
+	for _, e := range s.jobs {
+		s.wg.Add(1)
+		go s.loop(ctx, e)
+		slog.Info("job scheduled", "job", e.job.Name, "interval", e.job.Interval)
+	}
+}
+
+// Stop หยุดตั้งเวลา job ใหม่ ยกเลิก job ที่กำลังรัน แล้วรอจนจบหรือ ctx หมดเวลา
+func (s *Scheduler) Stop(ctx context.Context) error {
+	s.mu.Lock()
+	cancel := s.cancel
+	s.mu.Unlock()
+
+	if cancel == nil {
+		return nil
+	}
+	cancel()
+
+	done := make(chan struct{})
+	go func() {
+		s.wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		slog.Info("scheduler stopped")
+		return nil
+	case <-ctx.Done():
+		return fmt.Errorf("scheduler: jobs did not stop in time: %w", ctx.Err())
+	}
+}
+
+// Statuses คืนสถานะล่าสุดของทุก job ตามลำดับที่ลงทะเบียน
+func (s *Scheduler) Statuses() []Status {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	list := make([]Status, 0, len(s.jobs))
+	for _, e := range s.jobs {
+		e.mu.Lock()
+		st := e.status
+		st.Running = e.running
+		e.mu.Unlock()
+		list = append(list, st)
+	}
+	return list
+}
+
+func (s *Scheduler) loop(ctx context.Context, e *entry) {
+	defer s.wg.Done()
+
+	timer := time.NewTimer(e.next())
+	defer timer.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-timer.C:
+			// รันใน goroutine แยกเพื่อให้ตั้งเวลารอบถัดไปได้ตรง ถ้ารอบก่อนยังไม่จบจะถูกข้าม
+			s.wg.Add(1)
+			go func() {
+				defer s.wg.Done()
+				e.execute(ctx)
+			}()
+			timer.Reset(e.next())
+		}
+	}
+}
+
+func (e *entry) next() time.Duration {
+	d := e.job.Interval
+	if e.job.Jitter > 0 {
+		d += rand.N(e.job.Jitter)
+	}
+	return d
+}
+
+func (e *entry) execute(ctx context.Context) {
+	e.mu.Lock()
+	if e.running {
+		e.status.Skipped++
+		e.mu.Unlock()
+		metrics.Add(e.job.Name+".skipped", 1)
+		slog.Warn("job skipped: previous run still in progress", "job", e.job.Name)
+		return
+	}
+	e.running = true
+	started := time.Now()
+	e.status.LastStarted = started
+	e.mu.Unlock()
+
+	if e.job.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, e.job.Timeout)
+		defer cancel()
+	}
+
+	count, err := e.safeRun(ctx)
+	elapsed := time.Since(started)
+
+	e.mu.Lock()
+	e.running = false
+	e.status.Runs++
+	e.status.LastFinished = time.Now()
+	e.status.LastDuration = elapsed
+	e.status.LastCount = count
+	e.status.Processed += count
+	e.status.LastError = ""
+	if err != nil {
+		e.status.Failures++
+		e.status.LastError = err.Error()
+	}
+	e.mu.Unlock()
+
+	metrics.Add(e.job.Name+".runs", 1)
+	metrics.Add(e.job.Name+".processed", count)
+	if err != nil {
+		metrics.Add(e.job.Name+".failures", 1)
+		slog.Error("job failed", "job", e.job.Name, "duration", elapsed, "count", count, "error", err)
+		return
+	}
+	slog.Info("job finished", "job", e.job.Name, "duration", elapsed, "count", count)
+}
+
+// safeRun กัน panic ของ job ไม่ให้ล้มทั้ง process
+func (e *entry) safeRun(ctx context.Context) (n int64, err error) {
+	defer func() {
+		if rec := recover(); rec != nil {
+			slog.Error("job panicked", "job", e.job.Name, "panic", rec, "stack", string(debug.Stack()))
+			err = fmt.Errorf("panic: %v", rec)
+		}
+	}()
+	return e.job.Run(ctx)
+}
@@ -0,0 +1,58 @@
+package storage
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"myapp/internal/apperr"
+	"myapp/internal/httpx"
+)
+
+// FilesPath คือ path ที่ app เสิร์ฟ object ผ่าน Handler และเป็น URL default ของ local driver
+const FilesPath = "/files"
+
+// Handler เสิร์ฟ object ของ s ตาม path หลัง prefix เช่น /files/users/3f9a...c1.jpg
+// ใช้ได้กับทุก backend: local driver ใช้เป็นทางเปิดไฟล์หลัก ส่วน S3 ใช้ proxy bucket ที่ไม่เปิด public ได้
+func Handler(s Storage, prefix string) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		key := strings.TrimPrefix(r.URL.Path, strings.TrimRight(prefix, "/")+"/")
+		if !ValidKey(key) {
+			httpx.WriteError(w, r, apperr.NotFound("File not found"))
+			return
+		}
+
+		obj, err := s.Get(r.Context(), key)
+		if errors.Is(err, ErrNotFound) {
+			httpx.WriteError(w, r, apperr.NotFound("File not found"))
+			return
+		}
+		if err != nil {
+			httpx.WriteError(w, r, err)
+			return
+		}
+		defer obj.Body.Close()
+
+		// ✅ ชื่อไฟล์เป็นชื่อสุ่มที่ไม่ถูกเขียนทับ จึง cache ได้นาน; nosniff กัน browser เดาชนิดไฟล์เอง
+		w.Header().Set("Content-Type", obj.ContentType)
+		w.Header().Set("X-Content-Type-Options", "nosniff")
+		w.Header().Set("Cache-Control", "public, max-age=86400")
+
+		if rs, ok := obj.Body.(io.ReadSeeker); ok {
+			http.ServeContent(w, r, key, obj.ModTime, rs)
+			return
+		}
+		if obj.Size >= 0 {
+			w.Header().Set("Content-Length", strconv.FormatInt(obj.Size, 10))
+		}
+		if !obj.ModTime.IsZero() {
+			w.Header().Set("Last-Modified", obj.ModTime.UTC().Format(http.TimeFormat))
+		}
+		if r.Method == http.MethodHead {
+			return
+		}
+		io.Copy(w, obj.Body)
+	})
+}
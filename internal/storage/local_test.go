@@ -0,0 +1,128 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestLocalRoundTrip(t *testing.T) {
+	ctx := context.Background()
+	root := t.TempDir()
+	l := NewLocal(root, FilesPath)
+	key := "users/3f9a.jpg"
+
+	if err := l.Put(ctx, key, strings.NewReader("first"), 5, "image/jpeg"); err != nil {
+		t.Fatalf("Put error = %v", err)
+	}
+	if err := l.Put(ctx, key, strings.NewReader("second"), 6, "image/jpeg"); err != nil {
+		t.Fatalf("Put (overwrite) error = %v", err)
+	}
+
+	obj, err := l.Get(ctx, key)
+	if err != nil {
+		t.Fatalf("Get error = %v", err)
+	}
+	body, _ := io.ReadAll(obj.Body)
+	obj.Body.Close()
+	if string(body) != "second" || obj.Size != 6 || obj.ContentType != "image/jpeg" {
+		t.Errorf("Get = %q (size %d, %s), want %q (size 6, image/jpeg)", body, obj.Size, obj.ContentType, "second")
+	}
+
+	// ไม่มี temp file ค้างอยู่ข้างไฟล์จริง
+	entries, _ := os.ReadDir(filepath.Join(root, "users"))
+	if len(entries) != 1 {
+		t.Errorf("users/ has %d entries, want 1", len(entries))
+	}
+
+	if err := l.Delete(ctx, key); err != nil {
+		t.Fatalf("Delete error = %v", err)
+	}
+	if _, err := l.Get(ctx, key); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Get after Delete error = %v, want ErrNotFound", err)
+	}
+	if err := l.Delete(ctx, key); err != nil {
+		t.Errorf("Delete of missing key error = %v, want nil", err)
+	}
+}
+
+func TestLocalGetDirectoryIsNotFound(t *testing.T) {
+	l := NewLocal(t.TempDir(), FilesPath)
+	if err := l.Put(context.Background(), "users/a.png", strings.NewReader("x"), 1, "image/png"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := l.Get(context.Background(), "users"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Get(directory) error = %v, want ErrNotFound", err)
+	}
+}
+
+func TestLocalRejectsInvalidKeys(t *testing.T) {
+	ctx := context.Background()
+	parent := t.TempDir()
+	root := filepath.Join(parent, "files")
+	l := NewLocal(root, FilesPath)
+
+	keys := []string{"", "../escape.jpg", "users/../../escape.jpg", "/etc/passwd", "users//a.jpg", "users/./a.jpg", `users\a.jpg`, "users/a\x00.jpg", "users/"}
+	for _, key := range keys {
+		if err := l.Put(ctx, key, strings.NewReader("x"), 1, "image/jpeg"); err == nil {
+			t.Errorf("Put(%q) error = nil, want invalid key", key)
+		}
+		if _, err := l.Get(ctx, key); err == nil || errors.Is(err, ErrNotFound) {
+			t.Errorf("Get(%q) error = %v, want invalid key", key, err)
+		}
+		if err := l.Delete(ctx, key); err == nil {
+			t.Errorf("Delete(%q) error = nil, want invalid key", key)
+		}
+	}
+	if _, err := os.Stat(filepath.Join(parent, "escape.jpg")); !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("file written outside root: %v", err)
+	}
+}
+
+func TestValidKey(t *testing.T) {
+	tests := []struct {
+		key  string
+		want bool
+	}{
+		{"users/3f9a.jpg", true},
+		{"accommodations/7/a b.png", true},
+		{"a", true},
+		{"..a/b..", true},
+		{"", false},
+		{"/users/a.jpg", false},
+		{"users/a.jpg/", false},
+		{"users//a.jpg", false},
+		{"users/./a.jpg", false},
+		{"users/../a.jpg", false},
+		{`users\a.jpg`, false},
+		{"users/a\n.jpg", false},
+		{"users/a\x7f.jpg", false},
+		{strings.Repeat("a", 1025), false},
+	}
+	for _, tt := range tests {
+		if got := ValidKey(tt.key); got != tt.want {
+			t.Errorf("ValidKey(%q) = %v, want %v", tt.key, got, tt.want)
+		}
+	}
+}
+
+func TestURL(t *testing.T) {
+	tests := []struct {
+		base, key string
+		want      string
+	}{
+		{FilesPath, "users/a.jpg", "/files/users/a.jpg"},
+		{"https://cdn.example.com/", "users/a b+c.jpg", "https://cdn.example.com/users/a%20b%2Bc.jpg"},
+		{FilesPath, "", ""},
+		{FilesPath, "https://example.com/legacy.jpg", "https://example.com/legacy.jpg"},
+	}
+	for _, tt := range tests {
+		if got := NewLocal("", tt.base).URL(tt.key); got != tt.want {
+			t.Errorf("URL(%q) with base %q = %q, want %q", tt.key, tt.base, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,195 @@
+package storage
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"myapp/internal/config"
+)
+
+// s3Timeout คือเวลาสูงสุดของ request หนึ่งครั้งไปที่ S3 (รวมอ่าน body)
+const s3Timeout = 60 * time.Second
+
+// S3 เก็บ object ใน bucket ของ S3 หรือ service ที่ใช้ API เดียวกัน (MinIO, R2, ...) โดยเซ็น request ด้วย SigV4
+type S3 struct {
+	endpoint  *url.URL
+	region    string
+	bucket    string
+	accessKey string
+	secretKey string
+	pathStyle bool
+	baseURL   string
+	client    *http.Client
+	now       func() time.Time
+}
+
+// NewS3 สร้าง client ของ bucket; publicURL ว่าง = ใช้ URL ของ bucket ตรง ๆ (bucket ต้องเปิดอ่านแบบ public)
+func NewS3(cfg config.S3Config, publicURL string) (*S3, error) {
+	endpoint, err := url.Parse(strings.TrimRight(cfg.Endpoint, "/"))
+	if err != nil || (endpoint.Scheme != "http" && endpoint.Scheme != "https") || endpoint.Host == "" {
+		return nil, fmt.Errorf("storage: S3_ENDPOINT must be an http(s) URL: %q", cfg.Endpoint)
+	}
+
+	s := &S3{
+		endpoint:  endpoint,
+		region:    cfg.Region,
+		bucket:    cfg.Bucket,
+		accessKey: cfg.AccessKey,
+		secretKey: cfg.SecretKey,
+		pathStyle: cfg.PathStyle,
+		baseURL:   publicURL,
+		client:    &http.Client{Timeout: s3Timeout},
+		now:       time.Now,
+	}
+	if s.baseURL == "" {
+		s.baseURL = s.bucketURL().String()
+	}
+	return s, nil
+}
+
+// Put อัปโหลดทั้งก้อนด้วย PUT Object; body ที่ seek ได้จะถูก hash ใส่ในลายเซ็น ที่เหลือส่งแบบ UNSIGNED-PAYLOAD
+func (s *S3) Put(ctx context.Context, key string, body io.Reader, size int64, contentType string) error {
+	if err := checkKey(key); err != nil {
+		return err
+	}
+
+	payloadHash := unsignedPayload
+	if rs, ok := body.(io.ReadSeeker); ok {
+		h := sha256.New()
+		if _, err := io.Copy(h, rs); err != nil {
+			return fmt.Errorf("hash %s: %w", key, err)
+		}
+		if _, err := rs.Seek(0, io.SeekStart); err != nil {
+			return fmt.Errorf("hash %s: %w", key, err)
+		}
+		payloadHash = hex.EncodeToString(h.Sum(nil))
+	}
+
+	req, err := s.newRequest(ctx, http.MethodPut, key, body)
+	if err != nil {
+		return err
+	}
+	req.ContentLength = size
+	if contentType != "" {
+		req.Header.Set("Content-Type", contentType)
+	}
+
+	resp, err := s.do(req, payloadHash)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	return s.check(resp, key)
+}
+
+func (s *S3) Get(ctx context.Context, key string) (Object, error) {
+	if err := checkKey(key); err != nil {
+		return Object{}, err
+	}
+	req, err := s.newRequest(ctx, http.MethodGet, key, nil)
+	if err != nil {
+		return Object{}, err
+	}
+	resp, err := s.do(req, emptyPayloadHash)
+	if err != nil {
+		return Object{}, err
+	}
+	if err := s.check(resp, key); err != nil {
+		resp.Body.Close()
+		return Object{}, err
+	}
+
+	obj := Object{Body: resp.Body, ContentType: resp.Header.Get("Content-Type"), Size: resp.ContentLength}
+	if obj.ContentType == "" {
+		obj.ContentType = "application/octet-stream"
+	}
+	if t, err := http.ParseTime(resp.Header.Get("Last-Modified")); err == nil {
+		obj.ModTime = t
+	}
+	return obj, nil
+}
+
+func (s *S3) Delete(ctx context.Context, key string) error {
+	if err := checkKey(key); err != nil {
+		return err
+	}
+	req, err := s.newRequest(ctx, http.MethodDelete, key, nil)
+	if err != nil {
+		return err
+	}
+	resp, err := s.do(req, emptyPayloadHash)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	// S3 ตอบ 204 แม้ไม่มี key อยู่ แต่บาง service ตอบ 404
+	if err := s.check(resp, key); err != nil && !errors.Is(err, ErrNotFound) {
+		return err
+	}
+	return nil
+}
+
+func (s *S3) URL(key string) string {
+	return publicURL(s.baseURL, key)
+}
+
+// bucketURL คือ URL ของ bucket: path-style (endpoint/bucket) สำหรับ MinIO หรือ virtual-hosted (bucket.endpoint) สำหรับ AWS
+func (s *S3) bucketURL() *url.URL {
+	u := *s.endpoint
+	if s.pathStyle {
+		u.Path += "/" + s.bucket
+		u.RawPath = ""
+	} else {
+		u.Host = s.bucket + "." + u.Host
+	}
+	return &u
+}
+
+func (s *S3) newRequest(ctx context.Context, method, key string, body io.Reader) (*http.Request, error) {
+	u := s.bucketURL()
+	u.Path += "/" + key
+	u.RawPath = escapeKey(u.Path) // ให้ path ที่ส่งจริงตรงกับที่เซ็น (EscapedPath จะใช้ค่านี้)
+	return http.NewRequestWithContext(ctx, method, u.String(), body)
+}
+
+func (s *S3) do(req *http.Request, payloadHash string) (*http.Response, error) {
+	sign(req, s.accessKey, s.secretKey, s.region, payloadHash, s.now())
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("s3 %s %s: %w", req.Method, req.URL.Path, err)
+	}
+	return resp, nil
+}
+
+// s3Error คือ body แบบ XML ที่ S3 ตอบเมื่อ error
+type s3Error struct {
+	Code    string `xml:"Code"`
+	Message string `xml:"Message"`
+}
+
+// check แปลง response ที่ไม่ใช่ 2xx เป็น error; 404 (NoSuchKey) คือ ErrNotFound
+func (s *S3) check(resp *http.Response, key string) error {
+	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		return nil
+	}
+	if resp.StatusCode == http.StatusNotFound {
+		return ErrNotFound
+	}
+
+	var e s3Error
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, 4<<10))
+	if xml.Unmarshal(body, &e) != nil || e.Code == "" {
+		e.Code = resp.Status
+	}
+	return fmt.Errorf("s3 %s %s: %s: %s", resp.Request.Method, key, e.Code, e.Message)
+}
@@ -0,0 +1,223 @@
+package storage
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"myapp/internal/config"
+)
+
+// fakeS3 คือ S3 จำลองแบบ path-style ที่ตรวจลายเซ็น SigV4 ของทุก request ก่อนตอบ
+type fakeS3 struct {
+	bucket  string
+	secret  string
+	mu      sync.Mutex
+	objects map[string]fakeObject
+	paths   []string // EscapedPath ที่ได้รับ ตามลำดับ
+}
+
+type fakeObject struct {
+	body        []byte
+	contentType string
+}
+
+func (f *fakeS3) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.paths = append(f.paths, r.URL.EscapedPath())
+
+	body, _ := io.ReadAll(r.Body)
+	if !f.verify(r, body) {
+		w.WriteHeader(http.StatusForbidden)
+		io.WriteString(w, `<Error><Code>SignatureDoesNotMatch</Code><Message>bad signature</Message></Error>`)
+		return
+	}
+
+	prefix := "/" + f.bucket + "/"
+	if !strings.HasPrefix(r.URL.Path, prefix) {
+		w.WriteHeader(http.StatusNotFound)
+		io.WriteString(w, `<Error><Code>NoSuchBucket</Code></Error>`)
+		return
+	}
+	key := strings.TrimPrefix(r.URL.Path, prefix)
+
+	switch r.Method {
+	case http.MethodPut:
+		f.objects[key] = fakeObject{body: body, contentType: r.Header.Get("Content-Type")}
+		w.WriteHeader(http.StatusOK)
+	case http.MethodGet:
+		obj, ok := f.objects[key]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			io.WriteString(w, `<Error><Code>NoSuchKey</Code></Error>`)
+			return
+		}
+		w.Header().Set("Content-Type", obj.contentType)
+		w.Header().Set("Last-Modified", "Fri, 24 May 2013 00:00:00 GMT")
+		w.Write(obj.body)
+	case http.MethodDelete:
+		delete(f.objects, key)
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		w.WriteHeader(http.StatusMethodNotAllowed)
+	}
+}
+
+// verify เซ็น request ที่ได้รับซ้ำด้วย header ชุดเดียวกันแล้วเทียบ Authorization
+func (f *fakeS3) verify(r *http.Request, body []byte) bool {
+	auth := r.Header.Get("Authorization")
+	i := strings.Index(auth, "SignedHeaders=")
+	if i < 0 {
+		return false
+	}
+	signed := strings.Split(strings.SplitN(auth[i+len("SignedHeaders="):], ",", 2)[0], ";")
+
+	payloadHash := r.Header.Get("X-Amz-Content-Sha256")
+	if payloadHash != unsignedPayload {
+		sum := sha256.Sum256(body)
+		if hex.EncodeToString(sum[:]) != payloadHash {
+			return false
+		}
+	}
+	now, err := time.Parse("20060102T150405Z", r.Header.Get("X-Amz-Date"))
+	if err != nil {
+		return false
+	}
+
+	clone, _ := http.NewRequest(r.Method, "http://"+r.Host+r.URL.EscapedPath(), nil)
+	clone.URL.RawPath = r.URL.EscapedPath()
+	for _, name := range signed {
+		if name != "host" {
+			clone.Header.Set(name, r.Header.Get(name))
+		}
+	}
+	sign(clone, "test-access", f.secret, "us-east-1", payloadHash, now)
+	return clone.Header.Get("Authorization") == auth
+}
+
+// newFakeS3 คืน client ที่เซ็นด้วย secret ส่วน fake ยอมรับเฉพาะ "test-secret"
+func newFakeS3(t *testing.T, secret string) (*S3, *fakeS3) {
+	t.Helper()
+	fake := &fakeS3{bucket: "uploads", secret: "test-secret", objects: map[string]fakeObject{}}
+	srv := httptest.NewServer(fake)
+	t.Cleanup(srv.Close)
+
+	s, err := NewS3(config.S3Config{
+		Endpoint:  srv.URL,
+		Region:    "us-east-1",
+		Bucket:    "uploads",
+		AccessKey: "test-access",
+		SecretKey: secret,
+		PathStyle: true,
+	}, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	return s, fake
+}
+
+func TestS3RoundTrip(t *testing.T) {
+	ctx := context.Background()
+	s, fake := newFakeS3(t, "test-secret")
+	key := "accommodations/7/a b+c.jpg"
+
+	// bytes.Reader seek ได้ จึงถูก hash ใส่ลายเซ็น; io.MultiReader ส่งแบบ UNSIGNED-PAYLOAD
+	if err := s.Put(ctx, key, bytes.NewReader([]byte("jpeg-bytes")), 10, "image/jpeg"); err != nil {
+		t.Fatalf("Put error = %v", err)
+	}
+	if err := s.Put(ctx, "users/x.png", io.MultiReader(strings.NewReader("png")), 3, "image/png"); err != nil {
+		t.Fatalf("Put (unsigned payload) error = %v", err)
+	}
+
+	obj, err := s.Get(ctx, key)
+	if err != nil {
+		t.Fatalf("Get error = %v", err)
+	}
+	body, _ := io.ReadAll(obj.Body)
+	obj.Body.Close()
+	if string(body) != "jpeg-bytes" || obj.ContentType != "image/jpeg" || obj.Size != 10 {
+		t.Errorf("Get = %q (%s, size %d), want jpeg-bytes (image/jpeg, size 10)", body, obj.ContentType, obj.Size)
+	}
+	if obj.ModTime.IsZero() {
+		t.Error("Get did not parse Last-Modified")
+	}
+	if want := "/uploads/accommodations/7/a%20b%2Bc.jpg"; fake.paths[0] != want {
+		t.Errorf("request path = %q, want %q", fake.paths[0], want)
+	}
+
+	if err := s.Delete(ctx, key); err != nil {
+		t.Fatalf("Delete error = %v", err)
+	}
+	if _, err := s.Get(ctx, key); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Get after Delete error = %v, want ErrNotFound", err)
+	}
+	if err := s.Delete(ctx, key); err != nil {
+		t.Errorf("Delete of missing key error = %v, want nil", err)
+	}
+}
+
+func TestS3ReportsServiceErrors(t *testing.T) {
+	s, _ := newFakeS3(t, "wrong-secret")
+
+	err := s.Put(context.Background(), "users/a.jpg", bytes.NewReader([]byte("x")), 1, "image/jpeg")
+	if err == nil || !strings.Contains(err.Error(), "SignatureDoesNotMatch") {
+		t.Errorf("Put with wrong secret error = %v, want SignatureDoesNotMatch", err)
+	}
+	if _, err := s.Get(context.Background(), "users/a.jpg"); err == nil || errors.Is(err, ErrNotFound) {
+		t.Errorf("Get with wrong secret error = %v, want service error", err)
+	}
+}
+
+func TestS3RejectsInvalidKeys(t *testing.T) {
+	s, fake := newFakeS3(t, "test-secret")
+	ctx := context.Background()
+	for _, key := range []string{"", "../a.jpg", "/a.jpg", "a//b.jpg"} {
+		if err := s.Put(ctx, key, strings.NewReader("x"), 1, "image/jpeg"); err == nil {
+			t.Errorf("Put(%q) error = nil, want invalid key", key)
+		}
+		if _, err := s.Get(ctx, key); err == nil {
+			t.Errorf("Get(%q) error = nil, want invalid key", key)
+		}
+		if err := s.Delete(ctx, key); err == nil {
+			t.Errorf("Delete(%q) error = nil, want invalid key", key)
+		}
+	}
+	if len(fake.paths) != 0 {
+		t.Errorf("invalid keys reached the server: %v", fake.paths)
+	}
+}
+
+func TestS3URL(t *testing.T) {
+	tests := []struct {
+		name      string
+		cfg       config.S3Config
+		publicURL string
+		key       string
+		want      string
+	}{
+		{"path style", config.S3Config{Endpoint: "http://minio:9000", Bucket: "uploads", PathStyle: true}, "", "users/a.jpg", "http://minio:9000/uploads/users/a.jpg"},
+		{"virtual hosted", config.S3Config{Endpoint: "https://s3.amazonaws.com", Bucket: "uploads"}, "", "users/a.jpg", "https://uploads.s3.amazonaws.com/users/a.jpg"},
+		{"public url", config.S3Config{Endpoint: "https://s3.amazonaws.com", Bucket: "uploads"}, "https://cdn.example.com", "users/a b.jpg", "https://cdn.example.com/users/a%20b.jpg"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, err := NewS3(tt.cfg, tt.publicURL)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := s.URL(tt.key); got != tt.want {
+				t.Errorf("URL = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,130 @@
+// Package storage คือที่เก็บไฟล์ที่ upload แบบเปลี่ยน backend ได้ (local disk หรือ S3-compatible เช่น MinIO)
+// database เก็บแค่ object key (เช่น "users/3f9a...c1.jpg") ส่วน URL ที่ให้ client สร้างจาก key ตอนตอบ
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+	"strings"
+	"time"
+
+	"myapp/internal/config"
+)
+
+// ErrNotFound คือ key ที่ไม่มี object อยู่
+var ErrNotFound = errors.New("storage: object not found")
+
+// Storage คือ backend ของไฟล์ที่ upload; key ต้องผ่าน ValidKey
+type Storage interface {
+	// Put เขียน object ทับของเดิม (size คือจำนวน byte ของ body)
+	Put(ctx context.Context, key string, body io.Reader, size int64, contentType string) error
+	// Get เปิด object ให้อ่าน; ผู้เรียกต้องปิด Body เอง
+	Get(ctx context.Context, key string) (Object, error)
+	// Delete ลบ object; key ที่ไม่มีอยู่แล้วถือว่าสำเร็จ
+	Delete(ctx context.Context, key string) error
+	// URL คือ URL ที่ client ใช้เปิดไฟล์ได้ (key ว่างคืน "" และค่าที่เป็น http(s) URL อยู่แล้วคืนตามเดิม)
+	URL(key string) string
+}
+
+// Object คือ object ที่เปิดจาก Get
+type Object struct {
+	Body        io.ReadCloser
+	ContentType string
+	Size        int64 // -1 เมื่อไม่ทราบขนาด
+	ModTime     time.Time
+}
+
+// New สร้าง backend ตาม STORAGE_DRIVER
+func New(cfg config.StorageConfig) (Storage, error) {
+	if cfg.PublicURL != "" {
+		if err := checkBaseURL(cfg.PublicURL); err != nil {
+			return nil, err
+		}
+	}
+	switch cfg.Driver {
+	case config.StorageLocal:
+		base := cfg.PublicURL
+		if base == "" {
+			base = FilesPath
+		}
+		return NewLocal(cfg.LocalDir, base), nil
+	case config.StorageS3:
+		return NewS3(cfg.S3, cfg.PublicURL)
+	}
+	return nil, fmt.Errorf("unknown storage driver %q", cfg.Driver)
+}
+
+// ValidKey ตรวจว่า key เป็น path แบบ relative ที่ไม่มีส่วน "." / ".." หรือช่องว่างระหว่าง "/" (กันการหลุดออกนอก root)
+func ValidKey(key string) bool {
+	if key == "" || len(key) > 1024 || strings.ContainsRune(key, '\\') {
+		return false
+	}
+	for _, c := range key {
+		if c < 0x20 || c == 0x7f {
+			return false
+		}
+	}
+	for _, seg := range strings.Split(key, "/") {
+		if seg == "" || seg == "." || seg == ".." {
+			return false
+		}
+	}
+	return true
+}
+
+func checkKey(key string) error {
+	if !ValidKey(key) {
+		return fmt.Errorf("storage: invalid key %q", key)
+	}
+	return nil
+}
+
+// isURL บอกว่าค่าที่บันทึกไว้เป็น URL ภายนอกอยู่แล้ว (ข้อมูลเดิมก่อนใช้ object key)
+func isURL(key string) bool {
+	return strings.HasPrefix(key, "http://") || strings.HasPrefix(key, "https://")
+}
+
+// publicURL ต่อ key เข้ากับ base URL
+func publicURL(base, key string) string {
+	if key == "" || isURL(key) {
+		return key
+	}
+	return strings.TrimRight(base, "/") + "/" + escapeKey(key)
+}
+
+// escapeKey encode ทุกส่วนของ key ยกเว้น "/" ตามกฎของ S3 (unreserved เท่านั้นที่ไม่ encode)
+func escapeKey(key string) string {
+	segs := strings.Split(key, "/")
+	for i, s := range segs {
+		segs[i] = escapeSegment(s)
+	}
+	return strings.Join(segs, "/")
+}
+
+func escapeSegment(s string) string {
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		if 'A' <= c && c <= 'Z' || 'a' <= c && c <= 'z' || '0' <= c && c <= '9' || c == '-' || c == '.' || c == '_' || c == '~' {
+			b.WriteByte(c)
+			continue
+		}
+		fmt.Fprintf(&b, "%%%02X", c)
+	}
+	return b.String()
+}
+
+// checkBaseURL ตรวจ STORAGE_PUBLIC_URL (ต้องเป็น path หรือ http(s) URL)
+func checkBaseURL(raw string) error {
+	if strings.HasPrefix(raw, "/") {
+		return nil
+	}
+	u, err := url.Parse(raw)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return fmt.Errorf("storage: public URL must be a path or an http(s) URL: %q", raw)
+	}
+	return nil
+}
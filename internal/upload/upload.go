@@ -0,0 +1,141 @@
+// Package upload คือขั้นตอนรับไฟล์จาก multipart form ที่ใช้ร่วมกันทุก module
+// จำกัดขนาด, ตรวจชนิดไฟล์จากเนื้อหาจริง (ไม่เชื่อ Content-Type/ชื่อไฟล์ของ client) และตั้งชื่อไฟล์ใหม่แบบสุ่ม
+package upload
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"path"
+	"strconv"
+
+	"myapp/internal/apperr"
+	"myapp/internal/storage"
+)
+
+const (
+	// MaxImageBytes คือขนาดสูงสุดของรูปหนึ่งไฟล์
+	MaxImageBytes = 10 << 20
+	// memoryLimit คือส่วนของ form ที่เก็บในหน่วยความจำ ที่เหลือพักไว้ใน temp file
+	memoryLimit = 8 << 20
+)
+
+// imageTypes คือชนิดรูปที่รับ (ตรวจด้วย http.DetectContentType) และนามสกุลที่ใช้ตั้งชื่อไฟล์
+var imageTypes = map[string]string{
+	"image/jpeg": ".jpg",
+	"image/png":  ".png",
+	"image/gif":  ".gif",
+	"image/webp": ".webp",
+}
+
+// File คือไฟล์ที่ผ่านการตรวจแล้ว พร้อมบันทึกด้วย Put
+type File struct {
+	Data        []byte
+	ContentType string
+	Ext         string
+	Filename    string // ชื่อเดิมจาก client ใช้แค่ใน log ห้ามใช้ตั้งชื่อไฟล์
+}
+
+// FormLimit คือขนาด body สูงสุดของ form ที่มีรูปไม่เกิน files ไฟล์ (เผื่อ field อื่นและ boundary อีก 1 MB)
+func FormLimit(files int) int64 {
+	return int64(files)*MaxImageBytes + 1<<20
+}
+
+// ParseForm จำกัดขนาด body ทั้งหมดไว้ที่ maxBytes แล้วอ่าน multipart form
+func ParseForm(w http.ResponseWriter, r *http.Request, maxBytes int64) error {
+	r.Body = http.MaxBytesReader(w, r.Body, maxBytes)
+	if err := r.ParseMultipartForm(memoryLimit); err != nil {
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			return apperr.TooLarge("Upload must not exceed " + strconv.FormatInt(maxBytes>>20, 10) + " MB").Wrap(err)
+		}
+		return apperr.Invalid("Error parsing form data").Wrap(err)
+	}
+	return nil
+}
+
+// Image อ่านรูปหนึ่งไฟล์จาก field (ต้องเรียก ParseForm ก่อน)
+func Image(r *http.Request, field string) (File, error) {
+	files := formFiles(r, field)
+	if len(files) == 0 {
+		return File{}, apperr.Validation("File is required", map[string]string{field: "is required"})
+	}
+	return readImage(files[0], field)
+}
+
+// Images อ่านรูปทุกไฟล์ใน field ตามลำดับที่ส่งมา (1 ถึง max ไฟล์)
+func Images(r *http.Request, field string, max int) ([]File, error) {
+	headers := formFiles(r, field)
+	switch {
+	case len(headers) == 0:
+		return nil, apperr.Validation("File is required", map[string]string{field: "is required"})
+	case len(headers) > max:
+		return nil, apperr.Validation("Too many files", map[string]string{field: "must contain at most " + strconv.Itoa(max) + " files"})
+	}
+
+	files := make([]File, 0, len(headers))
+	for i, h := range headers {
+		f, err := readImage(h, field+"."+strconv.Itoa(i))
+		if err != nil {
+			return nil, err
+		}
+		files = append(files, f)
+	}
+	return files, nil
+}
+
+// Put บันทึกไฟล์ลง storage เป็นชื่อสุ่มใต้ prefix แล้วคืน object key เช่น "accommodations/12/3f9a...c1.jpg"
+func Put(ctx context.Context, s storage.Storage, prefix string, f File) (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	key := path.Join(prefix, hex.EncodeToString(b)+f.Ext)
+	if err := s.Put(ctx, key, bytes.NewReader(f.Data), int64(len(f.Data)), f.ContentType); err != nil {
+		return "", err
+	}
+	return key, nil
+}
+
+func formFiles(r *http.Request, field string) []*multipart.FileHeader {
+	if r.MultipartForm == nil {
+		return nil
+	}
+	return r.MultipartForm.File[field]
+}
+
+func readImage(h *multipart.FileHeader, field string) (File, error) {
+	if h.Size > MaxImageBytes {
+		return File{}, apperr.Validation("File is too large", map[string]string{
+			field: "must not exceed " + strconv.Itoa(MaxImageBytes>>20) + " MB",
+		})
+	}
+
+	src, err := h.Open()
+	if err != nil {
+		return File{}, err
+	}
+	defer src.Close()
+
+	data, err := io.ReadAll(io.LimitReader(src, MaxImageBytes+1))
+	if err != nil {
+		return File{}, err
+	}
+	if len(data) > MaxImageBytes {
+		return File{}, apperr.Validation("File is too large", map[string]string{
+			field: "must not exceed " + strconv.Itoa(MaxImageBytes>>20) + " MB",
+		})
+	}
+
+	contentType := http.DetectContentType(data)
+	ext, ok := imageTypes[contentType]
+	if !ok {
+		return File{}, apperr.Validation("Unsupported file type", map[string]string{field: "must be a JPEG, PNG, GIF or WebP image"})
+	}
+	return File{Data: data, ContentType: contentType, Ext: ext, Filename: h.Filename}, nil
+}
@@ -0,0 +1,68 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"myapp/internal/auth"
+	"myapp/internal/httpx"
+	"myapp/internal/user/usecase"
+	"net/http"
+)
+
+type AuthHandler struct {
+	Usecase usecase.AuthUsecase
+}
+
+func NewAuthHandler(authUC usecase.AuthUsecase) *AuthHandler {
+	return &AuthHandler{Usecase: authUC}
+}
+
+// ✅ [POST] /auth/refresh - แลก refresh token เป็น token คู่ใหม่
+func (h *AuthHandler) Refresh(w http.ResponseWriter, r *http.Request) {
+	var req refreshRequest
+	if err := httpx.Decode(w, r, &req); err != nil {
+		httpx.WriteError(w, r, err)
+		return
+	}
+
+	tokens, err := h.Usecase.Refresh(r.Context(), req.RefreshToken)
+	if err != nil {
+		httpx.WriteError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(tokens)
+}
+
+// ✅ [POST] /auth/logout - revoke refresh token ของเครื่องนี้
+func (h *AuthHandler) Logout(w http.ResponseWriter, r *http.Request) {
+	var req refreshRequest
+	if err := httpx.Decode(w, r, &req); err != nil {
+		httpx.WriteError(w, r, err)
+		return
+	}
+
+	if err := h.Usecase.Logout(r.Context(), req.RefreshToken); err != nil && !errors.Is(err, usecase.ErrInvalidRefreshToken) {
+		httpx.WriteError(w, r, err)
+		return
+	}
+
+	json.NewEncoder(w).Encode(map[string]string{"message": "Logged out successfully"})
+}
+
+// ✅ [POST] /auth/logout-all - revoke refresh token ทุกเครื่องของผู้ใช้
+func (h *AuthHandler) LogoutAll(w http.ResponseWriter, r *http.Request) {
+	principal, ok := auth.PrincipalFrom(r.Context())
+	if !ok {
+		httpx.WriteError(w, r, auth.ErrMissingToken)
+		return
+	}
+
+	if err := h.Usecase.LogoutAll(r.Context(), principal.UserID); err != nil {
+		httpx.WriteError(w, r, err)
+		return
+	}
+
+	json.NewEncoder(w).Encode(map[string]string{"message": "Logged out from all devices"})
+}
@@ -0,0 +1,109 @@
+package handler
+
+import (
+	"time"
+
+	"myapp/internal/storage"
+	"myapp/internal/user/model"
+)
+
+// CreateUserRequest คือ body ของ POST /users/register
+type CreateUserRequest struct {
+	FirstName string `json:"first_name" validate:"required,max=100"`
+	Email     string `json:"email" validate:"required,email,max=255"`
+	Password  string `json:"password" validate:"required,min=8,max=72"`
+}
+
+type LoginRequest struct {
+	Email    string `json:"email" validate:"required,email,max=255"`
+	Password string `json:"password" validate:"required,max=72"`
+}
+
+// UpdateUserRequest คือ body ของ PUT /users/{id} (admin)
+type UpdateUserRequest struct {
+	FirstName   string `json:"first_name" validate:"required,max=100"`
+	LastName    string `json:"lastname" validate:"max=100"`
+	PhoneNumber *int64 `json:"phone_number" validate:"gte=0"`
+	Role        string `json:"role" validate:"required,oneof=admin host traveler"`
+}
+
+// UpdateProfileRequest คือ body ของ PUT /users/{id}/profile
+// รูปโปรไฟล์เปลี่ยนผ่าน endpoint อัปโหลดรูปเท่านั้น
+type UpdateProfileRequest struct {
+	FirstName   string `json:"first_name" validate:"required,max=100"`
+	LastName    string `json:"lastname" validate:"max=100"`
+	PhoneNumber *int64 `json:"phone_number" validate:"gte=0"`
+}
+
+type UpdateEmailRequest struct {
+	Email string `json:"email" validate:"required,email,max=255"`
+}
+
+type UpdatePasswordRequest struct {
+	OldPassword     string `json:"old_password" validate:"required,max=72"`
+	NewPassword     string `json:"new_password" validate:"required,min=8,max=72"`
+	ConfirmPassword string `json:"confirm_password" validate:"required"`
+}
+
+type ResetPasswordRequest struct {
+	Email       string `json:"email" validate:"required,email,max=255"`
+	OTP         string `json:"otp" validate:"required,digits,min=6,max=6"`
+	NewPassword string `json:"new_password" validate:"required,min=8,max=72"`
+}
+
+// SendOTPRequest คือ body ของ POST /otp/send; metadata ใช้กับ action register (เช่น first_name, password)
+type SendOTPRequest struct {
+	Email    string            `json:"email" validate:"required,email,max=255"`
+	Action   string            `json:"action" validate:"required,oneof=register verify_email reset_password"`
+	Metadata map[string]string `json:"metadata" validate:"max=20"`
+}
+
+type VerifyOTPRequest struct {
+	Email  string `json:"email" validate:"required,email,max=255"`
+	OTP    string `json:"otp" validate:"required,digits,min=6,max=6"`
+	Action string `json:"action" validate:"required,oneof=register verify_email reset_password"`
+}
+
+type ConfirmRegisterRequest struct {
+	Email  string `json:"email" validate:"required,email,max=255"`
+	OTP    string `json:"otp" validate:"required,digits,min=6,max=6"`
+	Action string `json:"action" validate:"required,oneof=register"`
+}
+
+type refreshRequest struct {
+	RefreshToken string `json:"refresh_token" validate:"required,max=512"`
+}
+
+// UserResponse คือข้อมูล user ที่ส่งให้ client (ไม่มี password)
+type UserResponse struct {
+	ID          int64      `json:"id"`
+	FirstName   string     `json:"first_name"`
+	LastName    string     `json:"lastname,omitempty"`
+	PhoneNumber *int64     `json:"phone_number,omitempty"`
+	Email       string     `json:"email"`
+	Photo       *string    `json:"photo,omitempty"`
+	PhotoURL    *string    `json:"photo_url,omitempty"`
+	CreatedAt   *time.Time `json:"created_at,omitempty"`
+	UpdatedAt   *time.Time `json:"updated_at,omitempty"`
+	Role        string     `json:"role,omitempty"`
+}
+
+func newUserResponse(u model.User, files storage.Storage) UserResponse {
+	var photoURL *string
+	if u.Photo != nil && *u.Photo != "" {
+		url := files.URL(*u.Photo)
+		photoURL = &url
+	}
+	return UserResponse{
+		ID:          u.ID,
+		FirstName:   u.FirstName,
+		LastName:    u.LastName,
+		PhoneNumber: u.PhoneNumber,
+		Email:       u.Email,
+		Photo:       u.Photo,
+		PhotoURL:    photoURL,
+		CreatedAt:   u.CreatedAt,
+		UpdatedAt:   u.UpdatedAt,
+		Role:        u.Role,
+	}
+}
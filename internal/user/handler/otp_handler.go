@@ -1,56 +1,68 @@
 package handler
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
+	"myapp/internal/apperr"
+	"myapp/internal/httpx"
 	"myapp/internal/user/usecase"
 	"net/http"
 )
 
 type OTPHandler struct {
-	Usecase     usecase.OTPUsecase
-	UserUsecase usecase.UserUsecase
+	Usecase      usecase.OTPUsecase
+	UserUsecase  usecase.UserUsecase
+	Registration usecase.RegistrationUsecase
+	Guard        usecase.OTPGuard
 }
 
-func NewOTPHandler(otpUC usecase.OTPUsecase, userUC usecase.UserUsecase) *OTPHandler {
+func NewOTPHandler(otpUC usecase.OTPUsecase, userUC usecase.UserUsecase, regUC usecase.RegistrationUsecase, guard usecase.OTPGuard) *OTPHandler {
 	return &OTPHandler{
-		Usecase:     otpUC,
-		UserUsecase: userUC, // ✅ เพิ่มตรงนี้
+		Usecase:      otpUC,
+		UserUsecase:  userUC, // ✅ เพิ่มตรงนี้
+		Registration: regUC,
+		Guard:        guard,
 	}
 }
 
 // ✅ [POST] /otp/send - ส่ง OTP ไปยังอีเมล
 func (h *OTPHandler) SendOTP(w http.ResponseWriter, r *http.Request) {
-	var req struct {
-		Email    string            `json:"email"`
-		Action   string            `json:"action"`
-		Metadata map[string]string `json:"metadata"`
+	var req SendOTPRequest
+	if err := httpx.Decode(w, r, &req); err != nil {
+		httpx.WriteError(w, r, err)
+		return
 	}
 
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil || req.Email == "" || req.Action == "" {
-		http.Error(w, "Invalid request", http.StatusBadRequest)
+	// ✅ cooldown + limit ต่อ email/IP ก่อนส่งอีเมล
+	if err := h.Guard.AllowSend(r.Context(), req.Email, httpx.ClientIP(r)); err != nil {
+		httpx.WriteError(w, r, err)
 		return
 	}
 
 	if req.Action == "register" {
 		// ✅ สมัครสมาชิก => ต้องยังไม่มี email นี้
-		if _, err := h.UserUsecase.GetByEmail(req.Email); err == nil {
-			http.Error(w, "This email is already registered", http.StatusConflict)
+		if _, err := h.UserUsecase.GetByEmail(r.Context(), req.Email); err == nil {
+			httpx.WriteError(w, r, usecase.ErrEmailTaken)
 			return
 		}
 	} else {
 		// ✅ action อื่นๆ => ต้องมี email นี้ในระบบ
-		if _, err := h.UserUsecase.GetByEmail(req.Email); err != nil {
-			http.Error(w, "This email is not registered", http.StatusNotFound)
+		if _, err := h.UserUsecase.GetByEmail(r.Context(), req.Email); errors.Is(err, apperr.ErrNotFound) {
+			httpx.WriteError(w, r, apperr.NotFound("This email is not registered"))
+			return
+		} else if err != nil {
+			httpx.WriteError(w, r, err)
 			return
 		}
 	}
-	if err := h.Usecase.SendOTPWithMetadata(req.Email, req.Action, req.Metadata); err != nil {
-		http.Error(w, "Failed to send OTP", http.StatusInternalServerError)
+	if err := h.Usecase.SendOTPWithMetadata(r.Context(), req.Email, req.Action, req.Metadata); err != nil {
+		httpx.WriteError(w, r, err)
 		return
 	}
 	// ✅ ส่ง OTP
-	if err := h.Usecase.SendOTP(req.Email, req.Action); err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+	if err := h.Usecase.SendOTP(r.Context(), req.Email, req.Action); err != nil {
+		httpx.WriteError(w, r, err)
 		return
 	}
 
@@ -59,18 +71,17 @@ func (h *OTPHandler) SendOTP(w http.ResponseWriter, r *http.Request) {
 
 // ✅ [POST] /otp/verify - ตรวจสอบ OTP
 func (h *OTPHandler) VerifyOTP(w http.ResponseWriter, r *http.Request) {
-	var req struct {
-		Email  string `json:"email"`
-		Otp    string `json:"otp"`
-		Action string `json:"action"`
-	}
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil || req.Email == "" || req.Otp == "" || req.Action == "" {
-		http.Error(w, "Invalid request", http.StatusBadRequest)
+	var req VerifyOTPRequest
+	if err := httpx.Decode(w, r, &req); err != nil {
+		httpx.WriteError(w, r, err)
 		return
 	}
 
-	if err := h.Usecase.VerifyOTP(req.Email, req.Otp, req.Action); err != nil {
-		http.Error(w, err.Error(), http.StatusUnauthorized)
+	err := h.Guard.Verify(r.Context(), req.Email, httpx.ClientIP(r), func(ctx context.Context) error {
+		return h.Usecase.VerifyOTP(ctx, req.Email, req.OTP, req.Action)
+	})
+	if err != nil {
+		httpx.WriteError(w, r, err)
 		return
 	}
 
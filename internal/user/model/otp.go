@@ -2,9 +2,13 @@ package model
 
 import "time"
 
+// OTP เก็บเฉพาะ hash ของรหัส (salt ต่อแถว) ไม่เก็บรหัสจริง
 type OTP struct {
+	ID        int64
 	Email     string
-	Code      string
+	Action    string
+	CodeHash  string
+	CodeSalt  string
 	ExpiresAt time.Time
 	Verified  bool
 }
@@ -0,0 +1,12 @@
+package model
+
+import "time"
+
+// OTPAttempt คือ counter ของการขอ/ตรวจ OTP ต่อ bucket (เช่น "send:email:a@b.com", "verify:ip:1.2.3.4")
+type OTPAttempt struct {
+	Bucket          string
+	Hits            int
+	WindowStartedAt time.Time
+	LastHitAt       time.Time
+	LockedUntil     *time.Time
+}
@@ -0,0 +1,15 @@
+package model
+
+import "time"
+
+// RefreshToken เก็บเฉพาะ hash ของ token จริง (ไม่เก็บ plain text)
+type RefreshToken struct {
+	ID         int64
+	UserID     int64
+	TokenHash  string
+	FamilyID   string
+	ExpiresAt  time.Time
+	RevokedAt  *time.Time
+	ReplacedBy *int64
+	CreatedAt  time.Time
+}
@@ -14,3 +14,8 @@ type User struct {
 	UpdatedAt   *time.Time `json:"updated_at,omitempty"`
 	Role        string    `json:"role,omitempty"`
 }
+
+// UserFilter คือเงื่อนไขของ list (ค่าว่าง = ไม่กรอง)
+type UserFilter struct {
+	Role string
+}
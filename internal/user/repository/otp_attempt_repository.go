@@ -0,0 +1,101 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"myapp/internal/database"
+	"myapp/internal/user/model"
+	"time"
+)
+
+// OTPAttemptRepository เก็บ counter ไว้ใน MySQL เพื่อให้ยังอยู่หลัง restart
+// ทุก method เขียนผ่าน pool โดยตรง (ไม่ใช้ transaction ใน ctx) เพื่อไม่ให้ counter หายเมื่อ transaction rollback
+type OTPAttemptRepository interface {
+	Get(ctx context.Context, bucket string) (model.OTPAttempt, error)
+	Hit(ctx context.Context, bucket string, now time.Time, window time.Duration) (model.OTPAttempt, error)
+	Lock(ctx context.Context, bucket string, until time.Time) error
+	Reset(ctx context.Context, bucket string) error
+	// DeleteStale ลบ bucket ที่ไม่มีการนับเลยตั้งแต่ before (ไม่เกิน limit แถว)
+	DeleteStale(ctx context.Context, before time.Time, limit int) (int64, error)
+}
+
+type otpAttemptRepo struct {
+	db *database.DB
+}
+
+func NewOTPAttemptRepository(db *database.DB) OTPAttemptRepository {
+	return &otpAttemptRepo{db: db}
+}
+
+func (r *otpAttemptRepo) Get(ctx context.Context, bucket string) (model.OTPAttempt, error) {
+	ctx, cancel := r.db.WithTimeout(ctx)
+	defer cancel()
+
+	var a model.OTPAttempt
+	err := r.db.QueryRowContext(ctx, `
+		SELECT bucket, hits, window_started_at, last_hit_at, locked_until
+		FROM otp_attempts WHERE bucket = ?`, bucket).
+		Scan(&a.Bucket, &a.Hits, &a.WindowStartedAt, &a.LastHitAt, &a.LockedUntil)
+	if errors.Is(err, sql.ErrNoRows) {
+		return model.OTPAttempt{Bucket: bucket}, nil
+	}
+	return a, err
+}
+
+// Hit เพิ่ม counter 1 ครั้ง; ถ้า window เดิมหมดแล้วจะเริ่มนับใหม่
+func (r *otpAttemptRepo) Hit(ctx context.Context, bucket string, now time.Time, window time.Duration) (model.OTPAttempt, error) {
+	now = now.UTC()
+	cutoff := now.Add(-window)
+
+	ctx, cancel := r.db.WithTimeout(ctx)
+	defer cancel()
+
+	// MySQL อัปเดต column ตามลำดับ: hits ต้องมาก่อน window_started_at เพื่อใช้ค่าเดิมในการเทียบ
+	_, err := r.db.ExecContext(ctx, `
+		INSERT INTO otp_attempts (bucket, hits, window_started_at, last_hit_at)
+		VALUES (?, 1, ?, ?)
+		ON DUPLICATE KEY UPDATE
+			hits = IF(window_started_at < ?, 1, hits + 1),
+			window_started_at = IF(window_started_at < ?, ?, window_started_at),
+			last_hit_at = ?`,
+		bucket, now, now,
+		cutoff,
+		cutoff, now,
+		now,
+	)
+	if err != nil {
+		return model.OTPAttempt{}, err
+	}
+	return r.Get(ctx, bucket)
+}
+
+func (r *otpAttemptRepo) Lock(ctx context.Context, bucket string, until time.Time) error {
+	ctx, cancel := r.db.WithTimeout(ctx)
+	defer cancel()
+
+	_, err := r.db.ExecContext(ctx, `UPDATE otp_attempts SET locked_until = ? WHERE bucket = ?`, until.UTC(), bucket)
+	return err
+}
+
+func (r *otpAttemptRepo) Reset(ctx context.Context, bucket string) error {
+	ctx, cancel := r.db.WithTimeout(ctx)
+	defer cancel()
+
+	_, err := r.db.ExecContext(ctx, `DELETE FROM otp_attempts WHERE bucket = ?`, bucket)
+	return err
+}
+
+func (r *otpAttemptRepo) DeleteStale(ctx context.Context, before time.Time, limit int) (int64, error) {
+	ctx, cancel := r.db.WithTimeout(ctx)
+	defer cancel()
+
+	res, err := r.db.ExecContext(ctx, `
+		DELETE FROM otp_attempts
+		WHERE last_hit_at < ? AND (locked_until IS NULL OR locked_until < ?)
+		LIMIT ?`, before.UTC(), before.UTC(), limit)
+	if err != nil {
+		return 0, err
+	}
+	return res.RowsAffected()
+}
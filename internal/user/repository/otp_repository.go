@@ -1,61 +1,106 @@
 package repository
 
 import (
-	"database/sql"
-	"log"
-	"time"
+	"context"
+	"log/slog"
+	"myapp/internal/database"
+	"myapp/internal/user/model"
 )
 
 type OTPRepository interface {
-	SaveOTP(email, otp, action string, expiresAt time.Time) error
-	SaveOTPWithMetadata(email, otp, action string, expiresAt time.Time, metadata map[string]string) error
-	VerifyOTP(email, code, action string) (bool, error)
-	MarkVerified(email, code, action string) error
-	GetOTPMetadata(email, action string) (map[string]string, error)
+	SaveOTP(ctx context.Context, otp model.OTP) error
+	SaveOTPWithMetadata(ctx context.Context, otp model.OTP, metadata map[string]string) error
+	FindActive(ctx context.Context, email, action string, maxAttempts int) ([]model.OTP, error)
+	MarkVerified(ctx context.Context, id int64) (bool, error)
+	IncrementAttempts(ctx context.Context, email, action string) error
+	GetOTPMetadata(ctx context.Context, email, action string) (map[string]string, error)
+	DeleteStale(ctx context.Context, limit int) (int64, error)
+	DeleteOrphanedMetadata(ctx context.Context, limit int) (int64, error)
 }
 type otpRepo struct {
-	db *sql.DB
+	db *database.DB
 }
 
-func NewOTPRepository(db *sql.DB) OTPRepository {
+func NewOTPRepository(db *database.DB) OTPRepository {
 	return &otpRepo{db: db}
 }
 
-func (r *otpRepo) SaveOTP(email, otp, action string, expiresAt time.Time) error {
+func (r *otpRepo) SaveOTP(ctx context.Context, otp model.OTP) error {
+	ctx, cancel := r.db.WithTimeout(ctx)
+	defer cancel()
+
 	// ✅ แปลงเป็น UTC เพื่อให้ตรงกับเวลาของ MySQL
-	expiresAt = expiresAt.UTC()
+	expiresAt := otp.ExpiresAt.UTC()
 
-	log.Printf("📥 Save OTP: email=%s, code=%s, action=%s, expires_at=%s", email, otp, action, expiresAt.Format(time.RFC3339))
-	_, err := r.db.Exec("INSERT INTO otps (email, code, action, expires_at) VALUES (?, ?, ?, ?)", email, otp, action, expiresAt)
+	slog.DebugContext(ctx, "saving OTP", "email", otp.Email, "action", otp.Action, "expires_at", expiresAt)
+	_, err := r.db.Conn(ctx).ExecContext(ctx, `
+		INSERT INTO otps (email, code_hash, code_salt, action, expires_at)
+		VALUES (?, ?, ?, ?, ?)`,
+		otp.Email, otp.CodeHash, otp.CodeSalt, otp.Action, expiresAt,
+	)
 	return err
 }
-func (r *otpRepo) VerifyOTP(email, code, action string) (bool, error) {
-	log.Printf("🔍 Verifying OTP: email=%s, code=%s", email, code)
-	log.Printf("🧪 Checking OTP - email: %s | code: %s | action: %s", email, code, action)
 
-	var count int
-	err := r.db.QueryRow(`
-		SELECT COUNT(*) FROM otps
-		WHERE email = ? AND code = ? AND action = ? AND verified = 0 AND expires_at > UTC_TIMESTAMP()`,
-		email, code, action,
-	).Scan(&count)
+// FindActive คืน OTP ที่ยังไม่หมดอายุ ยังไม่ถูกใช้ และยังเดาผิดไม่ถึง maxAttempts ให้ usecase เทียบ hash เอง
+func (r *otpRepo) FindActive(ctx context.Context, email, action string, maxAttempts int) ([]model.OTP, error) {
+	ctx, cancel := r.db.WithTimeout(ctx)
+	defer cancel()
 
+	rows, err := r.db.Conn(ctx).QueryContext(ctx, `
+		SELECT id, email, action, code_hash, code_salt, expires_at, verified
+		FROM otps
+		WHERE email = ? AND action = ? AND verified = 0 AND attempts < ? AND expires_at > UTC_TIMESTAMP()`,
+		email, action, maxAttempts,
+	)
 	if err != nil {
-		return false, err
+		return nil, err
 	}
+	defer rows.Close()
 
-	log.Printf("✅ Found OTP match count: %d", count)
-	return count > 0, nil
+	var list []model.OTP
+	for rows.Next() {
+		var o model.OTP
+		if err := rows.Scan(&o.ID, &o.Email, &o.Action, &o.CodeHash, &o.CodeSalt, &o.ExpiresAt, &o.Verified); err != nil {
+			return nil, err
+		}
+		list = append(list, o)
+	}
+	return list, rows.Err()
+}
+
+// MarkVerified คืน false ถ้า OTP ถูกใช้ไปแล้ว (กันการ verify ซ้ำพร้อมกัน)
+func (r *otpRepo) MarkVerified(ctx context.Context, id int64) (bool, error) {
+	ctx, cancel := r.db.WithTimeout(ctx)
+	defer cancel()
+
+	res, err := r.db.Conn(ctx).ExecContext(ctx, `
+		UPDATE otps SET verified = 1, verified_at = UTC_TIMESTAMP()
+		WHERE id = ? AND verified = 0`, id)
+	if err != nil {
+		return false, err
+	}
+	n, err := res.RowsAffected()
+	return n > 0, err
 }
 
-func (r *otpRepo) MarkVerified(email, code, action string) error {
-	_, err := r.db.Exec(`
-		UPDATE otps SET verified = 1, verified_at = NOW()
-		WHERE email = ? AND code = ? AND action = ?`, email, code, action)
+// IncrementAttempts นับการเดาผิดของ OTP ที่ยังใช้ได้ทั้งหมด
+// เขียนผ่าน pool โดยตรงเพื่อไม่ให้ถูก rollback ไปพร้อม transaction ของ caller
+func (r *otpRepo) IncrementAttempts(ctx context.Context, email, action string) error {
+	ctx, cancel := r.db.WithTimeout(ctx)
+	defer cancel()
+
+	_, err := r.db.ExecContext(ctx, `
+		UPDATE otps SET attempts = attempts + 1
+		WHERE email = ? AND action = ? AND verified = 0 AND expires_at > UTC_TIMESTAMP()`,
+		email, action)
 	return err
 }
-func (r *otpRepo) GetOTPMetadata(email, action string) (map[string]string, error) {
-	rows, err := r.db.Query(`
+
+func (r *otpRepo) GetOTPMetadata(ctx context.Context, email, action string) (map[string]string, error) {
+	ctx, cancel := r.db.WithTimeout(ctx)
+	defer cancel()
+
+	rows, err := r.db.Conn(ctx).QueryContext(ctx, `
 		SELECT field_key, field_value
 		FROM otp_metadata
 		WHERE email = ? AND action = ?`,
@@ -77,31 +122,70 @@ func (r *otpRepo) GetOTPMetadata(email, action string) (map[string]string, error
 	return result, nil
 }
 
-func (r *otpRepo) SaveOTPWithMetadata(email, otp, action string, expiresAt time.Time, metadata map[string]string) error {
-	expiresAt = expiresAt.UTC()
+func (r *otpRepo) SaveOTPWithMetadata(ctx context.Context, otp model.OTP, metadata map[string]string) error {
+	ctx, cancel := r.db.WithTimeout(ctx)
+	defer cancel()
 
-	// 1. Save OTP ปกติ
-	_, err := r.db.Exec(`
-		INSERT INTO otps (email, code, action, expires_at)
-		VALUES (?, ?, ?, ?)`,
-		email, otp, action, expiresAt,
-	)
-	if err != nil {
-		return err
-	}
+	email, action := otp.Email, otp.Action
 
-	// 2. Save metadata
-	for key, value := range metadata {
-		_, err := r.db.Exec(`
-			INSERT INTO otp_metadata (email, action, field_key, field_value)
-			VALUES (?, ?, ?, ?)`,
-			email, action, key, value,
-		)
-		if err != nil {
+	// ✅ OTP + metadata ต้องสำเร็จหรือล้มเหลวพร้อมกัน
+	return r.db.WithinTx(ctx, func(ctx context.Context) error {
+		// 1. Save OTP ปกติ
+		if err := r.SaveOTP(ctx, otp); err != nil {
 			return err
 		}
+
+		// 2. Save metadata
+		for key, value := range metadata {
+			_, err := r.db.Conn(ctx).ExecContext(ctx, `
+				INSERT INTO otp_metadata (email, action, field_key, field_value)
+				VALUES (?, ?, ?, ?)`,
+				email, action, key, value,
+			)
+			if err != nil {
+				return err
+			}
+		}
+		slog.DebugContext(ctx, "saving OTP metadata", "email", email, "action", action, "fields", len(metadata))
+
+		return nil
+	})
+}
+
+// DeleteStale ลบ OTP ที่หมดอายุหรือถูกใช้ไปแล้ว ครั้งละไม่เกิน limit แถว (กัน lock ตารางนาน)
+func (r *otpRepo) DeleteStale(ctx context.Context, limit int) (int64, error) {
+	ctx, cancel := r.db.WithTimeout(ctx)
+	defer cancel()
+
+	res, err := r.db.Conn(ctx).ExecContext(ctx, `
+		DELETE FROM otps
+		WHERE verified = 1 OR expires_at < UTC_TIMESTAMP()
+		LIMIT ?`, limit)
+	if err != nil {
+		return 0, err
 	}
-	log.Printf("📝 Saving metadata for: %s | %s | %v", email, action, metadata)
+	return res.RowsAffected()
+}
+
+// DeleteOrphanedMetadata ลบ metadata ที่ไม่มี OTP ของ email/action เดียวกันเหลืออยู่แล้ว
+func (r *otpRepo) DeleteOrphanedMetadata(ctx context.Context, limit int) (int64, error) {
+	ctx, cancel := r.db.WithTimeout(ctx)
+	defer cancel()
 
-	return nil
+	// MySQL ไม่ให้ใช้ LIMIT กับ multi-table DELETE จึงเลือก id ผ่าน derived table ก่อน
+	res, err := r.db.Conn(ctx).ExecContext(ctx, `
+		DELETE FROM otp_metadata
+		WHERE id IN (
+			SELECT id FROM (
+				SELECT m.id
+				FROM otp_metadata m
+				LEFT JOIN otps o ON o.email = m.email AND o.action = m.action
+				WHERE o.id IS NULL
+				LIMIT ?
+			) AS orphaned
+		)`, limit)
+	if err != nil {
+		return 0, err
+	}
+	return res.RowsAffected()
 }
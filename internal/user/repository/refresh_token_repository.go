@@ -0,0 +1,96 @@
+package repository
+
+import (
+	"context"
+	"myapp/internal/database"
+	"myapp/internal/user/model"
+)
+
+type RefreshTokenRepository interface {
+	Create(ctx context.Context, t model.RefreshToken) (int64, error)
+	GetByHash(ctx context.Context, tokenHash string) (model.RefreshToken, error)
+	Rotate(ctx context.Context, id, replacedBy int64) (bool, error)
+	Revoke(ctx context.Context, id int64) error
+	RevokeFamily(ctx context.Context, familyID string) error
+	RevokeAllForUser(ctx context.Context, userID int64) error
+}
+
+type refreshTokenRepo struct {
+	db *database.DB
+}
+
+func NewRefreshTokenRepository(db *database.DB) RefreshTokenRepository {
+	return &refreshTokenRepo{db: db}
+}
+
+func (r *refreshTokenRepo) Create(ctx context.Context, t model.RefreshToken) (int64, error) {
+	ctx, cancel := r.db.WithTimeout(ctx)
+	defer cancel()
+
+	res, err := r.db.Conn(ctx).ExecContext(ctx, `
+		INSERT INTO refresh_tokens (user_id, token_hash, family_id, expires_at, created_at)
+		VALUES (?, ?, ?, ?, UTC_TIMESTAMP())`,
+		t.UserID, t.TokenHash, t.FamilyID, t.ExpiresAt.UTC(),
+	)
+	if err != nil {
+		return 0, err
+	}
+	return res.LastInsertId()
+}
+
+func (r *refreshTokenRepo) GetByHash(ctx context.Context, tokenHash string) (model.RefreshToken, error) {
+	ctx, cancel := r.db.WithTimeout(ctx)
+	defer cancel()
+
+	var t model.RefreshToken
+	err := r.db.Conn(ctx).QueryRowContext(ctx, `
+		SELECT id, user_id, token_hash, family_id, expires_at, revoked_at, replaced_by, created_at
+		FROM refresh_tokens WHERE token_hash = ?`, tokenHash).
+		Scan(&t.ID, &t.UserID, &t.TokenHash, &t.FamilyID, &t.ExpiresAt, &t.RevokedAt, &t.ReplacedBy, &t.CreatedAt)
+	return t, database.NotFound(err, "Refresh token not found")
+}
+
+// Rotate revoke token เดิมและชี้ไปยัง token ใหม่; คืน false ถ้า token ถูก revoke ไปก่อนแล้ว (ใช้ซ้ำ)
+func (r *refreshTokenRepo) Rotate(ctx context.Context, id, replacedBy int64) (bool, error) {
+	ctx, cancel := r.db.WithTimeout(ctx)
+	defer cancel()
+
+	res, err := r.db.Conn(ctx).ExecContext(ctx, `
+		UPDATE refresh_tokens SET revoked_at = UTC_TIMESTAMP(), replaced_by = ?
+		WHERE id = ? AND revoked_at IS NULL`, replacedBy, id)
+	if err != nil {
+		return false, err
+	}
+	n, err := res.RowsAffected()
+	return n > 0, err
+}
+
+func (r *refreshTokenRepo) Revoke(ctx context.Context, id int64) error {
+	ctx, cancel := r.db.WithTimeout(ctx)
+	defer cancel()
+
+	_, err := r.db.Conn(ctx).ExecContext(ctx, `
+		UPDATE refresh_tokens SET revoked_at = UTC_TIMESTAMP()
+		WHERE id = ? AND revoked_at IS NULL`, id)
+	return err
+}
+
+func (r *refreshTokenRepo) RevokeFamily(ctx context.Context, familyID string) error {
+	ctx, cancel := r.db.WithTimeout(ctx)
+	defer cancel()
+
+	_, err := r.db.Conn(ctx).ExecContext(ctx, `
+		UPDATE refresh_tokens SET revoked_at = UTC_TIMESTAMP()
+		WHERE family_id = ? AND revoked_at IS NULL`, familyID)
+	return err
+}
+
+func (r *refreshTokenRepo) RevokeAllForUser(ctx context.Context, userID int64) error {
+	ctx, cancel := r.db.WithTimeout(ctx)
+	defer cancel()
+
+	_, err := r.db.Conn(ctx).ExecContext(ctx, `
+		UPDATE refresh_tokens SET revoked_at = UTC_TIMESTAMP()
+		WHERE user_id = ? AND revoked_at IS NULL`, userID)
+	return err
+}
@@ -1,60 +1,71 @@
 package repository
 
 import (
+	"context"
 	"database/sql"
-	"log"
+	"log/slog"
+	"myapp/internal/database"
 	"myapp/internal/user/model"
 )
 
 type UserRepository interface {
-	GetAll() ([]model.User, error)
-	GetByID(id int64) (model.User, error)
-	Create(user model.User) error
-	Update(user model.User) error
-	Delete(id int64) error
-	GetByEmail(email string) (model.User, error)
-	UpdateEmail(id int64, email string) error
-	IsEmailTaken(email string, excludeID int64) (bool, error)
-	UpdatePassword(id int64, hashedPassword string) error
-	UpdateProfilePhoto(id int64, photoPath string) error
+	List(ctx context.Context, f model.UserFilter, opts database.ListOptions) (database.Page[model.User], error)
+	GetByID(ctx context.Context, id int64) (model.User, error)
+	Create(ctx context.Context, user model.User) error
+	Update(ctx context.Context, user model.User) error
+	Delete(ctx context.Context, id int64) error
+	GetByEmail(ctx context.Context, email string) (model.User, error)
+	UpdateEmail(ctx context.Context, id int64, email string) error
+	IsEmailTaken(ctx context.Context, email string, excludeID int64) (bool, error)
+	UpdatePassword(ctx context.Context, id int64, hashedPassword string) error
+	// UpdateProfilePhoto ตั้งรูปใหม่และคืน object key ของรูปเดิม ("" เมื่อยังไม่มีรูป)
+	UpdateProfilePhoto(ctx context.Context, id int64, photoKey string) (string, error)
 }
 
 type userRepo struct {
-	db *sql.DB
+	db *database.DB
 }
 
-func NewUserRepository(db *sql.DB) UserRepository {
+func NewUserRepository(db *database.DB) UserRepository {
 	return &userRepo{db: db}
 }
 
-func (r *userRepo) GetAll() ([]model.User, error) {
-	rows, err := r.db.Query(`
-		SELECT user_id, first_name, lastname, password, phone_number, email, photo, created_at, updated_at, role
-		FROM users`)
-	if err != nil {
-		return nil, err
-	}
-	defer rows.Close()
-
-	var users []model.User
-	for rows.Next() {
+// userList คือ sort key ที่ client ใช้ได้ใน ?sort= (created_at เป็น NULL ได้จึงไม่เปิดให้ sort)
+var userList = database.ListSpec[model.User]{
+	IDColumn: "user_id",
+	ID:       func(u model.User) any { return u.ID },
+	Sorts: map[string]database.SortKey[model.User]{
+		"id":         {Column: "user_id", Value: func(u model.User) any { return u.ID }},
+		"first_name": {Column: "first_name", Value: func(u model.User) any { return u.FirstName }},
+		"email":      {Column: "email", Value: func(u model.User) any { return u.Email }},
+	},
+	DefaultSort: "id",
+	Scan: func(rows *sql.Rows) (model.User, error) {
 		var user model.User
 		err := rows.Scan(
 			&user.ID, &user.FirstName, &user.LastName, &user.Password,
 			&user.PhoneNumber, &user.Email, &user.Photo,
 			&user.CreatedAt, &user.UpdatedAt, &user.Role,
 		)
-		if err != nil {
-			return nil, err
-		}
-		users = append(users, user)
+		return user, err
+	},
+}
+
+func (r *userRepo) List(ctx context.Context, f model.UserFilter, opts database.ListOptions) (database.Page[model.User], error) {
+	q := database.Select("users",
+		"user_id", "first_name", "lastname", "password", "phone_number", "email", "photo", "created_at", "updated_at", "role")
+	if f.Role != "" {
+		q.Where("role = ?", f.Role)
 	}
-	return users, nil
+	return database.List(ctx, r.db, q, userList, opts)
 }
 
-func (r *userRepo) GetByID(id int64) (model.User, error) {
+func (r *userRepo) GetByID(ctx context.Context, id int64) (model.User, error) {
+	ctx, cancel := r.db.WithTimeout(ctx)
+	defer cancel()
+
 	var user model.User
-	err := r.db.QueryRow(`
+	err := r.db.Conn(ctx).QueryRowContext(ctx, `
 		SELECT user_id, first_name, lastname, password, phone_number, email, photo, created_at, updated_at, role
 		FROM users WHERE user_id = ?`, id).
 		Scan(
@@ -62,11 +73,14 @@ func (r *userRepo) GetByID(id int64) (model.User, error) {
 			&user.PhoneNumber, &user.Email, &user.Photo,
 			&user.CreatedAt, &user.UpdatedAt, &user.Role,
 		)
-	return user, err
+	return user, database.NotFound(err, "User not found")
 }
 
-func (r *userRepo) Create(user model.User) error {
-	_, err := r.db.Exec(`
+func (r *userRepo) Create(ctx context.Context, user model.User) error {
+	ctx, cancel := r.db.WithTimeout(ctx)
+	defer cancel()
+
+	_, err := r.db.Conn(ctx).ExecContext(ctx, `
 		INSERT INTO users (first_name, email, password, created_at)
 		VALUES (?, ?, ?, NOW())`,
 		user.FirstName, user.Email, user.Password,
@@ -74,52 +88,82 @@ func (r *userRepo) Create(user model.User) error {
 	return err
 }
 
-func (r *userRepo) Update(u model.User) error {
-	_, err := r.db.Exec(`
+func (r *userRepo) Update(ctx context.Context, u model.User) error {
+	ctx, cancel := r.db.WithTimeout(ctx)
+	defer cancel()
+
+	_, err := r.db.Conn(ctx).ExecContext(ctx, `
 		UPDATE users 
-		SET first_name=?, lastname=?, phone_number=?, photo=?, role=?, updated_at=NOW()
+		SET first_name=?, lastname=?, phone_number=?, role=?, updated_at=NOW()
 		WHERE user_id=?`,
-		u.FirstName, u.LastName, u.PhoneNumber, u.Photo, u.Role, u.ID)
-	log.Printf("🔥 Update values: fname=%s, lname=%s, phone=%v", u.FirstName, u.LastName, u.PhoneNumber)
+		u.FirstName, u.LastName, u.PhoneNumber, u.Role, u.ID)
+	slog.DebugContext(ctx, "updating user", "user_id", u.ID)
 
 	return err
 }
 
-func (r *userRepo) Delete(id int64) error {
-	_, err := r.db.Exec("DELETE FROM users WHERE user_id=?", id)
-	return err
+func (r *userRepo) Delete(ctx context.Context, id int64) error {
+	ctx, cancel := r.db.WithTimeout(ctx)
+	defer cancel()
+
+	res, err := r.db.Conn(ctx).ExecContext(ctx, "DELETE FROM users WHERE user_id=?", id)
+	return database.RequireAffected(res, err, "User not found")
 }
 
-func (r *userRepo) GetByEmail(email string) (model.User, error) {
+func (r *userRepo) GetByEmail(ctx context.Context, email string) (model.User, error) {
+	ctx, cancel := r.db.WithTimeout(ctx)
+	defer cancel()
+
 	var u model.User
-	err := r.db.QueryRow(`
+	err := r.db.Conn(ctx).QueryRowContext(ctx, `
 	SELECT user_id, first_name, lastname, password, phone_number, email, photo, created_at, updated_at, role 
 	FROM users WHERE TRIM(LOWER(email)) = TRIM(LOWER(?))`, email).
 		Scan(&u.ID, &u.FirstName, &u.LastName, &u.Password, &u.PhoneNumber, &u.Email, &u.Photo, &u.CreatedAt, &u.UpdatedAt, &u.Role)
 
-	return u, err
+	return u, database.NotFound(err, "User not found")
 }
 
 // Update Email
-func (r *userRepo) UpdateEmail(id int64, email string) error {
-	_, err := r.db.Exec(`UPDATE users SET email = ?, updated_at = NOW() WHERE user_id = ?`, email, id)
+func (r *userRepo) UpdateEmail(ctx context.Context, id int64, email string) error {
+	ctx, cancel := r.db.WithTimeout(ctx)
+	defer cancel()
+
+	_, err := r.db.Conn(ctx).ExecContext(ctx, `UPDATE users SET email = ?, updated_at = NOW() WHERE user_id = ?`, email, id)
 	return err
 }
 
 // Update Password
-func (r *userRepo) UpdatePassword(id int64, hashedPassword string) error {
-	_, err := r.db.Exec(`UPDATE users SET password = ?, updated_at = NOW() WHERE user_id = ?`, hashedPassword, id)
+func (r *userRepo) UpdatePassword(ctx context.Context, id int64, hashedPassword string) error {
+	ctx, cancel := r.db.WithTimeout(ctx)
+	defer cancel()
+
+	_, err := r.db.Conn(ctx).ExecContext(ctx, `UPDATE users SET password = ?, updated_at = NOW() WHERE user_id = ?`, hashedPassword, id)
 	return err
 }
 
-func (r *userRepo) IsEmailTaken(email string, excludeID int64) (bool, error) {
+func (r *userRepo) IsEmailTaken(ctx context.Context, email string, excludeID int64) (bool, error) {
+	ctx, cancel := r.db.WithTimeout(ctx)
+	defer cancel()
+
 	var count int
-	err := r.db.QueryRow(`
+	err := r.db.Conn(ctx).QueryRowContext(ctx, `
 		SELECT COUNT(*) FROM users WHERE email = ? AND user_id != ?`, email, excludeID).Scan(&count)
 	return count > 0, err
 }
 
-func (r *userRepo) UpdateProfilePhoto(id int64, photoPath string) error {
-	_, err := r.db.Exec(`UPDATE users SET photo = ?, updated_at = NOW() WHERE user_id = ?`, photoPath, id)
-	return err
+func (r *userRepo) UpdateProfilePhoto(ctx context.Context, id int64, photoKey string) (string, error) {
+	ctx, cancel := r.db.WithTimeout(ctx)
+	defer cancel()
+
+	var previous sql.NullString
+	err := r.db.WithinTx(ctx, func(ctx context.Context) error {
+		// ✅ lock แถวไว้ให้รูปเดิมที่อ่านได้เป็นรูปที่ถูกแทนที่จริง (กัน upload พร้อมกันสองครั้ง)
+		err := r.db.Conn(ctx).QueryRowContext(ctx, `SELECT photo FROM users WHERE user_id = ? FOR UPDATE`, id).Scan(&previous)
+		if err != nil {
+			return database.NotFound(err, "User not found")
+		}
+		_, err = r.db.Conn(ctx).ExecContext(ctx, `UPDATE users SET photo = ?, updated_at = NOW() WHERE user_id = ?`, photoKey, id)
+		return err
+	})
+	return previous.String, err
 }
@@ -2,10 +2,12 @@
 package user
 
 import (
-	"database/sql"
-	"log"
-	"net/http"
-
+	"myapp/internal/auth"
+	"myapp/internal/config"
+	"myapp/internal/database"
+	reviewRepo "myapp/internal/review/repository"
+	"myapp/internal/scheduler"
+	"myapp/internal/storage"
 	"myapp/internal/user/handler"
 	"myapp/internal/user/repository"
 	"myapp/internal/user/routes/otpRoutes"
@@ -14,52 +16,101 @@ import (
 	"github.com/gorilla/mux"
 )
 
-func InitRouter(db *sql.DB) *mux.Router {
-	r := mux.NewRouter()
+// Module คือ user module (users, login, OTP, refresh token) สำหรับ mount ใน app
+type Module struct {
+	handler     *handler.UserHandler
+	authHandler *handler.AuthHandler
+	otpHandler  *handler.OTPHandler
+	tokens      *auth.TokenManager
+	cleanup     usecase.OTPCleanup
+	jobs        config.JobsConfig
+}
 
+func NewModule(db *database.DB, tokens *auth.TokenManager, cfg config.Config, files storage.Storage) *Module {
 	// ✅ Repository & Usecase
 	repo := repository.NewUserRepository(db)
 	otpRepo := repository.NewOTPRepository(db)
+	refreshRepo := repository.NewRefreshTokenRepository(db)
 
-	userUsecase := usecase.NewUserUsecase(repo)
-	emailSender := usecase.NewEmailSender()
-	otpUsecase := usecase.NewOTPUsecase(otpRepo, emailSender)
+	userUsecase := usecase.NewUserUsecase(db, repo, refreshRepo, reviewRepo.NewReviewRepository(db), files)
+	authUsecase := usecase.NewAuthUsecase(refreshRepo, repo, tokens, cfg.JWT.RefreshTTL)
+	emailSender := usecase.NewEmailSender(cfg.SMTP)
+	otpUsecase := usecase.NewOTPUsecase(otpRepo, emailSender, cfg.OTP.MaxAttempts)
+	attemptRepo := repository.NewOTPAttemptRepository(db)
+	otpGuard := usecase.NewOTPGuard(attemptRepo, cfg.OTP)
+	registration := usecase.NewRegistrationUsecase(db, repo, otpUsecase)
 
 	// ✅ Handler พร้อม OTP
-	h := handler.NewUserHandler(userUsecase, otpUsecase)
+	return &Module{
+		handler:     handler.NewUserHandler(userUsecase, otpUsecase, authUsecase, registration, otpGuard, files),
+		authHandler: handler.NewAuthHandler(authUsecase),
+		otpHandler:  handler.NewOTPHandler(otpUsecase, userUsecase, registration, otpGuard),
+		tokens:      tokens,
+		cleanup:     usecase.NewOTPCleanup(otpRepo, attemptRepo, cfg.OTP),
+		jobs:        cfg.Jobs,
+	}
+}
 
-	// ✅ OTP Routes
-	otpRoutes.RegisterOtpRoutes(r, db, userUsecase)
+func (m *Module) Name() string { return "user" }
 
-	// ✅ User CRUD
-	r.HandleFunc("/users", h.GetAll).Methods("GET")
-	r.HandleFunc("/users/{id}", h.GetByID).Methods("GET")
-	r.HandleFunc("/users/register", func(w http.ResponseWriter, r *http.Request) {
-		log.Println("🔥 Router matched /users/register [POST]")
-		h.Create(w, r)
-	}).Methods("POST")
+// Jobs คือ background job ของ user module (ล้าง OTP ที่หมดอายุ/ใช้แล้ว, metadata ที่ค้าง และ counter ของ rate limit ที่หมดผลแล้ว)
+func (m *Module) Jobs() []scheduler.Job {
+	return []scheduler.Job{
+		{
+			Name:     "otp_purge",
+			Interval: m.jobs.OTPCleanupInterval,
+			Jitter:   m.jobs.Jitter,
+			Timeout:  m.jobs.Timeout,
+			Run:      m.cleanup.PurgeOTPs,
+		},
+		{
+			Name:     "otp_metadata_purge",
+			Interval: m.jobs.OTPCleanupInterval,
+			Jitter:   m.jobs.Jitter,
+			Timeout:  m.jobs.Timeout,
+			Run:      m.cleanup.PurgeOrphanedMetadata,
+		},
+		{
+			Name:     "otp_attempts_purge",
+			Interval: m.jobs.OTPCleanupInterval,
+			Jitter:   m.jobs.Jitter,
+			Timeout:  m.jobs.Timeout,
+			Run:      m.cleanup.PurgeAttempts,
+		},
+	}
+}
+
+func (m *Module) RegisterRoutes(r *mux.Router) {
+	h := m.handler
+	authH := m.authHandler
 
-	r.HandleFunc("/users/{id}", h.Update).Methods("PUT")
-	r.HandleFunc("/users/{id}", h.Delete).Methods("DELETE")
+	// ✅ Public routes (ไม่ต้องใช้ token)
+	otpRoutes.RegisterOtpRoutes(r, m.otpHandler)
 
-	// ✅ Auth & User Updates
+	r.HandleFunc("/users/register", h.Create).Methods("POST")
 	r.HandleFunc("/login", h.Login).Methods("POST")
-	r.HandleFunc("/users/{id}/profile", h.UpdateProfile).Methods("PUT")
-	r.HandleFunc("/users/{id}/email", h.UpdateEmail).Methods("PUT")
-	r.HandleFunc("/users/{id}/password", h.UpdatePassword).Methods("PUT")
 	r.HandleFunc("/users/reset-password", h.ResetPassword).Methods("POST")
-	r.HandleFunc("/users/reset-password", h.UpdateProfilePhoto).Methods("PUT")
+	r.HandleFunc("/auth/refresh", authH.Refresh).Methods("POST")
+	r.HandleFunc("/auth/logout", authH.Logout).Methods("POST")
+
+	// ✅ Protected routes (ต้องมี Bearer token ที่ verify แล้ว)
+	protected := r.NewRoute().Subrouter()
+	protected.Use(auth.Middleware(m.tokens))
 
-	// r.Path("/users/profile-photo").Methods("PUT").HandlerFunc(h.UpdateProfilePhoto)
+	adminOnly := auth.Roles(auth.RoleAdmin)
+	selfOrAdmin := auth.AnyOf(auth.Self("id"), adminOnly)
 
-	// log.Println("✅ Route /users/profile-photo [PUT] registered")
+	// ✅ route ของ /users/me ต้องมาก่อน /users/{id} (mux ใช้ route แรกที่ match); {id} รับเฉพาะตัวเลข
+	protected.HandleFunc("/users/me/photo", h.UpdateProfilePhoto).Methods("PUT")
 
-	// r.Walk(func(route *mux.Route, router *mux.Router, ancestors []*mux.Route) error {
-	// 	path, _ := route.GetPathTemplate()
-	// 	methods, _ := route.GetMethods()
-	// 	log.Printf("🛣️      Route registered: %s [%s]\n", path, methods)
-	// 	return nil
-	// })
+	protected.Handle("/users", auth.Authorize(adminOnly, h.GetAll)).Methods("GET")
+	protected.Handle("/users/{id:[0-9]+}", auth.Authorize(selfOrAdmin, h.GetByID)).Methods("GET")
+	protected.Handle("/users/{id:[0-9]+}", auth.Authorize(adminOnly, h.Update)).Methods("PUT")
+	protected.Handle("/users/{id:[0-9]+}", auth.Authorize(adminOnly, h.Delete)).Methods("DELETE")
 
-	return r
+	// ✅ ผู้ใช้แก้ไขได้เฉพาะข้อมูลของตัวเอง
+	protected.Handle("/users/{id:[0-9]+}/profile", auth.Authorize(selfOrAdmin, h.UpdateProfile)).Methods("PUT")
+	protected.Handle("/users/{id:[0-9]+}/email", auth.Authorize(selfOrAdmin, h.UpdateEmail)).Methods("PUT")
+	protected.Handle("/users/{id:[0-9]+}/password", auth.Authorize(auth.Self("id"), h.UpdatePassword)).Methods("PUT")
+	protected.HandleFunc("/auth/logout-all", authH.LogoutAll).Methods("POST")
 }
@@ -0,0 +1,43 @@
+package user
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gorilla/mux"
+
+	"myapp/internal/user/handler"
+)
+
+func TestRoutesMatchExpectedTemplate(t *testing.T) {
+	m := &Module{handler: &handler.UserHandler{}, authHandler: &handler.AuthHandler{}, otpHandler: &handler.OTPHandler{}}
+	r := mux.NewRouter()
+	m.RegisterRoutes(r)
+
+	tests := []struct {
+		method, path string
+		want         string
+	}{
+		{http.MethodPut, "/users/me/photo", "/users/me/photo"},
+		{http.MethodPost, "/users/reset-password", "/users/reset-password"},
+		{http.MethodGet, "/users/42", "/users/{id:[0-9]+}"},
+		{http.MethodPut, "/users/42", "/users/{id:[0-9]+}"},
+		{http.MethodPut, "/users/42/profile", "/users/{id:[0-9]+}/profile"},
+		{http.MethodPut, "/users/me", ""},
+		{http.MethodGet, "/users/abc", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.method+" "+tt.path, func(t *testing.T) {
+			var match mux.RouteMatch
+			got := ""
+			if r.Match(httptest.NewRequest(tt.method, tt.path, nil), &match) && match.MatchErr == nil {
+				got, _ = match.Route.GetPathTemplate()
+			}
+			if got != tt.want {
+				t.Errorf("matched %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
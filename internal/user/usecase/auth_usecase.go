@@ -0,0 +1,170 @@
+package usecase
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"log/slog"
+	"time"
+
+	"myapp/internal/apperr"
+	"myapp/internal/auth"
+	"myapp/internal/user/model"
+	"myapp/internal/user/repository"
+)
+
+var (
+	ErrInvalidRefreshToken = apperr.Unauthorized("invalid_refresh_token", "Invalid or expired refresh token")
+	ErrRefreshTokenReused  = apperr.Unauthorized("refresh_token_reused", "Refresh token reuse detected, please log in again")
+)
+
+// TokenPair คือ access token อายุสั้น + refresh token สำหรับขอ access token ใหม่
+type TokenPair struct {
+	AccessToken  string `json:"token"`
+	RefreshToken string `json:"refresh_token"`
+	ExpiresIn    int64  `json:"expires_in"`
+}
+
+type AuthUsecase interface {
+	IssueTokens(ctx context.Context, user model.User) (TokenPair, error)
+	Refresh(ctx context.Context, refreshToken string) (TokenPair, error)
+	Logout(ctx context.Context, refreshToken string) error
+	LogoutAll(ctx context.Context, userID int64) error
+}
+
+type authUsecase struct {
+	refreshRepo repository.RefreshTokenRepository
+	userRepo    repository.UserRepository
+	tokens      *auth.TokenManager
+	refreshTTL  time.Duration
+}
+
+func NewAuthUsecase(refreshRepo repository.RefreshTokenRepository, userRepo repository.UserRepository, tokens *auth.TokenManager, refreshTTL time.Duration) AuthUsecase {
+	return &authUsecase{
+		refreshRepo: refreshRepo,
+		userRepo:    userRepo,
+		tokens:      tokens,
+		refreshTTL:  refreshTTL,
+	}
+}
+
+// IssueTokens ใช้ตอน Login: เริ่ม token family ใหม่
+func (u *authUsecase) IssueTokens(ctx context.Context, user model.User) (TokenPair, error) {
+	familyID, err := randomToken(16)
+	if err != nil {
+		return TokenPair{}, err
+	}
+	pair, _, err := u.issue(ctx, user, familyID)
+	return pair, err
+}
+
+// Refresh หมุน refresh token: token เดิมใช้ไม่ได้อีก, ถ้ามีการใช้ซ้ำจะ revoke ทั้ง family
+func (u *authUsecase) Refresh(ctx context.Context, refreshToken string) (TokenPair, error) {
+	stored, err := u.refreshRepo.GetByHash(ctx, hashToken(refreshToken))
+	if errors.Is(err, apperr.ErrNotFound) {
+		return TokenPair{}, ErrInvalidRefreshToken
+	}
+	if err != nil {
+		return TokenPair{}, err
+	}
+
+	if stored.RevokedAt != nil {
+		slog.WarnContext(ctx, "refresh token reuse detected", "user_id", stored.UserID, "family_id", stored.FamilyID)
+		if err := u.refreshRepo.RevokeFamily(ctx, stored.FamilyID); err != nil {
+			return TokenPair{}, err
+		}
+		return TokenPair{}, ErrRefreshTokenReused
+	}
+	if time.Now().After(stored.ExpiresAt) {
+		return TokenPair{}, ErrInvalidRefreshToken
+	}
+
+	user, err := u.userRepo.GetByID(ctx, stored.UserID)
+	if errors.Is(err, apperr.ErrNotFound) {
+		return TokenPair{}, ErrInvalidRefreshToken
+	}
+	if err != nil {
+		return TokenPair{}, err
+	}
+
+	pair, newID, err := u.issue(ctx, user, stored.FamilyID)
+	if err != nil {
+		return TokenPair{}, err
+	}
+
+	// ✅ ถ้ามี request อื่น rotate token นี้ไปก่อนแล้ว ถือว่าเป็นการใช้ซ้ำ
+	rotated, err := u.refreshRepo.Rotate(ctx, stored.ID, newID)
+	if err != nil {
+		return TokenPair{}, err
+	}
+	if !rotated {
+		if err := u.refreshRepo.RevokeFamily(ctx, stored.FamilyID); err != nil {
+			return TokenPair{}, err
+		}
+		return TokenPair{}, ErrRefreshTokenReused
+	}
+	return pair, nil
+}
+
+func (u *authUsecase) Logout(ctx context.Context, refreshToken string) error {
+	stored, err := u.refreshRepo.GetByHash(ctx, hashToken(refreshToken))
+	if errors.Is(err, apperr.ErrNotFound) {
+		return ErrInvalidRefreshToken
+	}
+	if err != nil {
+		return err
+	}
+	return u.refreshRepo.RevokeFamily(ctx, stored.FamilyID)
+}
+
+func (u *authUsecase) LogoutAll(ctx context.Context, userID int64) error {
+	return u.refreshRepo.RevokeAllForUser(ctx, userID)
+}
+
+func (u *authUsecase) issue(ctx context.Context, user model.User, familyID string) (TokenPair, int64, error) {
+	access, err := u.tokens.Issue(auth.Principal{
+		UserID: user.ID,
+		Email:  user.Email,
+		Role:   user.Role,
+	})
+	if err != nil {
+		return TokenPair{}, 0, err
+	}
+
+	refresh, err := randomToken(32)
+	if err != nil {
+		return TokenPair{}, 0, err
+	}
+
+	id, err := u.refreshRepo.Create(ctx, model.RefreshToken{
+		UserID:    user.ID,
+		TokenHash: hashToken(refresh),
+		FamilyID:  familyID,
+		ExpiresAt: time.Now().Add(u.refreshTTL),
+	})
+	if err != nil {
+		return TokenPair{}, 0, err
+	}
+
+	return TokenPair{
+		AccessToken:  access,
+		RefreshToken: refresh,
+		ExpiresIn:    int64(u.tokens.TTL().Seconds()),
+	}, id, nil
+}
+
+func randomToken(n int) (string, error) {
+	b := make([]byte, n)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+func hashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
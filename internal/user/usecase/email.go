@@ -1,8 +1,9 @@
 package usecase
 
 import (
+	"context"
+	"myapp/internal/config"
 	"net/smtp"
-	"os"
 )
 
 type SMTPEmailSender struct {
@@ -13,7 +14,11 @@ type SMTPEmailSender struct {
 }
 
 // ✅ implement EmailSender interface
-func (s *SMTPEmailSender) Send(to, subject, body string) error {
+func (s *SMTPEmailSender) Send(ctx context.Context, to, subject, body string) error {
+	// net/smtp ไม่รองรับ context: อย่างน้อยไม่เริ่มส่งถ้า request ถูกยกเลิกไปแล้ว
+	if err := ctx.Err(); err != nil {
+		return err
+	}
 	addr := s.Host + ":" + s.Port
 	auth := smtp.PlainAuth("", s.From, s.Password, s.Host)
 
@@ -24,12 +29,12 @@ func (s *SMTPEmailSender) Send(to, subject, body string) error {
 	return smtp.SendMail(addr, auth, s.From, []string{to}, msg)
 }
 
-// ✅ สร้าง constructor แบบโหลดจาก .env
-func NewEmailSender() EmailSender {
+// ✅ สร้าง constructor จาก config ที่โหลดตอน startup
+func NewEmailSender(cfg config.SMTPConfig) EmailSender {
 	return &SMTPEmailSender{
-		From:     os.Getenv("SMTP_FROM"),
-		Password: os.Getenv("SMTP_PASSWORD"),
-		Host:     os.Getenv("SMTP_HOST"),
-		Port:     os.Getenv("SMTP_PORT"),
+		From:     cfg.From,
+		Password: cfg.Password,
+		Host:     cfg.Host,
+		Port:     cfg.Port,
 	}
 }
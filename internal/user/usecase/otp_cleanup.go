@@ -0,0 +1,70 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	"myapp/internal/config"
+	"myapp/internal/user/repository"
+)
+
+// cleanupBatchSize คือจำนวนแถวสูงสุดที่ลบต่อหนึ่ง statement
+const cleanupBatchSize = 500
+
+// OTPCleanup ลบข้อมูล OTP ที่ไม่ใช้แล้ว สำหรับรันเป็น background job
+type OTPCleanup interface {
+	PurgeOTPs(ctx context.Context) (int64, error)
+	PurgeOrphanedMetadata(ctx context.Context) (int64, error)
+	PurgeAttempts(ctx context.Context) (int64, error)
+}
+
+type otpCleanup struct {
+	repo     repository.OTPRepository
+	attempts repository.OTPAttemptRepository
+	// attemptTTL คือเวลาที่ counter ยังมีผล: window ที่ยาวที่สุด + lockout
+	attemptTTL time.Duration
+}
+
+func NewOTPCleanup(repo repository.OTPRepository, attempts repository.OTPAttemptRepository, cfg config.OTPConfig) OTPCleanup {
+	return &otpCleanup{
+		repo:       repo,
+		attempts:   attempts,
+		attemptTTL: max(cfg.SendWindow, cfg.VerifyWindow) + cfg.LockoutDuration,
+	}
+}
+
+// PurgeOTPs ลบ OTP ที่หมดอายุหรือ verify แล้ว
+func (c *otpCleanup) PurgeOTPs(ctx context.Context) (int64, error) {
+	return purgeInBatches(ctx, c.repo.DeleteStale)
+}
+
+// PurgeOrphanedMetadata ลบ otp_metadata ที่ไม่มี OTP คู่กันเหลืออยู่
+func (c *otpCleanup) PurgeOrphanedMetadata(ctx context.Context) (int64, error) {
+	return purgeInBatches(ctx, c.repo.DeleteOrphanedMetadata)
+}
+
+// PurgeAttempts ลบ counter ของ otp_attempts ที่เลยทั้ง window และ lockout ไปแล้ว
+func (c *otpCleanup) PurgeAttempts(ctx context.Context) (int64, error) {
+	before := time.Now().Add(-c.attemptTTL)
+	return purgeInBatches(ctx, func(ctx context.Context, limit int) (int64, error) {
+		return c.attempts.DeleteStale(ctx, before, limit)
+	})
+}
+
+// purgeInBatches เรียก del ซ้ำจนกว่าจะลบได้น้อยกว่าหนึ่ง batch หรือ ctx ถูกยกเลิก
+func purgeInBatches(ctx context.Context, del func(ctx context.Context, limit int) (int64, error)) (int64, error) {
+	var total int64
+	for {
+		n, err := del(ctx, cleanupBatchSize)
+		total += n
+		if err != nil {
+			return total, err
+		}
+		if n < cleanupBatchSize {
+			return total, nil
+		}
+		if err := ctx.Err(); err != nil {
+			return total, err
+		}
+	}
+}
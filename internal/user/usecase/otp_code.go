@@ -0,0 +1,41 @@
+package usecase
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"fmt"
+	"math/big"
+)
+
+const otpDigits = 6
+
+var otpMax = big.NewInt(1_000_000)
+
+// GenerateRandomOTP สร้างเลข 6 หลักจาก crypto/rand
+func GenerateRandomOTP() (string, error) {
+	n, err := rand.Int(rand.Reader, otpMax)
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%0*d", otpDigits, n.Int64()), nil
+}
+
+func newOTPSalt() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
+
+func hashOTP(salt, code string) string {
+	sum := sha256.Sum256([]byte(salt + ":" + code))
+	return hex.EncodeToString(sum[:])
+}
+
+// otpMatches เทียบ hash แบบ constant time
+func otpMatches(salt, storedHash, code string) bool {
+	return subtle.ConstantTimeCompare([]byte(hashOTP(salt, code)), []byte(storedHash)) == 1
+}
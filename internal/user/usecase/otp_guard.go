@@ -0,0 +1,121 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"strings"
+	"time"
+
+	"myapp/internal/apperr"
+	"myapp/internal/config"
+	"myapp/internal/logging"
+	"myapp/internal/user/repository"
+)
+
+// OTPGuard จำกัดการขอ OTP (cooldown + limit ต่อ email/IP) และล็อกการตรวจ OTP เมื่อเดาผิดบ่อย
+type OTPGuard interface {
+	AllowSend(ctx context.Context, email, ip string) error
+	Verify(ctx context.Context, email, ip string, verify func(ctx context.Context) error) error
+}
+
+type otpGuard struct {
+	repo repository.OTPAttemptRepository
+	cfg  config.OTPConfig
+	now  func() time.Time
+}
+
+func NewOTPGuard(repo repository.OTPAttemptRepository, cfg config.OTPConfig) OTPGuard {
+	return &otpGuard{repo: repo, cfg: cfg, now: time.Now}
+}
+
+func (g *otpGuard) AllowSend(ctx context.Context, email, ip string) error {
+	now := g.now().UTC()
+	emailBucket := bucket("send", "email", email)
+	ipBucket := bucket("send", "ip", ip)
+
+	if err := g.checkLocked(ctx, now, emailBucket, ipBucket); err != nil {
+		return err
+	}
+
+	// ✅ resend cooldown ต่อ email
+	last, err := g.repo.Get(ctx, emailBucket)
+	if err != nil {
+		return err
+	}
+	if last.Hits > 0 {
+		if wait := last.LastHitAt.Add(g.cfg.SendCooldown).Sub(now); wait > 0 {
+			return apperr.RateLimited(wait)
+		}
+	}
+
+	if err := g.hit(ctx, now, emailBucket, g.cfg.SendWindow, g.cfg.SendLimitPerEmail); err != nil {
+		return err
+	}
+	return g.hit(ctx, now, ipBucket, g.cfg.SendWindow, g.cfg.SendLimitPerIP)
+}
+
+// Verify ครอบการตรวจ OTP: ปฏิเสธถ้าถูกล็อกอยู่, นับการเดาผิด และล้าง counter เมื่อสำเร็จ
+func (g *otpGuard) Verify(ctx context.Context, email, ip string, verify func(ctx context.Context) error) error {
+	now := g.now().UTC()
+	emailBucket := bucket("verify", "email", email)
+	ipBucket := bucket("verify", "ip", ip)
+
+	if err := g.checkLocked(ctx, now, emailBucket, ipBucket); err != nil {
+		return err
+	}
+
+	err := verify(ctx)
+	if err == nil {
+		if rerr := g.repo.Reset(ctx, emailBucket); rerr != nil {
+			slog.WarnContext(ctx, "failed to reset OTP verify counter", logging.Err(rerr))
+		}
+		return nil
+	}
+	if !errors.Is(err, ErrInvalidOTP) {
+		return err
+	}
+
+	if herr := g.hit(ctx, now, emailBucket, g.cfg.VerifyWindow, g.cfg.VerifyLimitPerEmail); herr != nil {
+		return herr
+	}
+	if herr := g.hit(ctx, now, ipBucket, g.cfg.VerifyWindow, g.cfg.VerifyLimitPerIP); herr != nil {
+		return herr
+	}
+	return err
+}
+
+func (g *otpGuard) checkLocked(ctx context.Context, now time.Time, buckets ...string) error {
+	for _, b := range buckets {
+		a, err := g.repo.Get(ctx, b)
+		if err != nil {
+			return err
+		}
+		if a.LockedUntil != nil && a.LockedUntil.After(now) {
+			return apperr.RateLimited(a.LockedUntil.Sub(now))
+		}
+	}
+	return nil
+}
+
+// hit นับ 1 ครั้ง; ถ้าเกิน limit จะล็อก bucket ตาม LockoutDuration แล้วคืน apperr rate limited
+func (g *otpGuard) hit(ctx context.Context, now time.Time, b string, window time.Duration, limit int) error {
+	a, err := g.repo.Hit(ctx, b, now, window)
+	if err != nil {
+		return err
+	}
+	if a.Hits <= limit {
+		return nil
+	}
+
+	until := now.Add(g.cfg.LockoutDuration)
+	if err := g.repo.Lock(ctx, b, until); err != nil {
+		return err
+	}
+	slog.WarnContext(ctx, "OTP throttled", "bucket", b, "hits", a.Hits, "locked_until", until)
+	return apperr.RateLimited(g.cfg.LockoutDuration)
+}
+
+func bucket(kind, scope, key string) string {
+	return kind + ":" + scope + ":" + strings.ToLower(strings.TrimSpace(key))
+}
@@ -0,0 +1,261 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"myapp/internal/apperr"
+	"myapp/internal/config"
+	"myapp/internal/user/model"
+)
+
+// memAttempts คือ OTPAttemptRepository ในหน่วยความจำ ที่นับ window แบบเดียวกับ MySQL
+type memAttempts struct {
+	rows map[string]model.OTPAttempt
+}
+
+func newMemAttempts() *memAttempts {
+	return &memAttempts{rows: map[string]model.OTPAttempt{}}
+}
+
+func (m *memAttempts) Get(ctx context.Context, bucket string) (model.OTPAttempt, error) {
+	if a, ok := m.rows[bucket]; ok {
+		return a, nil
+	}
+	return model.OTPAttempt{Bucket: bucket}, nil
+}
+
+func (m *memAttempts) Hit(ctx context.Context, bucket string, now time.Time, window time.Duration) (model.OTPAttempt, error) {
+	a, ok := m.rows[bucket]
+	if !ok || a.WindowStartedAt.Before(now.Add(-window)) {
+		a = model.OTPAttempt{Bucket: bucket, WindowStartedAt: now, LockedUntil: a.LockedUntil}
+	}
+	a.Hits++
+	a.LastHitAt = now
+	m.rows[bucket] = a
+	return a, nil
+}
+
+func (m *memAttempts) Lock(ctx context.Context, bucket string, until time.Time) error {
+	a := m.rows[bucket]
+	a.LockedUntil = &until
+	m.rows[bucket] = a
+	return nil
+}
+
+func (m *memAttempts) Reset(ctx context.Context, bucket string) error {
+	delete(m.rows, bucket)
+	return nil
+}
+
+func (m *memAttempts) DeleteStale(ctx context.Context, before time.Time, limit int) (int64, error) {
+	return 0, nil
+}
+
+// clock คือเวลาที่เลื่อนเองได้ในเทสต์
+type clock struct{ t time.Time }
+
+func (c *clock) now() time.Time          { return c.t }
+func (c *clock) advance(d time.Duration) { c.t = c.t.Add(d) }
+
+var guardCfg = config.OTPConfig{
+	SendCooldown:        time.Minute,
+	SendWindow:          time.Hour,
+	SendLimitPerEmail:   3,
+	SendLimitPerIP:      5,
+	VerifyWindow:        15 * time.Minute,
+	VerifyLimitPerEmail: 3,
+	VerifyLimitPerIP:    5,
+	LockoutDuration:     30 * time.Minute,
+}
+
+func newTestGuard() (*otpGuard, *memAttempts, *clock) {
+	repo := newMemAttempts()
+	c := &clock{t: time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)}
+	return &otpGuard{repo: repo, cfg: guardCfg, now: c.now}, repo, c
+}
+
+// retryAfter คืนค่า RetryAfter ถ้า err เป็น rate limited, ไม่เช่นนั้นคืน -1
+func retryAfter(err error) time.Duration {
+	if e, ok := apperr.As(err); ok && e.Kind == apperr.KindRateLimited {
+		return e.RetryAfter
+	}
+	return -1
+}
+
+func TestAllowSend(t *testing.T) {
+	type step struct {
+		advance   time.Duration
+		email, ip string
+		wantRetry time.Duration // 0 = ผ่าน
+	}
+	tests := []struct {
+		name  string
+		steps []step
+	}{
+		{
+			name: "cooldown between sends to the same email",
+			steps: []step{
+				{email: "a@example.com", ip: "1.1.1.1"},
+				{advance: 20 * time.Second, email: "a@example.com", ip: "1.1.1.1", wantRetry: 40 * time.Second},
+				{advance: 40 * time.Second, email: "a@example.com", ip: "1.1.1.1"},
+			},
+		},
+		{
+			name: "email bucket is case and space insensitive",
+			steps: []step{
+				{email: "a@example.com", ip: "1.1.1.1"},
+				{email: " A@Example.com ", ip: "2.2.2.2", wantRetry: time.Minute},
+			},
+		},
+		{
+			name: "cooldown is per email",
+			steps: []step{
+				{email: "a@example.com", ip: "1.1.1.1"},
+				{email: "b@example.com", ip: "1.1.1.1"},
+			},
+		},
+		{
+			name: "per-email limit locks the email",
+			steps: []step{
+				{email: "a@example.com", ip: "1.1.1.1"},
+				{advance: time.Minute, email: "a@example.com", ip: "1.1.1.2"},
+				{advance: time.Minute, email: "a@example.com", ip: "1.1.1.3"},
+				{advance: time.Minute, email: "a@example.com", ip: "1.1.1.4", wantRetry: 30 * time.Minute},
+				{advance: 10 * time.Minute, email: "a@example.com", ip: "1.1.1.5", wantRetry: 20 * time.Minute},
+				{email: "b@example.com", ip: "1.1.1.5"},
+			},
+		},
+		{
+			name: "per-ip limit locks the ip for every email",
+			steps: []step{
+				{email: "a1@example.com", ip: "9.9.9.9"},
+				{email: "a2@example.com", ip: "9.9.9.9"},
+				{email: "a3@example.com", ip: "9.9.9.9"},
+				{email: "a4@example.com", ip: "9.9.9.9"},
+				{email: "a5@example.com", ip: "9.9.9.9"},
+				{email: "a6@example.com", ip: "9.9.9.9", wantRetry: 30 * time.Minute},
+				{advance: time.Minute, email: "a7@example.com", ip: "9.9.9.9", wantRetry: 29 * time.Minute},
+				{email: "a7@example.com", ip: "8.8.8.8"},
+			},
+		},
+		{
+			name: "lock expires after the lockout duration",
+			steps: []step{
+				{email: "a@example.com", ip: "1.1.1.1"},
+				{advance: time.Minute, email: "a@example.com", ip: "1.1.1.1"},
+				{advance: time.Minute, email: "a@example.com", ip: "1.1.1.1"},
+				{advance: time.Minute, email: "a@example.com", ip: "1.1.1.1", wantRetry: 30 * time.Minute},
+				{advance: 61 * time.Minute, email: "a@example.com", ip: "1.1.1.1"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g, _, c := newTestGuard()
+			for i, s := range tt.steps {
+				c.advance(s.advance)
+				err := g.AllowSend(context.Background(), s.email, s.ip)
+				if s.wantRetry == 0 {
+					if err != nil {
+						t.Fatalf("step %d: unexpected error %v", i, err)
+					}
+					continue
+				}
+				if got := retryAfter(err); got != s.wantRetry {
+					t.Fatalf("step %d: retry after = %v (err %v), want %v", i, got, err, s.wantRetry)
+				}
+			}
+		})
+	}
+}
+
+func TestVerify(t *testing.T) {
+	ctx := context.Background()
+	wrong := func(ctx context.Context) error { return ErrInvalidOTP }
+	right := func(ctx context.Context) error { return nil }
+
+	t.Run("wrong guesses lock the email", func(t *testing.T) {
+		g, _, c := newTestGuard()
+		for i := 0; i < guardCfg.VerifyLimitPerEmail; i++ {
+			if err := g.Verify(ctx, "a@example.com", "1.1.1.1", wrong); !errors.Is(err, ErrInvalidOTP) {
+				t.Fatalf("guess %d: err = %v, want ErrInvalidOTP", i, err)
+			}
+		}
+		if got := retryAfter(g.Verify(ctx, "a@example.com", "1.1.1.1", wrong)); got != 30*time.Minute {
+			t.Fatalf("retry after = %v, want 30m", got)
+		}
+
+		// ระหว่างล็อก แม้รหัสถูกก็ไม่เรียก verify
+		c.advance(5 * time.Minute)
+		called := false
+		err := g.Verify(ctx, "a@example.com", "2.2.2.2", func(ctx context.Context) error { called = true; return nil })
+		if called || retryAfter(err) != 25*time.Minute {
+			t.Fatalf("locked verify: called = %v, err = %v", called, err)
+		}
+
+		if err := g.Verify(ctx, "b@example.com", "2.2.2.2", right); err != nil {
+			t.Fatalf("other email: %v", err)
+		}
+	})
+
+	t.Run("wrong guesses from one ip lock the ip", func(t *testing.T) {
+		g, _, _ := newTestGuard()
+		emails := []string{"a@example.com", "b@example.com", "c@example.com", "d@example.com", "e@example.com"}
+		for _, e := range emails {
+			if err := g.Verify(ctx, e, "9.9.9.9", wrong); !errors.Is(err, ErrInvalidOTP) {
+				t.Fatalf("%s: err = %v, want ErrInvalidOTP", e, err)
+			}
+		}
+		if got := retryAfter(g.Verify(ctx, "f@example.com", "9.9.9.9", wrong)); got != 30*time.Minute {
+			t.Fatalf("retry after = %v, want 30m", got)
+		}
+		if got := retryAfter(g.Verify(ctx, "f@example.com", "9.9.9.9", right)); got != 30*time.Minute {
+			t.Fatalf("locked ip: retry after = %v, want 30m", got)
+		}
+		if err := g.Verify(ctx, "f@example.com", "8.8.8.8", right); err != nil {
+			t.Fatalf("other ip: %v", err)
+		}
+	})
+
+	t.Run("success resets the email counter", func(t *testing.T) {
+		g, repo, _ := newTestGuard()
+		for i := 0; i < guardCfg.VerifyLimitPerEmail; i++ {
+			_ = g.Verify(ctx, "a@example.com", "1.1.1.1", wrong)
+		}
+		if err := g.Verify(ctx, "a@example.com", "1.1.1.1", right); err != nil {
+			t.Fatalf("verify: %v", err)
+		}
+		if a, _ := repo.Get(ctx, bucket("verify", "email", "a@example.com")); a.Hits != 0 {
+			t.Fatalf("email hits = %d after success, want 0", a.Hits)
+		}
+		if err := g.Verify(ctx, "a@example.com", "1.1.1.1", wrong); !errors.Is(err, ErrInvalidOTP) {
+			t.Fatalf("err = %v, want ErrInvalidOTP", err)
+		}
+	})
+
+	t.Run("guesses outside the window start a new count", func(t *testing.T) {
+		g, _, c := newTestGuard()
+		for i := 0; i < guardCfg.VerifyLimitPerEmail; i++ {
+			_ = g.Verify(ctx, "a@example.com", "1.1.1.1", wrong)
+		}
+		c.advance(guardCfg.VerifyWindow + time.Second)
+		if err := g.Verify(ctx, "a@example.com", "1.1.1.1", wrong); !errors.Is(err, ErrInvalidOTP) {
+			t.Fatalf("err = %v, want ErrInvalidOTP", err)
+		}
+	})
+
+	t.Run("other errors are not counted", func(t *testing.T) {
+		g, repo, _ := newTestGuard()
+		boom := errors.New("db down")
+		if err := g.Verify(ctx, "a@example.com", "1.1.1.1", func(ctx context.Context) error { return boom }); !errors.Is(err, boom) {
+			t.Fatalf("err = %v, want %v", err, boom)
+		}
+		if len(repo.rows) != 0 {
+			t.Fatalf("rows = %v, want none", repo.rows)
+		}
+	})
+}
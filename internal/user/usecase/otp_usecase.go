@@ -1,80 +1,140 @@
 package usecase
 
 import (
-	"errors"
-	"fmt"
-	"log"
+	"context"
+	"myapp/internal/apperr"
+	"myapp/internal/user/model"
 	"myapp/internal/user/repository"
 	"time"
 )
 
+var (
+	ErrInvalidOTP = apperr.Unauthorized("invalid_otp", "Invalid or expired OTP")
+	// ErrOTPDelivery: บันทึก OTP แล้วแต่ส่งอีเมลไม่สำเร็จ
+	ErrOTPDelivery = apperr.Unavailable("otp_delivery_failed", "Failed to send OTP, please try again")
+)
+
 type OTPUsecase interface {
-	SendOTP(email, action string) error
-	SendOTPWithMetadata(email, action string, metadata map[string]string) error
-	VerifyOTP(email, otp, action string) error
-	VerifyAndGetMetadata(email, otp, action string) (map[string]string, error)
+	SendOTP(ctx context.Context, email, action string) error
+	// IssueOTP บันทึก OTP แล้วคืนรหัสโดยยังไม่ส่ง ใช้ใน transaction แล้วค่อย DeliverOTP หลัง commit
+	IssueOTP(ctx context.Context, email, action string) (string, error)
+	DeliverOTP(ctx context.Context, email, action, otp string) error
+	SendOTPWithMetadata(ctx context.Context, email, action string, metadata map[string]string) error
+	VerifyOTP(ctx context.Context, email, otp, action string) error
+	VerifyAndGetMetadata(ctx context.Context, email, otp, action string) (map[string]string, error)
 }
 
 type otpUsecase struct {
 	repo        repository.OTPRepository
 	emailSender EmailSender // ✅ Interface สำหรับส่งอีเมล (mock/test ได้)
+	maxAttempts int         // ✅ เดาผิดครบกี่ครั้งแล้ว OTP ใช้ไม่ได้อีก
 }
 
-func NewOTPUsecase(repo repository.OTPRepository, sender EmailSender) OTPUsecase {
-	return &otpUsecase{repo: repo, emailSender: sender}
+func NewOTPUsecase(repo repository.OTPRepository, sender EmailSender, maxAttempts int) OTPUsecase {
+	return &otpUsecase{repo: repo, emailSender: sender, maxAttempts: maxAttempts}
 }
-func (u *otpUsecase) SendOTP(email, action string) error {
-	otp := GenerateRandomOTP()                         // ✅ สร้าง OTP 6 หลัก
-	expiresAt := time.Now().Add(5 * time.Minute).UTC() // ✅ หมดอายุใน 5 นาที
-
-	err := u.repo.SaveOTP(email, otp, action, expiresAt)
+func (u *otpUsecase) SendOTP(ctx context.Context, email, action string) error {
+	otp, err := u.IssueOTP(ctx, email, action)
 	if err != nil {
 		return err
 	}
-	log.Printf("🕒 Local time now: %s", time.Now().Format(time.RFC3339))
-	log.Printf("🕒 UTC time now  : %s", time.Now().UTC().Format(time.RFC3339))
 
 	// ✅ ส่งอีเมลจริง พร้อม action ในเนื้อหา
-	return u.emailSender.Send(email, "Your OTP Code", "Your OTP for "+action+" is: "+otp)
+	return u.DeliverOTP(ctx, email, action, otp)
+}
+
+func (u *otpUsecase) IssueOTP(ctx context.Context, email, action string) (string, error) {
+	otp, record, err := newOTP(email, action) // ✅ สร้าง OTP 6 หลัก หมดอายุใน 5 นาที
+	if err != nil {
+		return "", err
+	}
+	if err := u.repo.SaveOTP(ctx, record); err != nil {
+		return "", err
+	}
+	return otp, nil
 }
 
-func (u *otpUsecase) VerifyOTP(email, otp, action string) error {
-	valid, err := u.repo.VerifyOTP(email, otp, action)
+func (u *otpUsecase) VerifyOTP(ctx context.Context, email, otp, action string) error {
+	candidates, err := u.repo.FindActive(ctx, email, action, u.maxAttempts)
 	if err != nil {
 		return err
 	}
-	if !valid {
-		return errors.New("Invalid or expired OTP")
+
+	// ✅ เทียบทุกแถวแบบ constant time ไม่หยุดที่แถวแรกที่ตรง
+	var matched int64
+	for _, c := range candidates {
+		if otpMatches(c.CodeSalt, c.CodeHash, otp) && matched == 0 {
+			matched = c.ID
+		}
+	}
+	if matched == 0 {
+		if len(candidates) > 0 {
+			if err := u.repo.IncrementAttempts(ctx, email, action); err != nil {
+				return err
+			}
+		}
+		return ErrInvalidOTP
+	}
+
+	ok, err := u.repo.MarkVerified(ctx, matched)
+	if err != nil {
+		return err
 	}
-	return u.repo.MarkVerified(email, otp, action)
+	if !ok {
+		return ErrInvalidOTP
+	}
+	return nil
 }
 
 // EmailSender interface
 
 type EmailSender interface {
-	Send(to, subject, body string) error
+	Send(ctx context.Context, to, subject, body string) error
 }
 
-// GenerateRandomOTP สร้างเลข 6 หลักแบบสุ่ม
-func GenerateRandomOTP() string {
-	r := time.Now().UnixNano() % 1000000
-	return fmt.Sprintf("%06d", r)
+// newOTP คืนรหัสจริง (สำหรับส่งอีเมลเท่านั้น) และ record ที่มีแต่ hash สำหรับบันทึก
+func newOTP(email, action string) (string, model.OTP, error) {
+	code, err := GenerateRandomOTP()
+	if err != nil {
+		return "", model.OTP{}, err
+	}
+	salt, err := newOTPSalt()
+	if err != nil {
+		return "", model.OTP{}, err
+	}
+	return code, model.OTP{
+		Email:     email,
+		Action:    action,
+		CodeHash:  hashOTP(salt, code),
+		CodeSalt:  salt,
+		ExpiresAt: time.Now().Add(5 * time.Minute).UTC(),
+	}, nil
 }
-func (u *otpUsecase) VerifyAndGetMetadata(email, otp, action string) (map[string]string, error) {
-	if err := u.VerifyOTP(email, otp, action); err != nil {
+
+func (u *otpUsecase) VerifyAndGetMetadata(ctx context.Context, email, otp, action string) (map[string]string, error) {
+	if err := u.VerifyOTP(ctx, email, otp, action); err != nil {
 		return nil, err
 	}
-	return u.repo.GetOTPMetadata(email, action)
+	return u.repo.GetOTPMetadata(ctx, email, action)
 }
-func (u *otpUsecase) SendOTPWithMetadata(email, action string, metadata map[string]string) error {
-	otp := GenerateRandomOTP()
-	expiresAt := time.Now().Add(5 * time.Minute).UTC()
-
-	err := u.repo.SaveOTPWithMetadata(email, otp, action, expiresAt, metadata)
+func (u *otpUsecase) SendOTPWithMetadata(ctx context.Context, email, action string, metadata map[string]string) error {
+	otp, record, err := newOTP(email, action)
 	if err != nil {
 		return err
 	}
 
+	if err := u.repo.SaveOTPWithMetadata(ctx, record, metadata); err != nil {
+		return err
+	}
+
 	// ส่ง OTP ทาง Email
-	return u.emailSender.Send(email, "Your OTP Code", "Your OTP for "+action+" is: "+otp)
+	return u.DeliverOTP(ctx, email, action, otp)
+}
+
+// DeliverOTP ส่งรหัสทางอีเมล; ส่งไม่สำเร็จคืน ErrOTPDelivery (OTP ที่บันทึกไว้ยังใช้ได้)
+func (u *otpUsecase) DeliverOTP(ctx context.Context, email, action, otp string) error {
+	if err := u.emailSender.Send(ctx, email, "Your OTP Code", "Your OTP for "+action+" is: "+otp); err != nil {
+		return ErrOTPDelivery.Wrap(err)
+	}
+	return nil
 }
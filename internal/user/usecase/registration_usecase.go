@@ -0,0 +1,94 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+
+	"myapp/internal/apperr"
+	"myapp/internal/database"
+	"myapp/internal/logging"
+	"myapp/internal/user/model"
+	"myapp/internal/user/repository"
+
+	"golang.org/x/crypto/bcrypt"
+)
+
+var ErrMissingRegistrationData = apperr.Invalid("Missing required registration data")
+
+// RegistrationUsecase รวมขั้นตอนสมัครสมาชิกที่ต้องเขียนหลายตารางไว้ใน transaction เดียว
+type RegistrationUsecase interface {
+	Register(ctx context.Context, user model.User) error
+	ConfirmRegister(ctx context.Context, email, otp string) error
+}
+
+type registrationUsecase struct {
+	tx       database.Transactor
+	userRepo repository.UserRepository
+	otp      OTPUsecase
+}
+
+func NewRegistrationUsecase(tx database.Transactor, userRepo repository.UserRepository, otp OTPUsecase) RegistrationUsecase {
+	return &registrationUsecase{tx: tx, userRepo: userRepo, otp: otp}
+}
+
+// Register สร้าง user + OTP verify_email ใน transaction เดียว แล้วส่งอีเมลหลัง commit
+// (ไม่ถือ transaction ค้างระหว่างรอ SMTP); ถ้าส่งอีเมลไม่สำเร็จ user ยังถูกสร้าง (ขอ OTP ใหม่ได้)
+func (u *registrationUsecase) Register(ctx context.Context, user model.User) error {
+	var otp string
+	err := u.tx.WithinTx(ctx, func(ctx context.Context) error {
+		if err := u.userRepo.Create(ctx, user); database.IsDuplicate(err) {
+			return ErrEmailTaken
+		} else if err != nil {
+			return err
+		}
+		var err error
+		otp, err = u.otp.IssueOTP(ctx, user.Email, "verify_email")
+		return err
+	})
+	if err != nil {
+		return err
+	}
+
+	if err := u.otp.DeliverOTP(ctx, user.Email, "verify_email", otp); err != nil {
+		slog.WarnContext(ctx, "failed to deliver registration OTP", "email", user.Email, logging.Err(err))
+	}
+	return nil
+}
+
+// ConfirmRegister ตรวจ OTP แล้วสร้าง user จาก metadata; ถ้าสร้าง user ไม่สำเร็จ OTP จะไม่ถูก mark ว่าใช้แล้ว
+func (u *registrationUsecase) ConfirmRegister(ctx context.Context, email, otp string) error {
+	return u.tx.WithinTx(ctx, func(ctx context.Context) error {
+		otpData, err := u.otp.VerifyAndGetMetadata(ctx, email, otp, "register")
+		if err != nil {
+			return err
+		}
+
+		if _, err := u.userRepo.GetByEmail(ctx, email); err == nil {
+			return ErrEmailTaken
+		} else if !errors.Is(err, apperr.ErrNotFound) {
+			return err
+		}
+
+		firstName := otpData["first_name"]
+		password := otpData["password"]
+		if firstName == "" || password == "" {
+			return ErrMissingRegistrationData
+		}
+
+		hashedPassword, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+		if err != nil {
+			return err
+		}
+
+		err = u.userRepo.Create(ctx, model.User{
+			FirstName: firstName,
+			Email:     email,
+			Password:  string(hashedPassword),
+		})
+		if database.IsDuplicate(err) {
+			return ErrEmailTaken
+		}
+		return err
+	})
+}
@@ -0,0 +1,96 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"myapp/internal/user/model"
+	"myapp/internal/user/repository"
+)
+
+// fakeTx จำว่าตอนนี้อยู่ใน transaction หรือไม่ และ commit ไปแล้วหรือยัง
+type fakeTx struct {
+	inTx      bool
+	committed bool
+}
+
+func (t *fakeTx) WithinTx(ctx context.Context, fn func(ctx context.Context) error) error {
+	t.inTx = true
+	err := fn(ctx)
+	t.inTx = false
+	t.committed = err == nil
+	return err
+}
+
+type createRepo struct {
+	repository.UserRepository
+	err error
+}
+
+func (r *createRepo) Create(ctx context.Context, user model.User) error { return r.err }
+
+// recordingOTP บันทึกว่า OTP ถูกออก/ส่งตอนไหนเทียบกับ transaction
+type recordingOTP struct {
+	OTPUsecase
+	tx           *fakeTx
+	deliverErr   error
+	issuedInTx   bool
+	delivered    bool
+	deliveredTx  bool
+	deliveredOTP string
+}
+
+func (o *recordingOTP) IssueOTP(ctx context.Context, email, action string) (string, error) {
+	o.issuedInTx = o.tx.inTx
+	return "123456", nil
+}
+
+func (o *recordingOTP) DeliverOTP(ctx context.Context, email, action, otp string) error {
+	o.delivered = true
+	o.deliveredTx = o.tx.inTx || !o.tx.committed
+	o.deliveredOTP = otp
+	return o.deliverErr
+}
+
+func TestRegisterSendsEmailAfterCommit(t *testing.T) {
+	tests := []struct {
+		name        string
+		createErr   error
+		deliverErr  error
+		wantErr     bool
+		wantDeliver bool
+	}{
+		{name: "delivers after commit", wantDeliver: true},
+		{name: "delivery failure still registers", deliverErr: ErrOTPDelivery, wantDeliver: true},
+		{name: "failed insert sends nothing", createErr: errors.New("insert failed"), wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tx := &fakeTx{}
+			otp := &recordingOTP{tx: tx, deliverErr: tt.deliverErr}
+			u := NewRegistrationUsecase(tx, &createRepo{err: tt.createErr}, otp)
+
+			err := u.Register(context.Background(), model.User{Email: "a@example.com"})
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Register error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if otp.delivered != tt.wantDeliver {
+				t.Fatalf("delivered = %v, want %v", otp.delivered, tt.wantDeliver)
+			}
+			if !tt.wantDeliver {
+				return
+			}
+			if !otp.issuedInTx {
+				t.Error("OTP was not saved inside the transaction")
+			}
+			if otp.deliveredTx {
+				t.Error("email was sent before the transaction committed")
+			}
+			if otp.deliveredOTP != "123456" {
+				t.Errorf("delivered otp = %q, want the issued one", otp.deliveredOTP)
+			}
+		})
+	}
+}
@@ -1,62 +1,127 @@
 package usecase
 
 import (
-	"errors"
+	"context"
+	"log/slog"
+	"myapp/internal/apperr"
+	"myapp/internal/database"
+	"myapp/internal/logging"
+	reviewRepo "myapp/internal/review/repository"
+	"myapp/internal/storage"
+	"myapp/internal/upload"
 	"myapp/internal/user/model"
 	"myapp/internal/user/repository"
 )
 
-type UserUsecase interface {
-	GetAll() ([]model.User, error)
-	GetByID(id int64) (model.User, error)
-	Create(user model.User) error
-	Update(user model.User) error
-	Delete(id int64) error
-	GetByEmail(email string) (model.User, error)
-	UpdateEmail(id int64, email string) error
-	UpdatePassword(id int64, hashedPassword string) error
-	IsEmailTaken(email string, excludeID int64) (bool, error)
-	UpdateProfilePhoto(id int64, photoPath string) error // ✅ เพิ่ม
+var ErrEmailTaken = apperr.Conflict("email_taken", "Email is already in use")
 
+type UserUsecase interface {
+	List(ctx context.Context, f model.UserFilter, opts database.ListOptions) (database.Page[model.User], error)
+	GetByID(ctx context.Context, id int64) (model.User, error)
+	Create(ctx context.Context, user model.User) error
+	Update(ctx context.Context, user model.User) error
+	Delete(ctx context.Context, id int64) error
+	GetByEmail(ctx context.Context, email string) (model.User, error)
+	UpdateEmail(ctx context.Context, id int64, email string) error
+	UpdatePassword(ctx context.Context, id int64, hashedPassword string) error
+	IsEmailTaken(ctx context.Context, email string, excludeID int64) (bool, error)
+	// UpdateProfilePhoto เก็บรูปลง storage แล้วตั้งเป็นรูปโปรไฟล์ คืน object key ของรูปใหม่
+	UpdateProfilePhoto(ctx context.Context, id int64, photo upload.File) (string, error)
 }
 
 type userUsecase struct {
-	repo repository.UserRepository
+	tx          database.Transactor
+	repo        repository.UserRepository
+	refreshRepo repository.RefreshTokenRepository
+	reviews     reviewRepo.ReviewRepository
+	files       storage.Storage
+}
+
+func NewUserUsecase(tx database.Transactor, repo repository.UserRepository, refreshRepo repository.RefreshTokenRepository, reviews reviewRepo.ReviewRepository, files storage.Storage) UserUsecase {
+	return &userUsecase{tx: tx, repo: repo, refreshRepo: refreshRepo, reviews: reviews, files: files}
+}
+func (u *userUsecase) List(ctx context.Context, f model.UserFilter, opts database.ListOptions) (database.Page[model.User], error) {
+	return u.repo.List(ctx, f, opts)
+}
+func (u *userUsecase) GetByID(ctx context.Context, id int64) (model.User, error) {
+	return u.repo.GetByID(ctx, id)
+}
+func (u *userUsecase) Create(ctx context.Context, user model.User) error {
+	return u.repo.Create(ctx, user)
+}
+func (u *userUsecase) Update(ctx context.Context, user model.User) error {
+	return u.repo.Update(ctx, user)
 }
 
-func NewUserUsecase(repo repository.UserRepository) UserUsecase {
-	return &userUsecase{repo: repo}
+// Delete ลบผู้ใช้ (รีวิวถูกลบด้วย FK cascade) และหักคะแนนรีวิวของผู้ใช้ออกจากยอดสะสมของที่พัก ใน transaction เดียว
+func (u *userUsecase) Delete(ctx context.Context, id int64) error {
+	return u.tx.WithinTx(ctx, func(ctx context.Context) error {
+		if err := u.reviews.SubtractUserReviews(ctx, id); err != nil {
+			return err
+		}
+		return u.repo.Delete(ctx, id)
+	})
 }
-func (u *userUsecase) GetAll() ([]model.User, error)        { return u.repo.GetAll() }
-func (u *userUsecase) GetByID(id int64) (model.User, error) { return u.repo.GetByID(id) }
-func (u *userUsecase) Create(user model.User) error         { return u.repo.Create(user) }
-func (u *userUsecase) Update(user model.User) error         { return u.repo.Update(user) }
-func (u *userUsecase) Delete(id int64) error                { return u.repo.Delete(id) }
-func (u *userUsecase) GetByEmail(email string) (model.User, error) {
-	return u.repo.GetByEmail(email)
+func (u *userUsecase) GetByEmail(ctx context.Context, email string) (model.User, error) {
+	return u.repo.GetByEmail(ctx, email)
 }
 
-func (u *userUsecase) UpdateEmail(id int64, email string) error {
+func (u *userUsecase) UpdateEmail(ctx context.Context, id int64, email string) error {
 	// ตรวจสอบว่า email ซ้ำหรือไม่
-	taken, err := u.repo.IsEmailTaken(email, id)
+	taken, err := u.repo.IsEmailTaken(ctx, email, id)
 	if err != nil {
 		return err
 	}
 	if taken {
-		return errors.New("email is already in use")
+		return ErrEmailTaken
+	}
+
+	// unique key กันกรณีมี request อื่นแย่ง email เดียวกันหลังเช็กแล้ว
+	if err := u.repo.UpdateEmail(ctx, id, email); database.IsDuplicate(err) {
+		return ErrEmailTaken
+	} else if err != nil {
+		return err
 	}
+	return nil
+}
 
-	return u.repo.UpdateEmail(id, email)
+// UpdatePassword ใช้ทั้งเปลี่ยนรหัสผ่านและ reset password: revoke refresh token ทุกเครื่องด้วย
+func (u *userUsecase) UpdatePassword(ctx context.Context, id int64, hashedPassword string) error {
+	return u.tx.WithinTx(ctx, func(ctx context.Context) error {
+		if err := u.repo.UpdatePassword(ctx, id, hashedPassword); err != nil {
+			return err
+		}
+		return u.refreshRepo.RevokeAllForUser(ctx, id)
+	})
 }
 
-func (u *userUsecase) UpdatePassword(id int64, hashedPassword string) error {
-	return u.repo.UpdatePassword(id, hashedPassword)
+func (u *userUsecase) IsEmailTaken(ctx context.Context, email string, excludeID int64) (bool, error) {
+	return u.repo.IsEmailTaken(ctx, email, excludeID)
 }
 
-func (u *userUsecase) IsEmailTaken(email string, excludeID int64) (bool, error) {
-	return u.repo.IsEmailTaken(email, excludeID)
+// UpdateProfilePhoto เขียนไฟล์ก่อนแล้วค่อยบันทึก key; บันทึกไม่สำเร็จจะลบไฟล์ใหม่ทิ้ง สำเร็จแล้วจึงลบรูปเดิม
+func (u *userUsecase) UpdateProfilePhoto(ctx context.Context, id int64, photo upload.File) (string, error) {
+	key, err := upload.Put(ctx, u.files, "users", photo)
+	if err != nil {
+		return "", err
+	}
+
+	previous, err := u.repo.UpdateProfilePhoto(ctx, id, key)
+	if err != nil {
+		u.removeFile(ctx, key)
+		return "", err
+	}
+
+	// ✅ รูปเดิมที่เป็น URL ภายนอก (ข้อมูลก่อนใช้ object key) ไม่ได้อยู่ใน storage ของเรา
+	if previous != "" && previous != key && storage.ValidKey(previous) {
+		u.removeFile(ctx, previous)
+	}
+	return key, nil
 }
 
-func (u *userUsecase) UpdateProfilePhoto(id int64, photoPath string) error {
-	return u.repo.UpdateProfilePhoto(id, photoPath) // ไปเรียกที่ repository ต่อ
+// removeFile ลบไฟล์แบบ best effort; ถ้าลบไม่สำเร็จแค่เหลือไฟล์ค้าง ไม่กระทบข้อมูล
+func (u *userUsecase) removeFile(ctx context.Context, key string) {
+	if err := u.files.Delete(ctx, key); err != nil {
+		slog.WarnContext(ctx, "failed to remove profile photo file", "key", key, logging.Err(err))
+	}
 }
@@ -0,0 +1,115 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"io"
+	"slices"
+	"strings"
+	"testing"
+
+	"myapp/internal/apperr"
+	"myapp/internal/storage"
+	"myapp/internal/upload"
+	"myapp/internal/user/repository"
+)
+
+// memStorage คือ storage ในหน่วยความจำที่จำ key ที่ถูก Put/Delete
+type memStorage struct {
+	storage.Storage
+	objects map[string]bool
+	deleted []string
+}
+
+func (m *memStorage) Put(ctx context.Context, key string, body io.Reader, size int64, contentType string) error {
+	m.objects[key] = true
+	return nil
+}
+
+func (m *memStorage) Delete(ctx context.Context, key string) error {
+	delete(m.objects, key)
+	m.deleted = append(m.deleted, key)
+	return nil
+}
+
+// photoRepo จำลองเฉพาะ UpdateProfilePhoto ของ UserRepository
+type photoRepo struct {
+	repository.UserRepository
+	photo string
+	err   error
+}
+
+func (r *photoRepo) UpdateProfilePhoto(ctx context.Context, id int64, photoKey string) (string, error) {
+	if r.err != nil {
+		return "", r.err
+	}
+	previous := r.photo
+	r.photo = photoKey
+	return previous, nil
+}
+
+func TestUpdateProfilePhoto(t *testing.T) {
+	photo := upload.File{Data: []byte("jpeg"), ContentType: "image/jpeg", Ext: ".jpg"}
+
+	tests := []struct {
+		name        string
+		current     string
+		repoErr     error
+		wantErr     bool
+		wantDeleted func(newKey string) []string
+	}{
+		{
+			name:        "first photo removes nothing",
+			wantDeleted: func(string) []string { return nil },
+		},
+		{
+			name:        "replacing removes the previous file after the update",
+			current:     "users/old.jpg",
+			wantDeleted: func(string) []string { return []string{"users/old.jpg"} },
+		},
+		{
+			name:        "legacy URL is not a storage key",
+			current:     "https://example.com/old.jpg",
+			wantDeleted: func(string) []string { return nil },
+		},
+		{
+			name:        "failed update removes the new file and keeps the old one",
+			current:     "users/old.jpg",
+			repoErr:     apperr.NotFound("User not found"),
+			wantErr:     true,
+			wantDeleted: func(newKey string) []string { return []string{newKey} },
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			files := &memStorage{objects: map[string]bool{}}
+			repo := &photoRepo{photo: tt.current, err: tt.repoErr}
+			u := NewUserUsecase(nil, repo, nil, nil, files)
+
+			key, err := u.UpdateProfilePhoto(context.Background(), 1, photo)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			var newKey string
+			for k := range files.objects {
+				newKey = k
+			}
+			if tt.wantErr {
+				if len(files.deleted) != 1 || !strings.HasPrefix(files.deleted[0], "users/") {
+					t.Fatalf("deleted = %v, want the uploaded file", files.deleted)
+				}
+				newKey = files.deleted[0]
+				if !errors.Is(err, tt.repoErr) {
+					t.Errorf("error = %v, want %v", err, tt.repoErr)
+				}
+			} else if key != newKey || repo.photo != key {
+				t.Errorf("key = %q, stored %q, repo has %q", key, newKey, repo.photo)
+			}
+			if want := tt.wantDeleted(newKey); !slices.Equal(files.deleted, want) {
+				t.Errorf("deleted = %v, want %v", files.deleted, want)
+			}
+		})
+	}
+}
@@ -0,0 +1,232 @@
+// Package validate ตรวจ request DTO ตาม struct tag `validate:"..."`
+//
+// กฎที่รองรับ (คั่นด้วย comma):
+//
+//	required        ต้องไม่เป็นค่าว่าง (string ที่มีแต่ช่องว่างถือว่าว่าง, pointer ต้องไม่ nil)
+//	email           รูปแบบอีเมล
+//	min=N / max=N   ความยาวของ string (นับเป็นตัวอักษร) หรือจำนวนสมาชิกของ slice/map
+//	gte=N / lte=N   ช่วงของตัวเลข
+//	oneof=a b c     ค่าต้องเป็นหนึ่งในรายการ
+//	digits          string ที่มีแต่ตัวเลข
+//
+// field ที่เป็น pointer และเป็น nil จะข้ามกฎอื่นทั้งหมดถ้าไม่มี required
+// ชื่อ field ในผลลัพธ์ใช้ชื่อจาก json tag เพื่อให้ตรงกับที่ client ส่งมา
+package validate
+
+import (
+	"fmt"
+	"net/mail"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+	"unicode/utf8"
+)
+
+type rule struct {
+	name  string
+	param string
+}
+
+type field struct {
+	index []int
+	name  string
+	rules []rule
+}
+
+var cache sync.Map // reflect.Type -> []field
+
+// Struct คืนข้อความ error ราย field (nil ถ้าผ่านทั้งหมด); v ต้องเป็น struct หรือ pointer ของ struct
+func Struct(v any) map[string]string {
+	rv := reflect.Indirect(reflect.ValueOf(v))
+	if rv.Kind() != reflect.Struct {
+		panic(fmt.Sprintf("validate: expected struct, got %s", rv.Kind()))
+	}
+
+	errs := map[string]string{}
+	check(rv, "", errs)
+	if len(errs) == 0 {
+		return nil
+	}
+	return errs
+}
+
+func check(rv reflect.Value, prefix string, errs map[string]string) {
+	for _, f := range fields(rv.Type()) {
+		fv := rv.FieldByIndex(f.index)
+		name := prefix + f.name
+
+		if msg := apply(fv, f.rules); msg != "" {
+			errs[name] = msg
+			continue
+		}
+
+		// ✅ ตรวจ struct ซ้อนต่อ (เช่น filter ที่เป็น object)
+		inner := reflect.Indirect(fv)
+		if inner.IsValid() && inner.Kind() == reflect.Struct && inner.Type().PkgPath() != "time" {
+			check(inner, name+".", errs)
+		}
+	}
+}
+
+func apply(fv reflect.Value, rules []rule) string {
+	if fv.Kind() == reflect.Pointer {
+		if fv.IsNil() {
+			if hasRule(rules, "required") {
+				return "is required"
+			}
+			return ""
+		}
+		fv = fv.Elem()
+	}
+
+	for _, r := range rules {
+		if msg := applyRule(fv, r); msg != "" {
+			return msg
+		}
+	}
+	return ""
+}
+
+func applyRule(fv reflect.Value, r rule) string {
+	switch r.name {
+	case "required":
+		if fv.Kind() == reflect.String && strings.TrimSpace(fv.String()) == "" || fv.IsZero() {
+			return "is required"
+		}
+	case "email":
+		s := fv.String()
+		if s == "" {
+			return ""
+		}
+		addr, err := mail.ParseAddress(s)
+		if err != nil || addr.Address != s || !strings.Contains(s[strings.LastIndex(s, "@"):], ".") {
+			return "must be a valid email address"
+		}
+	case "digits":
+		for _, c := range fv.String() {
+			if c < '0' || c > '9' {
+				return "must contain only digits"
+			}
+		}
+	case "min", "max":
+		n := mustInt(r)
+		l, unit := length(fv)
+		if r.name == "min" && l < n {
+			return fmt.Sprintf("must be at least %d %s", n, unit)
+		}
+		if r.name == "max" && l > n {
+			return fmt.Sprintf("must be at most %d %s", n, unit)
+		}
+	case "gte", "lte":
+		limit, err := strconv.ParseFloat(r.param, 64)
+		if err != nil {
+			panic(fmt.Sprintf("validate: %s needs a number, got %q", r.name, r.param))
+		}
+		v := number(fv)
+		if r.name == "gte" && v < limit {
+			return "must be greater than or equal to " + r.param
+		}
+		if r.name == "lte" && v > limit {
+			return "must be less than or equal to " + r.param
+		}
+	case "oneof":
+		options := strings.Fields(r.param)
+		s := fmt.Sprint(fv.Interface())
+		for _, o := range options {
+			if s == o {
+				return ""
+			}
+		}
+		return "must be one of: " + strings.Join(options, ", ")
+	default:
+		panic(fmt.Sprintf("validate: unknown rule %q", r.name))
+	}
+	return ""
+}
+
+func length(fv reflect.Value) (int, string) {
+	switch fv.Kind() {
+	case reflect.String:
+		return utf8.RuneCountInString(fv.String()), "characters"
+	case reflect.Slice, reflect.Map, reflect.Array:
+		return fv.Len(), "items"
+	}
+	panic(fmt.Sprintf("validate: min/max not supported on %s", fv.Kind()))
+}
+
+func number(fv reflect.Value) float64 {
+	switch {
+	case fv.CanInt():
+		return float64(fv.Int())
+	case fv.CanUint():
+		return float64(fv.Uint())
+	case fv.CanFloat():
+		return fv.Float()
+	}
+	panic(fmt.Sprintf("validate: gte/lte not supported on %s", fv.Kind()))
+}
+
+func mustInt(r rule) int {
+	n, err := strconv.Atoi(r.param)
+	if err != nil {
+		panic(fmt.Sprintf("validate: %s needs an integer, got %q", r.name, r.param))
+	}
+	return n
+}
+
+func hasRule(rules []rule, name string) bool {
+	for _, r := range rules {
+		if r.name == name {
+			return true
+		}
+	}
+	return false
+}
+
+// fields อ่าน tag ของ type ครั้งเดียวแล้วเก็บ cache ไว้
+func fields(t reflect.Type) []field {
+	if cached, ok := cache.Load(t); ok {
+		return cached.([]field)
+	}
+
+	var list []field
+	for _, sf := range reflect.VisibleFields(t) {
+		if !sf.IsExported() || sf.Anonymous {
+			continue
+		}
+		tag := sf.Tag.Get("validate")
+		inner := sf.Type
+		if inner.Kind() == reflect.Pointer {
+			inner = inner.Elem()
+		}
+		if tag == "" && inner.Kind() != reflect.Struct {
+			continue
+		}
+		list = append(list, field{index: sf.Index, name: jsonName(sf), rules: parse(tag)})
+	}
+
+	cache.Store(t, list)
+	return list
+}
+
+func parse(tag string) []rule {
+	var rules []rule
+	for _, part := range strings.Split(tag, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		name, param, _ := strings.Cut(part, "=")
+		rules = append(rules, rule{name: name, param: param})
+	}
+	return rules
+}
+
+func jsonName(sf reflect.StructField) string {
+	name, _, _ := strings.Cut(sf.Tag.Get("json"), ",")
+	if name == "" || name == "-" {
+		return sf.Name
+	}
+	return name
+}
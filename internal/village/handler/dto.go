@@ -0,0 +1,29 @@
+package handler
+
+import "myapp/internal/village/model"
+
+// VillageRequest คือ body ของ POST /villages
+type VillageRequest struct {
+	Name       string `json:"name" validate:"required,max=255"`
+	DistrictID int64  `json:"district_id" validate:"required,gte=1"`
+}
+
+// UpdateVillageRequest คือ body ของ PUT /villages (ระบุ id ใน body)
+type UpdateVillageRequest struct {
+	ID int64 `json:"id" validate:"required,gte=1"`
+	VillageRequest
+}
+
+func (req VillageRequest) toModel() model.Village {
+	return model.Village{Name: req.Name, DistrictID: req.DistrictID}
+}
+
+type VillageResponse struct {
+	ID         int64  `json:"id"`
+	Name       string `json:"name"`
+	DistrictID int64  `json:"district_id"`
+}
+
+func newVillageResponse(v model.Village) VillageResponse {
+	return VillageResponse{ID: v.ID, Name: v.Name, DistrictID: v.DistrictID}
+}
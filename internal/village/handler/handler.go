@@ -0,0 +1,90 @@
+package handler
+
+import (
+	"myapp/internal/httpx"
+	"net/http"
+
+	"myapp/internal/database"
+	"myapp/internal/village/model"
+	"myapp/internal/village/usecase"
+)
+
+type VillageHandler struct {
+	Usecase usecase.VillageUsecase
+}
+
+func NewVillageHandler(u usecase.VillageUsecase) *VillageHandler {
+	return &VillageHandler{Usecase: u}
+}
+
+// GetAll รองรับ ?district_id=&limit=&offset=&cursor=&sort=
+func (h *VillageHandler) GetAll(w http.ResponseWriter, r *http.Request) {
+	opts, err := httpx.ListOptions(r)
+	if err != nil {
+		httpx.WriteError(w, r, err)
+		return
+	}
+	districtID, err := httpx.QueryID(r, "district_id")
+	if err != nil {
+		httpx.WriteError(w, r, err)
+		return
+	}
+
+	page, err := h.Usecase.List(r.Context(), model.VillageFilter{DistrictID: districtID}, opts)
+	if err != nil {
+		httpx.WriteError(w, r, err)
+		return
+	}
+	httpx.WriteJSON(w, http.StatusOK, database.MapPage(page, newVillageResponse))
+}
+
+func (h *VillageHandler) GetByID(w http.ResponseWriter, r *http.Request) {
+	id, err := httpx.PathID(r, "id")
+	if err != nil {
+		httpx.WriteError(w, r, err)
+		return
+	}
+	data, err := h.Usecase.GetByID(r.Context(), id)
+	if err != nil {
+		httpx.WriteError(w, r, err)
+		return
+	}
+	httpx.WriteJSON(w, http.StatusOK, newVillageResponse(data))
+}
+
+func (h *VillageHandler) Create(w http.ResponseWriter, r *http.Request) {
+	var req VillageRequest
+	if err := httpx.Decode(w, r, &req); err != nil {
+		httpx.WriteError(w, r, err)
+		return
+	}
+	if err := h.Usecase.Create(r.Context(), req.toModel()); err != nil {
+		httpx.WriteError(w, r, err)
+		return
+	}
+	w.WriteHeader(http.StatusCreated)
+}
+
+func (h *VillageHandler) Update(w http.ResponseWriter, r *http.Request) {
+	var req UpdateVillageRequest
+	if err := httpx.Decode(w, r, &req); err != nil {
+		httpx.WriteError(w, r, err)
+		return
+	}
+	v := req.toModel()
+	v.ID = req.ID
+	if err := h.Usecase.Update(r.Context(), v); err != nil {
+		httpx.WriteError(w, r, err)
+	}
+}
+
+func (h *VillageHandler) Delete(w http.ResponseWriter, r *http.Request) {
+	id, err := httpx.PathID(r, "id")
+	if err != nil {
+		httpx.WriteError(w, r, err)
+		return
+	}
+	if err := h.Usecase.Delete(r.Context(), id); err != nil {
+		httpx.WriteError(w, r, err)
+	}
+}
@@ -0,0 +1,12 @@
+package model
+
+type Village struct {
+	ID         int64  `json:"id"`
+	Name       string `json:"name"`
+	DistrictID int64  `json:"district_id"`
+}
+
+// VillageFilter คือเงื่อนไขของ list (field ที่เป็น nil = ไม่กรอง)
+type VillageFilter struct {
+	DistrictID *int64
+}
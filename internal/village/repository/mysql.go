@@ -0,0 +1,93 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"myapp/internal/database"
+	"myapp/internal/village/model"
+)
+
+type VillageRepository interface {
+	List(ctx context.Context, f model.VillageFilter, opts database.ListOptions) (database.Page[model.Village], error)
+	GetByID(ctx context.Context, id int64) (model.Village, error)
+	Exists(ctx context.Context, id int64) (bool, error)
+	Create(ctx context.Context, v model.Village) error
+	Update(ctx context.Context, v model.Village) error
+	Delete(ctx context.Context, id int64) error
+}
+
+type villageRepo struct {
+	db *database.DB
+}
+
+func NewVillageRepository(db *database.DB) VillageRepository {
+	return &villageRepo{db: db}
+}
+
+// villageList คือ sort key ที่ client ใช้ได้ใน ?sort= (ใส่ "-" นำหน้าเพื่อเรียงมากไปน้อย)
+var villageList = database.ListSpec[model.Village]{
+	IDColumn: "village_id",
+	ID:       func(v model.Village) any { return v.ID },
+	Sorts: map[string]database.SortKey[model.Village]{
+		"id":          {Column: "village_id", Value: func(v model.Village) any { return v.ID }},
+		"name":        {Column: "name", Value: func(v model.Village) any { return v.Name }},
+		"district_id": {Column: "district_id", Value: func(v model.Village) any { return v.DistrictID }},
+	},
+	DefaultSort: "id",
+	Scan: func(rows *sql.Rows) (model.Village, error) {
+		var v model.Village
+		err := rows.Scan(&v.ID, &v.Name, &v.DistrictID)
+		return v, err
+	},
+}
+
+func (r *villageRepo) List(ctx context.Context, f model.VillageFilter, opts database.ListOptions) (database.Page[model.Village], error) {
+	q := database.Select("village", "village_id", "name", "district_id")
+	if f.DistrictID != nil {
+		q.Where("district_id = ?", *f.DistrictID)
+	}
+	return database.List(ctx, r.db, q, villageList, opts)
+}
+
+func (r *villageRepo) GetByID(ctx context.Context, id int64) (model.Village, error) {
+	ctx, cancel := r.db.WithTimeout(ctx)
+	defer cancel()
+
+	var v model.Village
+	err := r.db.Conn(ctx).QueryRowContext(ctx, "SELECT village_id, name, district_id FROM village WHERE village_id=?", id).
+		Scan(&v.ID, &v.Name, &v.DistrictID)
+	return v, database.NotFound(err, "Village not found")
+}
+
+func (r *villageRepo) Exists(ctx context.Context, id int64) (bool, error) {
+	ctx, cancel := r.db.WithTimeout(ctx)
+	defer cancel()
+
+	var exists bool
+	err := r.db.Conn(ctx).QueryRowContext(ctx, "SELECT EXISTS(SELECT 1 FROM village WHERE village_id=?)", id).Scan(&exists)
+	return exists, err
+}
+
+func (r *villageRepo) Create(ctx context.Context, v model.Village) error {
+	ctx, cancel := r.db.WithTimeout(ctx)
+	defer cancel()
+
+	_, err := r.db.Conn(ctx).ExecContext(ctx, "INSERT INTO village (name, district_id) VALUES (?, ?)", v.Name, v.DistrictID)
+	return err
+}
+
+func (r *villageRepo) Update(ctx context.Context, v model.Village) error {
+	ctx, cancel := r.db.WithTimeout(ctx)
+	defer cancel()
+
+	_, err := r.db.Conn(ctx).ExecContext(ctx, "UPDATE village SET name=?, district_id=? WHERE village_id=?", v.Name, v.DistrictID, v.ID)
+	return err
+}
+
+func (r *villageRepo) Delete(ctx context.Context, id int64) error {
+	ctx, cancel := r.db.WithTimeout(ctx)
+	defer cancel()
+
+	res, err := r.db.Conn(ctx).ExecContext(ctx, "DELETE FROM village WHERE village_id=?", id)
+	return database.RequireAffected(res, err, "Village not found")
+}
@@ -0,0 +1,44 @@
+package router
+
+import (
+	"github.com/gorilla/mux"
+
+	"myapp/internal/auth"
+	"myapp/internal/database"
+	districtRepo "myapp/internal/district/repository"
+	villageHandler "myapp/internal/village/handler"
+	villageRepo "myapp/internal/village/repository"
+	villageUsecase "myapp/internal/village/usecase"
+)
+
+type Module struct {
+	handler *villageHandler.VillageHandler
+	tokens  *auth.TokenManager
+}
+
+func NewModule(db *database.DB, tokens *auth.TokenManager) *Module {
+	vRepo := villageRepo.NewVillageRepository(db)
+	vUC := villageUsecase.NewVillageUsecase(vRepo, districtRepo.NewDistrictRepository(db))
+	return &Module{
+		handler: villageHandler.NewVillageHandler(vUC),
+		tokens:  tokens,
+	}
+}
+
+func (m *Module) Name() string { return "village" }
+
+func (m *Module) RegisterRoutes(r *mux.Router) {
+	vH := m.handler
+
+	// ✅ Village routes
+	r.HandleFunc("/villages", vH.GetAll).Methods("GET")
+	r.HandleFunc("/villages/{id}", vH.GetByID).Methods("GET")
+
+	protected := r.NewRoute().Subrouter()
+	protected.Use(auth.Middleware(m.tokens))
+	adminOnly := auth.Roles(auth.RoleAdmin)
+
+	protected.Handle("/villages", auth.Authorize(adminOnly, vH.Create)).Methods("POST")
+	protected.Handle("/villages", auth.Authorize(adminOnly, vH.Update)).Methods("PUT")
+	protected.Handle("/villages/{id}", auth.Authorize(adminOnly, vH.Delete)).Methods("DELETE")
+}
@@ -0,0 +1,65 @@
+package usecase
+
+import (
+	"context"
+	"myapp/internal/apperr"
+	"myapp/internal/database"
+	districtRepo "myapp/internal/district/repository"
+	"myapp/internal/village/model"
+	"myapp/internal/village/repository"
+)
+
+type VillageUsecase interface {
+	List(ctx context.Context, f model.VillageFilter, opts database.ListOptions) (database.Page[model.Village], error)
+	GetByID(ctx context.Context, id int64) (model.Village, error)
+	Create(ctx context.Context, m model.Village) error
+	Update(ctx context.Context, m model.Village) error
+	Delete(ctx context.Context, id int64) error
+}
+
+type villageUsecase struct {
+	repo      repository.VillageRepository
+	districts districtRepo.DistrictRepository
+}
+
+func NewVillageUsecase(r repository.VillageRepository, districts districtRepo.DistrictRepository) VillageUsecase {
+	return &villageUsecase{repo: r, districts: districts}
+}
+
+func (u *villageUsecase) List(ctx context.Context, f model.VillageFilter, opts database.ListOptions) (database.Page[model.Village], error) {
+	return u.repo.List(ctx, f, opts)
+}
+
+func (u *villageUsecase) GetByID(ctx context.Context, id int64) (model.Village, error) {
+	return u.repo.GetByID(ctx, id)
+}
+
+func (u *villageUsecase) Create(ctx context.Context, v model.Village) error {
+	if err := u.checkDistrict(ctx, v.DistrictID); err != nil {
+		return err
+	}
+	return u.repo.Create(ctx, v)
+}
+
+func (u *villageUsecase) Update(ctx context.Context, v model.Village) error {
+	if err := u.checkDistrict(ctx, v.DistrictID); err != nil {
+		return err
+	}
+	return u.repo.Update(ctx, v)
+}
+
+func (u *villageUsecase) Delete(ctx context.Context, id int64) error {
+	return u.repo.Delete(ctx, id)
+}
+
+// checkDistrict ตรวจ foreign key ก่อนเขียน เพื่อคืน validation error ที่ระบุ field ได้
+func (u *villageUsecase) checkDistrict(ctx context.Context, id int64) error {
+	ok, err := u.districts.Exists(ctx, id)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return apperr.Validation("District does not exist", map[string]string{"district_id": "does not exist"})
+	}
+	return nil
+}
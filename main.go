@@ -1,85 +1,122 @@
 package main
 
 import (
+	"context"
 	"database/sql"
 	"fmt"
 	_ "github.com/go-sql-driver/mysql"
-	"github.com/joho/godotenv"
-	"log"
-	notification "myapp/internal/notification/routes"
-	user "myapp/internal/user/routes"
-	"net/http"
+	"log/slog"
+	"myapp/internal/app"
+	"myapp/internal/config"
+	"myapp/internal/logging"
+	"myapp/internal/migrate"
+	"myapp/internal/storage"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
 )
 
 func main() {
-	godotenv.Load()
-	// setup IP  & Log API server
-	ip := "192.168.80.213"
-	log.Println("🚀 Starting API server...")
+	// ✅ โหลด config จาก .env / CONFIG_FILE / environment
+	cfg, err := config.Load()
+	if err != nil {
+		fatal("invalid configuration", err)
+	}
+
+	// ✅ ตั้ง slog เป็น logger หลัก (level/format จาก LOG_LEVEL, LOG_FORMAT)
+	logging.Setup(cfg.Log, os.Stderr)
+	slog.Info("starting API server")
 
-	// Config    username ,pwd,database
-	dsn := fmt.Sprintf("jimmy:admin123@tcp(%s:3306)/flutterprojecttt?parseTime=true", ip)
-	// Log Connecting to Database
-	log.Println("🔌 Connecting to MySQL:", dsn)
+	// Log Connecting to Database (ไม่ log password)
+	slog.Info("connecting to MySQL", "database", cfg.DB.Redacted())
 
 	// show Error when Errors
-	db, err := sql.Open("mysql", dsn)
+	db, err := sql.Open("mysql", cfg.DB.DSN())
 	if err != nil {
-		log.Fatal("❌ Failed to connect to database:", err)
+		fatal("failed to connect to database", err)
 	}
-	defer db.Close()
 	// show Error when Database not responding
 	if err := db.Ping(); err != nil {
-		log.Fatal("❌ Database not responding:", err)
+		fatal("database not responding", err)
 	}
-	log.Println("✅ Connected to MySQL database")
+	slog.Info("connected to MySQL database")
 
-	// Init router from user module
-	r := user.InitRouter(db)
-	notification.RegisterNotificationRoutes(r, db) // ✅ เพิ่มตรงนี้
+	// ✅ Subcommand: go run . migrate up|down|status
+	if len(os.Args) > 1 && os.Args[1] == "migrate" {
+		err := runMigrate(db, os.Args[2:])
+		db.Close()
+		if err != nil {
+			fatal("migration failed", err)
+		}
+		return
+	}
 
-	// ✅ Wrap with CORS middleware
-	handler := corsMiddleware(r)
+	// ✅ ที่เก็บไฟล์ที่ upload ตาม STORAGE_DRIVER (local หรือ s3)
+	files, err := storage.New(cfg.Storage)
+	if err != nil {
+		fatal("invalid storage configuration", err)
+	}
+	slog.Info("file storage ready", "driver", cfg.Storage.Driver)
 
-	log.Println("🌐 Server running at http://0.0.0.0:5000")
-	log.Fatal(http.ListenAndServe("0.0.0.0:5000", handler))
+	// ✅ สร้าง app และ mount ทุก module (user, accommodation, district, notification)
+	application := app.New(cfg, db, files)
 
-	log.Println("✅ Routes initialized:")
-	log.Println(" - PUT /users/profile-photo")
+	// ✅ SIGINT / SIGTERM => graceful shutdown (drain request ก่อนปิด DB)
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
 
+	if err := application.Run(ctx); err != nil {
+		fatal("server error", err)
+	}
 }
 
-func corsMiddleware(next http.Handler) http.Handler {
-	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		defer func() {
-			if err := recover(); err != nil {
-				log.Printf("🔥 Recovered from panic: %v\n", err)
-				http.Error(w, "Internal Server Error", http.StatusInternalServerError)
-			}
-		}()
-		log.Printf("🌐 Incoming request: %s %s, Content-Type: %s", r.Method, r.URL.Path, r.Header.Get("Content-Type"))
-		log.Printf("🌐 Incoming request: %s %s", r.Method, r.URL.Path)
-		w.Header().Set("Access-Control-Allow-Origin", "*")
-		w.Header().Set("Access-Control-Allow-Headers", "Content-Type, Authorization")
-		w.Header().Set("Access-Control-Allow-Methods", "GET, POST, PUT, PATCH, DELETE, OPTIONS")
+func runMigrate(db *sql.DB, args []string) error {
+	if len(args) != 1 {
+		return fmt.Errorf("usage: %s migrate up|down|status", os.Args[0])
+	}
 
-		if r.Method == http.MethodOptions {
-			w.WriteHeader(http.StatusOK)
-			return
-		}
+	m, err := migrate.New(db)
+	if err != nil {
+		return err
+	}
 
-		next.ServeHTTP(w, r)
-	})
+	switch args[0] {
+	case "up":
+		applied, err := m.Up()
+		if err != nil {
+			return err
+		}
+		slog.Info("migrations applied", "count", len(applied))
+	case "down":
+		reverted, err := m.Down()
+		if err != nil {
+			return err
+		}
+		if reverted == nil {
+			slog.Info("nothing to revert")
+			return nil
+		}
+		slog.Info("migration reverted", "version", reverted.Version, "name", reverted.Name)
+	case "status":
+		list, err := m.Status()
+		if err != nil {
+			return err
+		}
+		for _, s := range list {
+			state := "pending"
+			if s.AppliedAt != nil {
+				state = "applied " + s.AppliedAt.Format(time.RFC3339)
+			}
+			fmt.Printf("%04d_%-30s %s\n", s.Version, s.Name, state)
+		}
+	default:
+		return fmt.Errorf("unknown migrate command %q (want up, down or status)", args[0])
+	}
+	return nil
 }
 
-// go func() {
-// 	for {
-// 		time.Sleep(5 * time.Minute) // ✅ ทุกๆ 5 นาที
-// 		_, err := db.Exec("DELETE FROM otps WHERE expires_at < NOW()")
-// 		if err != nil {
-// 			log.Println("❌ Failed to delete expired OTPs:", err)
-// 		} else {
-// 			log.Println("🧹 Expired OTPs deleted successfully")
-// 		}
-// 	}
-// }
+func fatal(msg string, err error) {
+	slog.Error(msg, logging.Err(err))
+	os.Exit(1)
+}